@@ -194,7 +194,9 @@ func HashAnyToSVMMessage(msg ccip_offramp.Any2SVMRampMessage, onRampAddress []by
 	return hash.Sum(nil), nil
 }
 
-// merkleFrom computes the Merkle root from a slice of byte slices.
+// MerkleFrom computes the Merkle root from a slice of byte slices.
+// It builds the same tree as the chainlink-ccip pkg/merkle package (both use merklemulti), which should be used
+// instead once this module depends on a chainlink-ccip version that includes it.
 func MerkleFrom(leaves [][32]byte) ([32]byte, error) {
 	tree, err := merklemulti.NewTree(hashutil.NewKeccak(), leaves)
 	if err != nil {
@@ -3,11 +3,15 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
@@ -21,9 +25,20 @@ type arguments struct {
 	files         []string
 	logType       parse.LogType
 	formatterName string
+	outputDir     string
+	fields        []string
+
+	messageID   string
+	sourceChain uint64
+	seqNum      uint64
+
+	follow             bool
+	followSkipExisting bool
+	followInterval     time.Duration
 
 	filter.CompiledFilterFields
-	filterOP filter.FilterOP
+	filterOP   filter.FilterOP
+	filterExpr filter.Expr
 }
 
 func makeCommand() *cli.Command {
@@ -31,12 +46,37 @@ func makeCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "carpenter",
 		Usage: "A tool for parsing and displaying logs",
+		Commands: []*cli.Command{
+			makeDecodeCommand(),
+		},
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
-				Name:        "filename",
-				Usage:       "Provide one or more files to read. If not provided, reads from stdin.",
+				Name: "filename",
+				Usage: "Provide one or more files, directories or glob patterns to read. If not provided, reads from stdin. " +
+					"Compressed (gzip, zstd) files are decompressed and lines from multiple files are interleaved by timestamp.",
 				Destination: &args.files,
 			},
+			&cli.BoolFlag{
+				Name:    "follow",
+				Aliases: []string{"F"},
+				Usage: "Keep reading the files as they grow, like 'tail -F'. Rotated or truncated files are followed. " +
+					"Requires --filename, stdin cannot be followed.",
+				Category:    "follow",
+				Destination: &args.follow,
+			},
+			&cli.BoolFlag{
+				Name:        "follow-new-only",
+				Usage:       "When following, skip the existing file contents and only process new lines.",
+				Category:    "follow",
+				Destination: &args.followSkipExisting,
+			},
+			&cli.DurationFlag{
+				Name:        "follow-interval",
+				Usage:       "How often followed files are checked for new data.",
+				Category:    "follow",
+				Value:       stream.DefaultFollowPollInterval,
+				Destination: &args.followInterval,
+			},
 			&cli.StringFlag{
 				Name:             "logType",
 				Usage:            "Specify the type of log to parse, valid options: json, mixed, ci",
@@ -69,6 +109,34 @@ func makeCommand() *cli.Command {
 					return nil
 				},
 			},
+			&cli.StringFlag{
+				Name:        "output-dir",
+				Usage:       "Directory for formatters that write files (i.e. otlp), defaults to stdout.",
+				Destination: &args.outputDir,
+			},
+			&cli.StringSliceFlag{
+				Name:        "fields",
+				Usage:       "Logger fields to include with the ndjson and csv formats, nested fields are separated with dots (outcome.outcomeType).",
+				Destination: &args.fields,
+			},
+			&cli.StringFlag{
+				Name:        "message-id",
+				Usage:       "Message to follow with the lifecycle format.",
+				Category:    "lifecycle",
+				Destination: &args.messageID,
+			},
+			&cli.UintFlag{
+				Name:        "source-chain",
+				Usage:       "Source chain selector of the message to follow with the lifecycle format, requires --seq-num.",
+				Category:    "lifecycle",
+				Destination: &args.sourceChain,
+			},
+			&cli.UintFlag{
+				Name:        "seq-num",
+				Usage:       "Sequence number of the message to follow with the lifecycle format, requires --source-chain.",
+				Category:    "lifecycle",
+				Destination: &args.seqNum,
+			},
 			&cli.StringSliceFlag{
 				Name:    "filter",
 				Aliases: []string{"f"},
@@ -85,6 +153,20 @@ func makeCommand() *cli.Command {
 					return nil
 				},
 			},
+			&cli.StringFlag{
+				Name:    "where",
+				Aliases: []string{"w"},
+				Usage: "Boolean filter expression, i.e. " +
+					"'(Plugin==Commit OR Plugin==Execute) AND SequenceNumber>=100 AND NOT fields.sourceChain==5009297550715157269'. " +
+					"Supports AND, OR, NOT, parentheses, the operators [==, !=, <, <=, >, >=, : (regexp)], " +
+					"the --filter fields and any logger field as fields.<name>. Combined with --filter using AND.",
+				Category: "filters",
+				Validator: func(s string) error {
+					var err error
+					args.filterExpr, err = filter.ParseExpr(s)
+					return err
+				},
+			},
 			&cli.StringFlag{
 				Name: "filter-op",
 				Usage: fmt.Sprintf(
@@ -105,20 +187,30 @@ func makeCommand() *cli.Command {
 			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return run(args)
+			return run(ctx, args)
 		},
 	}
 }
 
-func run(args arguments) error {
-	var options stream.InputOptions
+func run(ctx context.Context, args arguments) error {
+	options := stream.InputOptions{
+		Follow:             args.follow,
+		FollowSkipExisting: args.followSkipExisting,
+		FollowPollInterval: args.followInterval,
+	}
 
 	// If no files are provided the stream will read from stdin.
 	if len(args.files) != 0 {
 		options.Filenames = args.files
 	}
 
-	formatter, err := format.GetFormatter(args.formatterName, format.Options{})
+	formatter, err := format.GetFormatter(args.formatterName, format.Options{
+		OutputDir:   args.outputDir,
+		MessageID:   args.messageID,
+		SourceChain: args.sourceChain,
+		SeqNum:      args.seqNum,
+		Fields:      args.fields,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to get formatter: %w", err)
 	}
@@ -127,14 +219,38 @@ func run(args arguments) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize input stream: %w", err)
 	}
+	defer inputStream.Close()
+
+	if args.follow {
+		// A followed stream never ends on its own, stop it when the command is
+		// interrupted so that the formatter can still be closed. Other modes
+		// keep the default signal handling so that an interrupt stops them
+		// while they are blocked reading their input.
+		var cancel context.CancelFunc
+		ctx, cancel = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		stop := context.AfterFunc(ctx, func() {
+			_ = inputStream.Close()
+		})
+		defer stop()
+	}
 
 	scanner := bufio.NewScanner(inputStream)
 	for scanner.Scan() {
 		line := scanner.Text()
 		data, err := parse.ParseLine(line, args.logType)
 		if err != nil {
+			if args.follow {
+				// Don't give up on a live stream because of a single bad line.
+				fmt.Fprintf(os.Stderr, "ParseLine: %s\n", err)
+				continue
+			}
 			return fmt.Errorf("ParseLine: %w", err)
 		}
+		if data == nil {
+			continue
+		}
 
 		include, err := filter.Filter(data, args.CompiledFilterFields, args.filterOP)
 		if err != nil {
@@ -145,13 +261,16 @@ func run(args arguments) error {
 			}
 			return err
 		}
-		if !include {
+		if !include || !filter.Match(data, args.filterExpr) {
 			// no data to display.
 			continue
 		}
 
 		formatter.Format(data)
 	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
 
 	// Check if formatter implements io.Closer and call Close if it does
 	if closer, ok := formatter.(io.Closer); ok {
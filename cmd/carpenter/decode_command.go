@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/decode"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/stream"
+)
+
+type decodeArguments struct {
+	files  []string
+	plugin decode.Plugin
+	kind   decode.Kind
+}
+
+func makeDecodeCommand() *cli.Command {
+	var args decodeArguments
+	return &cli.Command{
+		Name:      "decode",
+		Usage:     "Decode hex or base64 encoded OCR queries, observations, outcomes and EVM reports into JSON",
+		ArgsUsage: "[blob...]",
+		Description: "Decodes the blobs given as arguments. Without arguments the logs are searched " +
+			"for encoded values and every value that decodes is printed.",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "filename",
+				Usage:       "Provide one or more log files, directories or glob patterns to search. If not provided, reads from stdin.",
+				Destination: &args.files,
+			},
+			&cli.StringFlag{
+				Name:             "plugin",
+				Usage:            fmt.Sprintf("Plugin codec to use: [%s]", strings.Join(decode.PluginNames(), ", ")),
+				Value:            decode.PluginAuto.String(),
+				ValidateDefaults: true, // to make sure default is assigned.
+				Validator: func(s string) error {
+					var err error
+					args.plugin, err = decode.ParsePlugin(s)
+					return err
+				},
+			},
+			&cli.StringFlag{
+				Name:             "kind",
+				Usage:            fmt.Sprintf("OCR type to decode: [%s]", strings.Join(decode.KindNames(), ", ")),
+				Value:            decode.KindAuto.String(),
+				ValidateDefaults: true, // to make sure default is assigned.
+				Validator: func(s string) error {
+					var err error
+					args.kind, err = decode.ParseKind(s)
+					return err
+				},
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() > 0 {
+				return decodeBlobs(os.Stdout, cmd.Args().Slice(), args)
+			}
+			return decodeLogs(os.Stdout, args)
+		},
+	}
+}
+
+// decodeBlobs decodes values provided by the user, failures are reported as errors.
+func decodeBlobs(out io.Writer, blobs []string, args decodeArguments) error {
+	for _, raw := range blobs {
+		blob, err := decode.ParseBlob(raw)
+		if err != nil {
+			return err
+		}
+		decoded, err := decode.Decode(blob.Data, args.plugin, args.kind)
+		if err != nil {
+			return err
+		}
+		if err := writeDecoded(out, "", blob, decoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeLogs searches the logs for encoded values, values which don't decode are skipped.
+func decodeLogs(out io.Writer, args decodeArguments) error {
+	inputStream, err := stream.InitializeInputStream(stream.InputOptions{Filenames: args.files})
+	if err != nil {
+		return fmt.Errorf("failed to initialize input stream: %w", err)
+	}
+	defer inputStream.Close()
+
+	scanner := bufio.NewScanner(inputStream)
+	// Encoded observations can be large.
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		for _, blob := range decode.FindBlobs(scanner.Text()) {
+			decoded, err := decode.Decode(blob.Data, args.plugin, args.kind)
+			if err != nil {
+				continue
+			}
+			if err := writeDecoded(out, fmt.Sprintf("line %d: ", lineNum), blob, decoded); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func writeDecoded(out io.Writer, prefix string, blob decode.Blob, decoded decode.Decoded) error {
+	raw, err := json.MarshalIndent(decoded.Value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s as JSON: %w", decoded.Plugin, decoded.Kind, err)
+	}
+	_, err = fmt.Fprintf(out, "%s%s %s (%d bytes, %s)\n%s\n",
+		prefix, decoded.Plugin, decoded.Kind, len(blob.Data), blob.Encoding, raw)
+	return err
+}
@@ -0,0 +1,226 @@
+//go:generate go-enum -f=$GOFILE --names --nocase
+
+// Package decode finds hex or base64 encoded OCR queries, observations, outcomes and reports
+// embedded in logs. Queries, observations and outcomes are decoded with the ocrtypecodec/v1
+// protobuf codecs, reports with the EVM report ABI.
+package decode
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	ocrtypecodec "github.com/smartcontractkit/chainlink-ccip/pkg/ocrtypecodec/v1"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/ocrtypecodec/v1/ocrtypecodecpb"
+)
+
+// Plugin selects which plugin codec to decode with.
+// ENUM(Auto, Commit, Exec)
+type Plugin string
+
+// Kind selects which OCR type to decode.
+// ENUM(Auto, Query, Observation, Outcome, Report)
+type Kind string
+
+// MinBlobBytes is the smallest blob that is considered when searching logs, shorter values are
+// most likely ordinary fields rather than encoded OCR types.
+const MinBlobBytes = 8
+
+var (
+	// blobRegex matches both encodings, hex strings are valid base64 as well so each match is
+	// classified afterwards.
+	blobRegex = regexp.MustCompile(`[A-Za-z0-9+/]{12,}={0,2}`)
+	hexRegex  = regexp.MustCompile(`^(?:0x)?[0-9a-fA-F]+$`)
+)
+
+// Blob is an encoded value found in a log line.
+type Blob struct {
+	Text     string
+	Encoding string
+	Data     []byte
+}
+
+// Decoded is a blob decoded into an OCR type.
+type Decoded struct {
+	Plugin Plugin
+	Kind   Kind
+	Value  any
+}
+
+// decoder decodes a single plugin type. The proto message, when set, is used to strictly
+// validate the input before it is handed to the codec, protobuf happily decodes most random bytes.
+type decoder struct {
+	plugin Plugin
+	kind   Kind
+	msg    func() proto.Message
+	decode func(data []byte) (any, error)
+}
+
+var decoders = []decoder{
+	{
+		plugin: PluginCommit, kind: KindObservation,
+		msg: func() proto.Message { return &ocrtypecodecpb.CommitObservation{} },
+		decode: func(data []byte) (any, error) {
+			return ocrtypecodec.NewCommitCodecProto().DecodeObservation(data)
+		},
+	},
+	{
+		plugin: PluginCommit, kind: KindOutcome,
+		msg: func() proto.Message { return &ocrtypecodecpb.CommitOutcome{} },
+		decode: func(data []byte) (any, error) {
+			return ocrtypecodec.NewCommitCodecProto().DecodeOutcome(data)
+		},
+	},
+	{
+		plugin: PluginCommit, kind: KindQuery,
+		msg: func() proto.Message { return &ocrtypecodecpb.CommitQuery{} },
+		decode: func(data []byte) (any, error) {
+			return ocrtypecodec.NewCommitCodecProto().DecodeQuery(data)
+		},
+	},
+	{
+		plugin: PluginExec, kind: KindObservation,
+		msg: func() proto.Message { return &ocrtypecodecpb.ExecObservation{} },
+		decode: func(data []byte) (any, error) {
+			return ocrtypecodec.NewExecCodecProto().DecodeObservation(data)
+		},
+	},
+	{
+		plugin: PluginExec, kind: KindOutcome,
+		msg: func() proto.Message { return &ocrtypecodecpb.ExecOutcome{} },
+		decode: func(data []byte) (any, error) {
+			return ocrtypecodec.NewExecCodecProto().DecodeOutcome(data)
+		},
+	},
+	// Reports are chain specific, only the EVM encoding is supported.
+	{
+		plugin: PluginCommit, kind: KindReport,
+		decode: decodeEVMCommitReport,
+	},
+	{
+		plugin: PluginExec, kind: KindReport,
+		decode: decodeEVMExecReport,
+	},
+}
+
+// Decode tries every codec matching the plugin and kind and returns the first one which
+// accepts the data.
+func Decode(data []byte, plugin Plugin, kind Kind) (Decoded, error) {
+	if len(data) == 0 {
+		return Decoded{}, fmt.Errorf("no data to decode")
+	}
+
+	var errs []string
+	for _, d := range decoders {
+		if plugin != PluginAuto && plugin != d.plugin {
+			continue
+		}
+		if kind != KindAuto && kind != d.kind {
+			continue
+		}
+
+		value, err := d.tryDecode(data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %s: %s", d.plugin, d.kind, err))
+			continue
+		}
+		return Decoded{Plugin: d.plugin, Kind: d.kind, Value: value}, nil
+	}
+
+	return Decoded{}, fmt.Errorf("unable to decode %d bytes: %s", len(data), strings.Join(errs, "; "))
+}
+
+func (d decoder) tryDecode(data []byte) (value any, err error) {
+	if d.msg != nil {
+		msg := d.msg()
+		if err := proto.Unmarshal(data, msg); err != nil {
+			return nil, err
+		}
+		if hasUnknownFields(msg.ProtoReflect()) {
+			return nil, fmt.Errorf("unknown fields")
+		}
+	}
+
+	// The codecs assume well-formed input and may dereference missing sub messages.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("codec panicked: %v", r)
+		}
+	}()
+	return d.decode(data)
+}
+
+// hasUnknownFields checks the message and all nested messages for fields that are not part of
+// the schema, which means the data was encoded as a different type.
+func hasUnknownFields(m protoreflect.Message) bool {
+	if len(m.GetUnknown()) > 0 {
+		return true
+	}
+	unknown := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					unknown = hasUnknownFields(mv.Message())
+					return !unknown
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := 0; i < list.Len() && !unknown; i++ {
+					unknown = hasUnknownFields(list.Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			unknown = hasUnknownFields(v.Message())
+		}
+		return !unknown
+	})
+	return unknown
+}
+
+// FindBlobs returns all hex and base64 encoded values of at least MinBlobBytes in the line.
+func FindBlobs(line string) []Blob {
+	var blobs []Blob
+	for _, match := range blobRegex.FindAllString(line, -1) {
+		if hexRegex.MatchString(match) {
+			data, err := hex.DecodeString(strings.TrimPrefix(match, "0x"))
+			if err == nil && len(data) >= MinBlobBytes {
+				blobs = append(blobs, Blob{Text: match, Encoding: "hex", Data: data})
+			}
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(match)
+		if err != nil {
+			data, err = base64.RawStdEncoding.DecodeString(match)
+		}
+		if err != nil || len(data) < MinBlobBytes {
+			continue
+		}
+		blobs = append(blobs, Blob{Text: match, Encoding: "base64", Data: data})
+	}
+	return blobs
+}
+
+// ParseBlob decodes a single hex (optionally 0x prefixed) or base64 value.
+func ParseBlob(s string) (Blob, error) {
+	s = strings.TrimSpace(s)
+	raw := strings.TrimPrefix(s, "0x")
+	if data, err := hex.DecodeString(raw); err == nil {
+		return Blob{Text: s, Encoding: "hex", Data: data}, nil
+	}
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return Blob{Text: s, Encoding: "base64", Data: data}, nil
+	}
+	if data, err := base64.RawStdEncoding.DecodeString(s); err == nil {
+		return Blob{Text: s, Encoding: "base64", Data: data}, nil
+	}
+	return Blob{}, fmt.Errorf("%q is neither hex nor base64", s)
+}
@@ -0,0 +1,136 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package decode
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// KindAuto is a Kind of type Auto.
+	KindAuto Kind = "Auto"
+	// KindQuery is a Kind of type Query.
+	KindQuery Kind = "Query"
+	// KindObservation is a Kind of type Observation.
+	KindObservation Kind = "Observation"
+	// KindOutcome is a Kind of type Outcome.
+	KindOutcome Kind = "Outcome"
+	// KindReport is a Kind of type Report.
+	KindReport Kind = "Report"
+)
+
+var ErrInvalidKind = fmt.Errorf("not a valid Kind, try [%s]", strings.Join(_KindNames, ", "))
+
+var _KindNames = []string{
+	string(KindAuto),
+	string(KindQuery),
+	string(KindObservation),
+	string(KindOutcome),
+	string(KindReport),
+}
+
+// KindNames returns a list of possible string values of Kind.
+func KindNames() []string {
+	tmp := make([]string, len(_KindNames))
+	copy(tmp, _KindNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x Kind) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Kind) IsValid() bool {
+	_, err := ParseKind(string(x))
+	return err == nil
+}
+
+var _KindValue = map[string]Kind{
+	"Auto":        KindAuto,
+	"auto":        KindAuto,
+	"Query":       KindQuery,
+	"query":       KindQuery,
+	"Observation": KindObservation,
+	"observation": KindObservation,
+	"Outcome":     KindOutcome,
+	"outcome":     KindOutcome,
+	"Report":      KindReport,
+	"report":      KindReport,
+}
+
+// ParseKind attempts to convert a string to a Kind.
+func ParseKind(name string) (Kind, error) {
+	if x, ok := _KindValue[name]; ok {
+		return x, nil
+	}
+	// Case insensitive parse, do a separate lookup to prevent unnecessary cost of lowercasing a string if we don't need to.
+	if x, ok := _KindValue[strings.ToLower(name)]; ok {
+		return x, nil
+	}
+	return Kind(""), fmt.Errorf("%s is %w", name, ErrInvalidKind)
+}
+
+const (
+	// PluginAuto is a Plugin of type Auto.
+	PluginAuto Plugin = "Auto"
+	// PluginCommit is a Plugin of type Commit.
+	PluginCommit Plugin = "Commit"
+	// PluginExec is a Plugin of type Exec.
+	PluginExec Plugin = "Exec"
+)
+
+var ErrInvalidPlugin = fmt.Errorf("not a valid Plugin, try [%s]", strings.Join(_PluginNames, ", "))
+
+var _PluginNames = []string{
+	string(PluginAuto),
+	string(PluginCommit),
+	string(PluginExec),
+}
+
+// PluginNames returns a list of possible string values of Plugin.
+func PluginNames() []string {
+	tmp := make([]string, len(_PluginNames))
+	copy(tmp, _PluginNames)
+	return tmp
+}
+
+// String implements the Stringer interface.
+func (x Plugin) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Plugin) IsValid() bool {
+	_, err := ParsePlugin(string(x))
+	return err == nil
+}
+
+var _PluginValue = map[string]Plugin{
+	"Auto":   PluginAuto,
+	"auto":   PluginAuto,
+	"Commit": PluginCommit,
+	"commit": PluginCommit,
+	"Exec":   PluginExec,
+	"exec":   PluginExec,
+}
+
+// ParsePlugin attempts to convert a string to a Plugin.
+func ParsePlugin(name string) (Plugin, error) {
+	if x, ok := _PluginValue[name]; ok {
+		return x, nil
+	}
+	// Case insensitive parse, do a separate lookup to prevent unnecessary cost of lowercasing a string if we don't need to.
+	if x, ok := _PluginValue[strings.ToLower(name)]; ok {
+		return x, nil
+	}
+	return Plugin(""), fmt.Errorf("%s is %w", name, ErrInvalidPlugin)
+}
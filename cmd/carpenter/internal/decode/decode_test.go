@@ -0,0 +1,143 @@
+package decode
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/report_codec"
+	"github.com/smartcontractkit/chainlink-ccip/commit/committypes"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot"
+	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
+	ocrtypecodec "github.com/smartcontractkit/chainlink-ccip/pkg/ocrtypecodec/v1"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+func encodedCommitObservation(t *testing.T) []byte {
+	obs := committypes.Observation{
+		MerkleRootObs: merkleroot.Observation{
+			OnRampMaxSeqNums: []plugintypes.SeqNumChain{{ChainSel: 5009297550715157269, SeqNum: 10}},
+		},
+		FChain: map[cciptypes.ChainSelector]int{5009297550715157269: 1},
+	}
+	data, err := ocrtypecodec.NewCommitCodecProto().EncodeObservation(obs)
+	require.NoError(t, err)
+	return data
+}
+
+func encodedExecOutcome(t *testing.T) []byte {
+	otc := exectypes.Outcome{
+		State: exectypes.GetCommitReports,
+		CommitReports: []exectypes.CommitData{{
+			SourceChain:         5009297550715157269,
+			SequenceNumberRange: cciptypes.NewSeqNumRange(1, 10),
+		}},
+	}
+	data, err := ocrtypecodec.NewExecCodecProto().EncodeOutcome(otc)
+	require.NoError(t, err)
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	decoded, err := Decode(encodedCommitObservation(t), PluginAuto, KindAuto)
+	require.NoError(t, err)
+	require.Equal(t, PluginCommit, decoded.Plugin)
+	require.Equal(t, KindObservation, decoded.Kind)
+	obs, ok := decoded.Value.(committypes.Observation)
+	require.True(t, ok)
+	require.Equal(t, cciptypes.SeqNum(10), obs.MerkleRootObs.OnRampMaxSeqNums[0].SeqNum)
+
+	decoded, err = Decode(encodedExecOutcome(t), PluginAuto, KindAuto)
+	require.NoError(t, err)
+	require.Equal(t, PluginExec, decoded.Plugin)
+	require.Equal(t, KindOutcome, decoded.Kind)
+	otc, ok := decoded.Value.(exectypes.Outcome)
+	require.True(t, ok)
+	require.Len(t, otc.CommitReports, 1)
+
+	_, err = Decode(encodedExecOutcome(t), PluginCommit, KindAuto)
+	require.Error(t, err)
+
+	_, err = Decode([]byte("definitely not protobuf"), PluginAuto, KindAuto)
+	require.Error(t, err)
+
+	_, err = Decode(nil, PluginAuto, KindAuto)
+	require.Error(t, err)
+}
+
+func TestDecode_reports(t *testing.T) {
+	commitReport := report_codec.OffRampCommitReport{
+		PriceUpdates: report_codec.InternalPriceUpdates{
+			TokenPriceUpdates: []report_codec.InternalTokenPriceUpdate{},
+			GasPriceUpdates: []report_codec.InternalGasPriceUpdate{
+				{DestChainSelector: 5009297550715157269, UsdPerUnitGas: big.NewInt(1e9)},
+			},
+		},
+		BlessedMerkleRoots: []report_codec.InternalMerkleRoot{},
+		UnblessedMerkleRoots: []report_codec.InternalMerkleRoot{{
+			SourceChainSelector: 5009297550715157269,
+			OnRampAddress:       []byte{0x01, 0x02},
+			MinSeqNr:            1,
+			MaxSeqNr:            10,
+			MerkleRoot:          [32]byte{0xaa},
+		}},
+		RmnSignatures: []report_codec.IRMNRemoteSignature{},
+	}
+	data, err := reportCodecABI.Methods["decodeCommitReport"].Outputs.Pack(commitReport)
+	require.NoError(t, err)
+
+	decoded, err := Decode(data, PluginAuto, KindAuto)
+	require.NoError(t, err)
+	require.Equal(t, PluginCommit, decoded.Plugin)
+	require.Equal(t, KindReport, decoded.Kind)
+	require.Equal(t, commitReport, decoded.Value)
+
+	execReports := []report_codec.InternalExecutionReport{{
+		SourceChainSelector: 5009297550715157269,
+		Messages:            []report_codec.InternalAny2EVMRampMessage{},
+		OffchainTokenData:   [][][]byte{},
+		Proofs:              [][32]byte{{0xbb}},
+		ProofFlagBits:       big.NewInt(5),
+	}}
+	data, err = reportCodecABI.Methods["decodeExecuteReport"].Outputs.Pack(execReports)
+	require.NoError(t, err)
+
+	decoded, err = Decode(data, PluginExec, KindReport)
+	require.NoError(t, err)
+	require.Equal(t, execReports, decoded.Value)
+
+	// an exec report is not a commit report
+	_, err = Decode(data, PluginCommit, KindReport)
+	require.Error(t, err)
+}
+
+func TestFindBlobs(t *testing.T) {
+	obs := encodedCommitObservation(t)
+	line := fmt.Sprintf(`{"msg":"observation","hex":"0x%s","b64":"%s","short":"0xabcd","donID":1}`,
+		hex.EncodeToString(obs), base64.StdEncoding.EncodeToString(obs))
+
+	blobs := FindBlobs(line)
+	require.Len(t, blobs, 2)
+	require.Equal(t, "hex", blobs[0].Encoding)
+	require.Equal(t, obs, blobs[0].Data)
+	require.Equal(t, "base64", blobs[1].Encoding)
+	require.Equal(t, obs, blobs[1].Data)
+}
+
+func TestParseBlob(t *testing.T) {
+	blob, err := ParseBlob("0x0102")
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, blob.Data)
+
+	blob, err = ParseBlob(base64.StdEncoding.EncodeToString([]byte("hello world")))
+	require.NoError(t, err)
+	require.Equal(t, "base64", blob.Encoding)
+
+	_, err = ParseBlob("not an encoding!")
+	require.Error(t, err)
+}
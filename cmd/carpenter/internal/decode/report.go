@@ -0,0 +1,55 @@
+package decode
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/report_codec"
+)
+
+// reportCodecABI declares the decode functions of the ReportCodec contract, their outputs are
+// the ABI encoded commit and exec reports accepted by the EVM OffRamp.
+var reportCodecABI = func() *abi.ABI {
+	parsed, err := report_codec.ReportCodecMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+func decodeEVMCommitReport(data []byte) (any, error) {
+	value, err := unpackReport("decodeCommitReport", data)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(value, new(report_codec.OffRampCommitReport)).(*report_codec.OffRampCommitReport), nil
+}
+
+func decodeEVMExecReport(data []byte) (any, error) {
+	value, err := unpackReport("decodeExecuteReport", data)
+	if err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(value, new([]report_codec.InternalExecutionReport)).(*[]report_codec.InternalExecutionReport), nil
+}
+
+// unpackReport decodes the report returned by the method. The report is encoded again and
+// compared with the input, so that data which only happens to decode, e.g. another report type,
+// is rejected.
+func unpackReport(method string, data []byte) (any, error) {
+	outputs := reportCodecABI.Methods[method].Outputs
+	values, err := outputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := outputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(encoded, data) {
+		return nil, fmt.Errorf("not a canonical EVM report encoding")
+	}
+	return values[0], nil
+}
@@ -0,0 +1,545 @@
+package filter
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+// Expr is a compiled boolean filter expression.
+//
+// The grammar supports parentheses, AND/OR/NOT (also written as &&, || and !) and comparisons
+// of the form 'Field OP Value':
+//
+//	(Plugin==Commit OR Plugin==Execute) AND NOT LogLevel:debug
+//	SequenceNumber>=100 AND SequenceNumber<200
+//	Timestamp>=2025-01-20T11:50:00Z AND Timestamp<2025-01-20T11:55:00Z
+//	fields.sourceChain==5009297550715157269
+//
+// Fields are the Field enum values or 'fields.<name>' for any JSON logger field, nested fields
+// are separated with dots (fields.outcome.outcomeType). Supported operators:
+//   - ':' and '~' match a regular expression, like the --filter flag.
+//   - '==' and '!=' compare numbers, timestamps or strings.
+//   - '<', '<=', '>', '>=' compare numbers or timestamps.
+//
+// Decimal numbers are compared exactly, so chain selectors don't match their neighbours.
+// Timestamp values must be RFC3339 timestamps or times of day.
+//
+// Values containing spaces or parentheses can be quoted with double quotes. Comparisons on
+// missing fields are false.
+type Expr interface {
+	Eval(data *parse.Data) bool
+	String() string
+}
+
+// Match evaluates the expression, a nil expression matches everything.
+func Match(data *parse.Data, expr Expr) bool {
+	if expr == nil {
+		return true
+	}
+	return expr.Eval(data)
+}
+
+// ParseExpr compiles a filter expression, see Expr for the grammar.
+func ParseExpr(input string) (Expr, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	p := &exprParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	return expr, nil
+}
+
+// Operators, ordered so that the longest operators are matched first.
+var operators = []string{"==", "!=", ">=", "<=", ">", "<", ":", "~"}
+
+const fieldsPrefix = "fields."
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenOperator
+	tokenOpenParen
+	tokenCloseParen
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits the input into tokens. A comparison like 'Field==value' is split into three
+// tokens, the value of a comparison extends to the next whitespace or closing parenthesis.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	afterOperator := false
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, text: "(", pos: i})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, text: ")", pos: i})
+			i++
+			continue
+		case r == '"':
+			end := i + 1
+			var sb strings.Builder
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				sb.WriteRune(runes[end])
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: sb.String(), pos: i})
+			afterOperator = false
+			i = end + 1
+			continue
+		}
+
+		if !afterOperator {
+			if op := operatorAt(runes, i); op != "" {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+				i += len(op)
+				afterOperator = true
+				continue
+			}
+			if r == '!' {
+				tokens = append(tokens, token{kind: tokenNot, text: "!", pos: i})
+				i++
+				continue
+			}
+			if hasPrefix(runes, i, "&&") {
+				tokens = append(tokens, token{kind: tokenAnd, text: "&&", pos: i})
+				i += 2
+				continue
+			}
+			if hasPrefix(runes, i, "||") {
+				tokens = append(tokens, token{kind: tokenOr, text: "||", pos: i})
+				i += 2
+				continue
+			}
+		}
+
+		// A word ends at whitespace or a parenthesis, a field name also ends at an operator.
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+			if !afterOperator && operatorAt(runes, i) != "" {
+				break
+			}
+			i++
+		}
+		text := string(runes[start:i])
+
+		kind := tokenWord
+		if !afterOperator {
+			switch strings.ToUpper(text) {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+		}
+		tokens = append(tokens, token{kind: kind, text: text, pos: start})
+		afterOperator = false
+	}
+
+	return tokens, nil
+}
+
+func operatorAt(runes []rune, i int) string {
+	for _, op := range operators {
+		if hasPrefix(runes, i, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func hasPrefix(runes []rune, i int, prefix string) bool {
+	return strings.HasPrefix(string(runes[i:min(len(runes), i+len(prefix))]), prefix)
+}
+
+// exprParser is a recursive descent parser, precedence from low to high is OR, AND, NOT.
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() (token, error) {
+	if p.done() {
+		return token{}, fmt.Errorf("unexpected end of expression")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *exprParser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.peek().kind == tokenOr {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.peek().kind == tokenAnd {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (Expr, error) {
+	if !p.done() && p.peek().kind == tokenNot {
+		p.pos++
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (Expr, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.kind {
+	case tokenOpenParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, err := p.next()
+		if err != nil || closing.kind != tokenCloseParen {
+			return nil, fmt.Errorf("missing closing parenthesis for position %d", t.pos)
+		}
+		return inner, nil
+	case tokenWord:
+		op, err := p.next()
+		if err != nil || op.kind != tokenOperator {
+			return nil, fmt.Errorf("expected an operator after %q at position %d", t.text, t.pos)
+		}
+		value, err := p.next()
+		if err != nil || value.kind != tokenWord {
+			return nil, fmt.Errorf("expected a value after %q at position %d", t.text+op.text, t.pos)
+		}
+		return newComparison(t.text, op.text, value.text)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+}
+
+type andExpr struct {
+	left, right Expr
+}
+
+func (e andExpr) Eval(data *parse.Data) bool {
+	return e.left.Eval(data) && e.right.Eval(data)
+}
+
+func (e andExpr) String() string {
+	return fmt.Sprintf("(%s AND %s)", e.left, e.right)
+}
+
+type orExpr struct {
+	left, right Expr
+}
+
+func (e orExpr) Eval(data *parse.Data) bool {
+	return e.left.Eval(data) || e.right.Eval(data)
+}
+
+func (e orExpr) String() string {
+	return fmt.Sprintf("(%s OR %s)", e.left, e.right)
+}
+
+type notExpr struct {
+	inner Expr
+}
+
+func (e notExpr) Eval(data *parse.Data) bool {
+	return !e.inner.Eval(data)
+}
+
+func (e notExpr) String() string {
+	return fmt.Sprintf("NOT %s", e.inner)
+}
+
+// comparison compares a field with a constant value. The value is pre-parsed as a number
+// and a timestamp when possible.
+type comparison struct {
+	field Field
+	// path is set instead of field for JSON logger fields.
+	path []string
+	op   string
+
+	value     string
+	number    *big.Rat
+	isNumber  bool
+	timestamp time.Time
+	// timeOfDay is set when the value only specified a time, it is compared with the time
+	// of day of the log.
+	timeOfDay bool
+	isTime    bool
+	re        *regexp.Regexp
+}
+
+func newComparison(name, op, value string) (Expr, error) {
+	c := comparison{op: op, value: value}
+
+	if strings.HasPrefix(name, fieldsPrefix) {
+		c.path = strings.Split(strings.TrimPrefix(name, fieldsPrefix), ".")
+	} else {
+		f, err := ParseField(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %s not in [%s] and not a %s<name> field",
+				name, strings.Join(FieldNames(), ", "), fieldsPrefix)
+		}
+		c.field = f
+	}
+
+	if n, ok := parseNumber(value); ok {
+		c.number, c.isNumber = n, true
+	}
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		c.timestamp, c.isTime = ts, true
+	} else if ts, err := time.Parse(time.TimeOnly, value); err == nil {
+		c.timestamp, c.isTime, c.timeOfDay = ts, true, true
+	}
+
+	switch op {
+	case ":", "~":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile regexp %s: %w", value, err)
+		}
+		c.re = re
+	case "==", "!=":
+		if c.field == FieldTimestamp && !c.isTime {
+			return nil, fmt.Errorf("%s%s%s: expected an RFC3339 timestamp or a time of day", name, op, value)
+		}
+	case "<", "<=", ">", ">=":
+		if c.field == FieldTimestamp && !c.isTime {
+			return nil, fmt.Errorf("%s%s%s: expected an RFC3339 timestamp or a time of day", name, op, value)
+		}
+		if c.field != FieldTimestamp && c.path == nil && !c.isNumber {
+			return nil, fmt.Errorf("%s%s%s: expected a number", name, op, value)
+		}
+	}
+
+	return c, nil
+}
+
+func (c comparison) String() string {
+	name := string(c.field)
+	if c.path != nil {
+		name = fieldsPrefix + strings.Join(c.path, ".")
+	}
+	return name + c.op + c.value
+}
+
+func (c comparison) Eval(data *parse.Data) bool {
+	if c.field == FieldTimestamp {
+		return c.evalTime(data.GetTimestamp())
+	}
+
+	raw, ok := c.lookup(data)
+	if !ok {
+		return false
+	}
+	str := valueString(raw)
+
+	if c.re != nil {
+		return c.re.MatchString(str)
+	}
+
+	if c.isNumber {
+		if n, ok := toNumber(raw); ok {
+			return compare(n.Cmp(c.number), c.op)
+		}
+	}
+
+	switch c.op {
+	case "==":
+		return str == c.value
+	case "!=":
+		return str != c.value
+	default:
+		// Ordering is only defined for numbers and timestamps.
+		return false
+	}
+}
+
+func (c comparison) evalTime(ts time.Time) bool {
+	if c.re != nil {
+		return c.re.MatchString(ts.Format(time.RFC3339Nano))
+	}
+	if c.timeOfDay {
+		// Compare durations since midnight.
+		sinceMidnight := func(t time.Time) time.Duration {
+			h, m, s := t.Clock()
+			return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+				time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+		}
+		return compare(cmp.Compare(sinceMidnight(ts.UTC()), sinceMidnight(c.timestamp)), c.op)
+	}
+	return compare(ts.Compare(c.timestamp), c.op)
+}
+
+// lookup returns the raw value of the field, numbers are returned as numbers.
+func (c comparison) lookup(data *parse.Data) (any, bool) {
+	if c.path == nil {
+		switch c.field {
+		case FieldDONID:
+			return data.DONID, true
+		case FieldSequenceNumber:
+			return data.SequenceNumber, true
+		case FieldOracleID:
+			return data.OracleID, true
+		default:
+			return fieldString(data, c.field), true
+		}
+	}
+
+	var current any = data.RawLoggerFields
+	for _, key := range c.path {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// compare applies the operator to the result of a comparison (-1, 0 or +1).
+func compare(c int, op string) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	default:
+		return false
+	}
+}
+
+// decimalRegex matches decimal numbers, hex strings such as addresses are compared as strings.
+var decimalRegex = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// parseNumber parses a decimal number exactly, so that large integers such as chain
+// selectors are not rounded.
+func parseNumber(s string) (*big.Rat, bool) {
+	if !decimalRegex.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// toNumber converts JSON numbers and numeric strings to an exact number.
+func toNumber(v any) (*big.Rat, bool) {
+	switch val := v.(type) {
+	case int:
+		return new(big.Rat).SetInt64(int64(val)), true
+	case json.Number:
+		return parseNumber(val.String())
+	case float64:
+		return new(big.Rat).SetFloat64(val), true
+	case string:
+		return parseNumber(val)
+	default:
+		return nil, false
+	}
+}
+
+func valueString(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,99 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func TestParseExpr(t *testing.T) {
+	data := &parse.Data{
+		ProdTimestamp:  "2025-01-20T11:50:22.325Z",
+		ProdLevel:      "debug",
+		ProdMessage:    "Sending Outcome",
+		Plugin:         "Commit",
+		Component:      "MerkleRoot",
+		OracleID:       2,
+		DONID:          1,
+		SequenceNumber: 150,
+		OCRPhase:       "otcm",
+		RawLoggerFields: map[string]any{
+			// numbers are decoded as json.Number by the parser
+			"sourceChain": json.Number("5009297550715157269"),
+			"seqNum":      "42",
+			"outcome": map[string]any{
+				"outcomeType": json.Number("1"),
+			},
+		},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: "Plugin==Commit", want: true},
+		{expr: "plugin==Execute", want: false},
+		{expr: "Plugin!=Execute", want: true},
+		{expr: "Message:Outcome$", want: true},
+		{expr: `Message=="Sending Outcome"`, want: true},
+		{expr: "SequenceNumber>=100 AND SequenceNumber<200", want: true},
+		{expr: "SequenceNumber>150", want: false},
+		{expr: "SequenceNumber<=150 && OracleID==2", want: true},
+		{expr: "Plugin==Execute OR OCRPhase==otcm", want: true},
+		{expr: "Plugin==Execute || Plugin==Commit && LogLevel:info", want: false},
+		{expr: "(Plugin==Execute || Plugin==Commit) && !LogLevel:info", want: true},
+		{expr: "NOT (Plugin==Commit AND Component==MerkleRoot)", want: false},
+		{expr: "not not Plugin==Commit", want: true},
+		{expr: "fields.sourceChain==5009297550715157269", want: true},
+		{expr: "fields.sourceChain==1", want: false},
+		// a neighbouring selector, equal at float64 precision
+		{expr: "fields.sourceChain==5009297550715157270", want: false},
+		{expr: "fields.sourceChain<5009297550715157270", want: true},
+		{expr: "fields.seqNum>40", want: true},
+		{expr: "fields.outcome.outcomeType==1", want: true},
+		{expr: "fields.outcome.missing==1", want: false},
+		{expr: "fields.missing!=1", want: false},
+		{expr: "Timestamp>=2025-01-20T11:50:00Z AND Timestamp<2025-01-20T11:51:00Z", want: true},
+		{expr: "Timestamp>2025-01-20T11:51:00Z", want: false},
+		{expr: "Timestamp>=11:50:22 AND Timestamp<11:50:23", want: true},
+		{expr: "Timestamp==2025-01-20T11:50:22.325Z", want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := ParseExpr(tc.expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, expr.Eval(data), "parsed as %s", expr)
+		})
+	}
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"Plugin",
+		"Plugin==",
+		"Unknown==1",
+		"(Plugin==Commit",
+		"Plugin==Commit)",
+		"Plugin==Commit AND",
+		"SequenceNumber>abc",
+		"Timestamp>yesterday",
+		"Timestamp==yesterday",
+		"Timestamp!=yesterday",
+		"Message:[",
+		`Message=="unterminated`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseExpr(expr)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMatch_NilExpr(t *testing.T) {
+	require.True(t, Match(&parse.Data{}, nil))
+}
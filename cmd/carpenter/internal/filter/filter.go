@@ -7,11 +7,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
 )
 
-// ENUM(Plugin, Component, LogLevel, Message, Caller, LoggerName, DONID, SequenceNumber)
+// ENUM(Plugin, Component, LogLevel, Message, Caller, LoggerName, DONID, SequenceNumber, OracleID, OCRPhase, Timestamp)
 type Field string
 
 type matcher struct {
@@ -91,27 +92,8 @@ func Filter(data *parse.Data, filters CompiledFilterFields, op FilterOP) (bool,
 	allMatch := true
 
 	for field, compiledFilters := range filters {
-		var fieldStr string
+		fieldStr := fieldString(data, field)
 		for _, compiledFilter := range compiledFilters {
-			switch field {
-			case FieldComponent:
-				fieldStr = data.Component
-			case FieldMessage:
-				fieldStr = data.GetMessage()
-			case FieldLogLevel:
-				fieldStr = data.GetLevel()
-			case FieldCaller:
-				fieldStr = data.GetCaller()
-			case FieldLoggerName:
-				fieldStr = data.GetLoggerName()
-			case FieldPlugin:
-				fieldStr = data.Plugin
-			case FieldDONID:
-				fieldStr = fmt.Sprintf("%d", data.DONID)
-			case FieldSequenceNumber:
-				fieldStr = fmt.Sprintf("%d", data.SequenceNumber)
-			}
-
 			matches := compiledFilter.re.MatchString(fieldStr)
 			if compiledFilter.antiMatcher {
 				if matches {
@@ -135,3 +117,33 @@ func Filter(data *parse.Data, filters CompiledFilterFields, op FilterOP) (bool,
 		return anyMatch, nil
 	}
 }
+
+// fieldString returns the string representation of a field, used for regexp matching.
+func fieldString(data *parse.Data, field Field) string {
+	switch field {
+	case FieldComponent:
+		return data.Component
+	case FieldMessage:
+		return data.GetMessage()
+	case FieldLogLevel:
+		return data.GetLevel()
+	case FieldCaller:
+		return data.GetCaller()
+	case FieldLoggerName:
+		return data.GetLoggerName()
+	case FieldPlugin:
+		return data.Plugin
+	case FieldDONID:
+		return fmt.Sprintf("%d", data.DONID)
+	case FieldSequenceNumber:
+		return fmt.Sprintf("%d", data.SequenceNumber)
+	case FieldOracleID:
+		return fmt.Sprintf("%d", data.OracleID)
+	case FieldOCRPhase:
+		return data.OCRPhase
+	case FieldTimestamp:
+		return data.GetTimestamp().Format(time.RFC3339Nano)
+	default:
+		return ""
+	}
+}
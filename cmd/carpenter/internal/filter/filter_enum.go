@@ -28,6 +28,12 @@ const (
 	FieldDONID Field = "DONID"
 	// FieldSequenceNumber is a Field of type SequenceNumber.
 	FieldSequenceNumber Field = "SequenceNumber"
+	// FieldOracleID is a Field of type OracleID.
+	FieldOracleID Field = "OracleID"
+	// FieldOCRPhase is a Field of type OCRPhase.
+	FieldOCRPhase Field = "OCRPhase"
+	// FieldTimestamp is a Field of type Timestamp.
+	FieldTimestamp Field = "Timestamp"
 )
 
 var ErrInvalidField = fmt.Errorf("not a valid Field, try [%s]", strings.Join(_FieldNames, ", "))
@@ -41,6 +47,9 @@ var _FieldNames = []string{
 	string(FieldLoggerName),
 	string(FieldDONID),
 	string(FieldSequenceNumber),
+	string(FieldOracleID),
+	string(FieldOCRPhase),
+	string(FieldTimestamp),
 }
 
 // FieldNames returns a list of possible string values of Field.
@@ -79,6 +88,12 @@ var _FieldValue = map[string]Field{
 	"donid":          FieldDONID,
 	"SequenceNumber": FieldSequenceNumber,
 	"sequencenumber": FieldSequenceNumber,
+	"OracleID":       FieldOracleID,
+	"oracleid":       FieldOracleID,
+	"OCRPhase":       FieldOCRPhase,
+	"ocrphase":       FieldOCRPhase,
+	"Timestamp":      FieldTimestamp,
+	"timestamp":      FieldTimestamp,
 }
 
 // ParseField attempts to convert a string to a Field.
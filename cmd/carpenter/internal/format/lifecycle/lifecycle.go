@@ -0,0 +1,320 @@
+// Package lifecycle follows a single CCIP message through the commit and exec plugin logs and
+// prints the stages it went through, answering "where is message X?".
+//
+// The message is selected by ID or by (sourceChain, seqNum). Commit logs only refer to
+// sequence number ranges, so when tracking by ID the source chain and sequence number are
+// learned from the exec logs (and vice versa).
+package lifecycle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot"
+)
+
+func init() {
+	format.Register("lifecycle", lifecycleFormatterFactory,
+		"Follow a message (--message-id or --source-chain and --seq-num) through the commit and exec logs.")
+
+	section = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#3366FF"))
+	highlight = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF9933"))
+	problem = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF3333"))
+}
+
+var section lipgloss.Style
+var highlight lipgloss.Style
+var problem lipgloss.Style
+
+const padding = "    "
+
+func lifecycleFormatterFactory(options format.Options) format.Formatter {
+	return newLifecycleFormatter(
+		newTarget(options.MessageID, options.SourceChain, options.SeqNum), os.Stdout)
+}
+
+func newLifecycleFormatter(t target, out io.Writer) *lifecycleFormatter {
+	return &lifecycleFormatter{target: t, out: out}
+}
+
+// stage is a step in the life of a message, stages are ordered by how far along the message is.
+type stage int
+
+const (
+	stageMentioned stage = iota
+	stageSent
+	stageRootObserved
+	stageRangeSelected
+	stageRootBuilt
+	stageRMNSigned
+	stageCommitReportGenerated
+	stageCommitted
+	stageExecCommitReport
+	stageExecMessage
+	stageExecTokenDataNotReady
+	stageExecTokenDataReady
+	stageExecNotReady
+	stageExecAddedToReport
+	stageExecInflight
+	stageExecExecuted
+)
+
+var stageNames = map[stage]string{
+	stageMentioned:             "mentioned",
+	stageSent:                  "commit: sent on source chain (onRamp max seqNum observed)",
+	stageRootObserved:          "commit: merkle root observed",
+	stageRangeSelected:         "commit: range selected for report",
+	stageRootBuilt:             "commit: merkle root built",
+	stageRMNSigned:             "commit: RMN signed",
+	stageCommitReportGenerated: "commit: report generated",
+	stageCommitted:             "commit: committed (offRamp next seqNum passed message)",
+	stageExecCommitReport:      "exec: commit report found",
+	stageExecMessage:           "exec: message read",
+	stageExecTokenDataNotReady: "exec: token data not ready",
+	stageExecTokenDataReady:    "exec: token data ready",
+	stageExecNotReady:          "exec: not ready",
+	stageExecAddedToReport:     "exec: added to report",
+	stageExecInflight:          "exec: inflight",
+	stageExecExecuted:          "exec: executed",
+}
+
+// Exec message states, as logged by the exec report builder checks.
+const (
+	messageStateAlreadyExecuted   = "already_executed"
+	messageStateInflight          = "inflight"
+	messageStateTokenDataNotReady = "token_data_not_ready"
+)
+
+// event is a log line that refers to the tracked message.
+type event struct {
+	stage  stage
+	detail string
+	data   *parse.Data
+}
+
+// lifecycleFormatter collects all logs, they are matched against the message when closed
+// because the message identity may only be learned from later logs.
+type lifecycleFormatter struct {
+	target target
+	out    io.Writer
+	logs   []*parse.Data
+}
+
+func (lf *lifecycleFormatter) Format(data *parse.Data) {
+	if data.RawLoggerFields == nil {
+		return
+	}
+	lf.logs = append(lf.logs, data)
+}
+
+func (lf *lifecycleFormatter) Validate() error {
+	if !lf.target.hasID() && !lf.target.hasChainSeqNum() {
+		return fmt.Errorf("lifecycle format requires --message-id or both --source-chain and --seq-num")
+	}
+	return nil
+}
+
+func (lf *lifecycleFormatter) Close() error {
+	t := lf.target
+	for _, data := range lf.logs {
+		t = t.learn(data.RawLoggerFields)
+	}
+
+	var events []event
+	for _, data := range lf.logs {
+		if e, ok := classify(t, data); ok {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].data.GetTimestamp().Before(events[j].data.GetTimestamp())
+	})
+
+	_, err := io.WriteString(lf.out, render(t, events))
+	return err
+}
+
+// classify decides which stage a log represents for the message, if any.
+func classify(t target, data *parse.Data) (event, bool) {
+	fields := data.RawLoggerFields
+	message := data.GetMessage()
+
+	switch message {
+	case merkleroot.SendingObservation:
+		obs, _ := fields["observation"].(map[string]any)
+		if obs == nil {
+			return event{}, false
+		}
+		if merkleRoots, ok := obs["merkleRoots"]; ok && t.mentions(merkleRoots) {
+			return event{stage: stageRootObserved, data: data}, true
+		}
+		if t.seqNumAtLeast(obs["onRampMaxSeqNums"], t.seqNum) {
+			return event{stage: stageSent, data: data}, true
+		}
+		return event{}, false
+	case merkleroot.SendingOutcome:
+		otc, _ := fields["outcome"].(map[string]any)
+		if otc == nil {
+			return event{}, false
+		}
+		if t.seqNumAtLeast(otc["offRampNextSeqNums"], t.seqNum+1) {
+			return event{stage: stageCommitted, data: data}, true
+		}
+		if roots, ok := otc["rootsToReport"]; ok && t.mentions(roots) {
+			if sigs, ok := otc["rmnReportSignatures"].([]any); ok && len(sigs) > 0 {
+				return event{stage: stageRMNSigned, detail: fmt.Sprintf("%d signatures", len(sigs)), data: data}, true
+			}
+			return event{stage: stageRootBuilt, data: data}, true
+		}
+		if ranges, ok := otc["rangesSelectedForReport"]; ok && t.mentions(ranges) {
+			return event{stage: stageRangeSelected, data: data}, true
+		}
+		return event{}, false
+	case "generating report":
+		if t.mentions(fields["roots"]) {
+			return event{stage: stageCommitReportGenerated, data: data}, true
+		}
+		return event{}, false
+	}
+
+	if data.Plugin == "Commit" {
+		for _, key := range []string{"offRampNextSeqNums", "seqNums"} {
+			if t.seqNumAtLeast(fields[key], t.seqNum+1) {
+				return event{stage: stageCommitted, detail: message, data: data}, true
+			}
+		}
+	}
+
+	if !t.mentions(fields) {
+		return event{}, false
+	}
+
+	if data.Plugin != "Execute" {
+		return event{stage: stageMentioned, detail: message, data: data}, true
+	}
+
+	if state, ok := fields["messageState"].(string); ok && state != "" {
+		switch state {
+		case messageStateAlreadyExecuted:
+			return event{stage: stageExecExecuted, data: data}, true
+		case messageStateInflight:
+			return event{stage: stageExecInflight, data: data}, true
+		case messageStateTokenDataNotReady:
+			return event{stage: stageExecTokenDataNotReady, detail: fmt.Sprintf("%v", fields["error"]), data: data}, true
+		default:
+			return event{stage: stageExecNotReady, detail: state, data: data}, true
+		}
+	}
+
+	switch {
+	case message == "read token data":
+		return event{stage: stageExecTokenDataReady, data: data}, true
+	case message == "messages added to report":
+		return event{stage: stageExecAddedToReport, data: data}, true
+	case hasMessageHeader(fields):
+		return event{stage: stageExecMessage, detail: message, data: data}, true
+	default:
+		return event{stage: stageExecCommitReport, detail: message, data: data}, true
+	}
+}
+
+// hasMessageHeader checks whether the logged fields contain full messages rather than only
+// commit report ranges.
+func hasMessageHeader(fields map[string]any) bool {
+	return walkMaps(fields, func(m map[string]any) bool {
+		_, ok := m["header"].(map[string]any)
+		return ok
+	})
+}
+
+// stageSummary aggregates all events of one stage.
+type stageSummary struct {
+	stage   stage
+	first   time.Time
+	last    time.Time
+	count   int
+	oracles map[int]struct{}
+	details map[string]struct{}
+}
+
+func render(t target, events []event) string {
+	var b strings.Builder
+	b.WriteString(section.Render("Message " + t.String()))
+	b.WriteString("\n")
+
+	if len(events) == 0 {
+		b.WriteString(padding)
+		b.WriteString(problem.Render("no logs found for this message"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	summaries := make(map[stage]*stageSummary)
+	var lastStage *stageSummary
+	for _, e := range events {
+		ts := e.data.GetTimestamp()
+		s, ok := summaries[e.stage]
+		if !ok {
+			s = &stageSummary{
+				stage:   e.stage,
+				first:   ts,
+				oracles: make(map[int]struct{}),
+				details: make(map[string]struct{}),
+			}
+			summaries[e.stage] = s
+		}
+		s.last = ts
+		s.count++
+		s.oracles[e.data.OracleID] = struct{}{}
+		if e.detail != "" {
+			s.details[e.detail] = struct{}{}
+		}
+		if lastStage == nil || e.stage >= lastStage.stage {
+			lastStage = s
+		}
+	}
+
+	ordered := make([]*stageSummary, 0, len(summaries))
+	for _, s := range summaries {
+		ordered = append(ordered, s)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].stage < ordered[j].stage
+	})
+
+	for _, s := range ordered {
+		b.WriteString(fmt.Sprintf("%s%s  %-60s %3d logs, %d oracles",
+			padding, s.first.Format(time.DateTime+".000"), stageNames[s.stage], s.count, len(s.oracles)))
+		if !s.last.Equal(s.first) {
+			b.WriteString(fmt.Sprintf(", last seen %s", s.last.Format(time.DateTime+".000")))
+		}
+		b.WriteString("\n")
+		details := make([]string, 0, len(s.details))
+		for d := range s.details {
+			details = append(details, d)
+		}
+		sort.Strings(details)
+		for _, d := range details {
+			b.WriteString(fmt.Sprintf("%s%s- %s\n", padding, padding, d))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("%sLast seen state: %s at %s\n", padding,
+		highlight.Render(stageNames[lastStage.stage]), lastStage.last.Format(time.DateTime+".000")))
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package lifecycle
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+const (
+	msgID       = "0x9f1e3a6b14b0ee4e0c5d2a7c4c8b0fd2c6b7bd1e1bb9b0fa1c29e6d9e3bd0a11"
+	sourceChain = uint64(3379446385462418246)
+)
+
+//nolint:lll // long test data
+var testLogs = []string{
+	`{"level":"info","ts":"2024-12-09T20:59:50.000Z","msg":"sending merkle root processor observation","plugin":"Commit","oracleID":0,"donID":1,"ocrSeqNr":10,"observation":{"onRampMaxSeqNums":[{"chainSel":3379446385462418246,"seqNum":7}],"offRampNextSeqNums":[{"chainSel":3379446385462418246,"seqNum":5}]}}`,
+	`{"level":"info","ts":"2024-12-09T20:59:51.000Z","msg":"Sending Outcome","plugin":"Commit","oracleID":0,"donID":1,"ocrSeqNr":11,"outcome":{"outcomeType":1,"rangesSelectedForReport":[{"chain":3379446385462418246,"seqNumRange":[5,7]}]}}`,
+	`{"level":"info","ts":"2024-12-09T20:59:52.000Z","msg":"Sending Outcome","plugin":"Commit","oracleID":1,"donID":1,"ocrSeqNr":12,"outcome":{"outcomeType":2,"rootsToReport":[{"chain":3379446385462418246,"seqNumsRange":[5,7],"merkleRoot":"0x01"}],"rmnReportSignatures":[{"r":"0x01","s":"0x02"}]}}`,
+	`{"level":"info","ts":"2024-12-09T20:59:53.000Z","msg":"Sending Outcome","plugin":"Commit","oracleID":1,"donID":1,"ocrSeqNr":14,"outcome":{"outcomeType":4,"offRampNextSeqNums":[{"chainSel":3379446385462418246,"seqNum":8}]}}`,
+	`{"level":"info","ts":"2024-12-09T20:59:54.000Z","msg":"commit reports","plugin":"Execute","oracleID":2,"donID":2,"ocrSeqNr":3,"candidateReports":[{"chainSelector":3379446385462418246,"sequenceNumberRange":[5,7]}]}`,
+	`{"level":"info","ts":"2024-12-09T20:59:55.000Z","msg":"unable to read token data - token data not ready","plugin":"Execute","oracleID":2,"donID":2,"ocrSeqNr":4,"messageID":"0x9F1E3A6B14B0EE4E0C5D2A7C4C8B0FD2C6B7BD1E1BB9B0FA1C29E6D9E3BD0A11","sourceChain":3379446385462418246,"seqNum":6,"error":"attestation pending","messageState":"token_data_not_ready"}`,
+	`{"level":"info","ts":"2024-12-09T20:59:56.000Z","msg":"read token data","plugin":"Execute","oracleID":2,"donID":2,"ocrSeqNr":6,"messageID":"0x9f1e3a6b14b0ee4e0c5d2a7c4c8b0fd2c6b7bd1e1bb9b0fa1c29e6d9e3bd0a11","sourceChain":3379446385462418246,"seqNum":6}`,
+	`{"level":"info","ts":"2024-12-09T20:59:57.000Z","msg":"messages added to report","plugin":"Execute","oracleID":2,"donID":2,"ocrSeqNr":6,"messageIDs":["0x9f1e3a6b14b0ee4e0c5d2a7c4c8b0fd2c6b7bd1e1bb9b0fa1c29e6d9e3bd0a11"],"seqNums":[6]}`,
+	// a different message on the same chain.
+	`{"level":"info","ts":"2024-12-09T20:59:58.000Z","msg":"read token data","plugin":"Execute","oracleID":2,"donID":2,"ocrSeqNr":6,"messageID":"0x01","sourceChain":3379446385462418246,"seqNum":9}`,
+}
+
+func runLifecycle(t *testing.T, tgt target) string {
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	var out bytes.Buffer
+	lf := newLifecycleFormatter(tgt, &out)
+	for _, line := range testLogs {
+		data, err := parse.ParseLine(line, parse.LogTypeJSON)
+		require.NoError(t, err)
+		lf.Format(data)
+	}
+	require.NoError(t, lf.Close())
+	return out.String()
+}
+
+func TestLifecycle_ByMessageID(t *testing.T) {
+	out := runLifecycle(t, newTarget(msgID, 0, 0))
+
+	require.Contains(t, out, fmt.Sprintf("sourceChain=%d seqNum=6", sourceChain))
+	for _, s := range []stage{
+		stageSent, stageRangeSelected, stageRMNSigned, stageCommitted, stageExecCommitReport,
+		stageExecTokenDataNotReady, stageExecTokenDataReady, stageExecAddedToReport,
+	} {
+		require.Contains(t, out, stageNames[s])
+	}
+	require.Contains(t, out, "attestation pending")
+	require.Contains(t, out, "Last seen state: exec: added to report at 2024-12-09 20:59:57.000")
+	require.NotContains(t, out, "20:59:58")
+}
+
+func TestLifecycle_BySourceChainAndSeqNum(t *testing.T) {
+	out := runLifecycle(t, newTarget("", sourceChain, 6))
+	require.Contains(t, out, "0x9f1e3a6b14b0ee4e0c5d2a7c4c8b0fd2c6b7bd1e1bb9b0fa1c29e6d9e3bd0a11")
+	require.Contains(t, out, "Last seen state: exec: added to report")
+}
+
+func TestLifecycle_NotFound(t *testing.T) {
+	out := runLifecycle(t, newTarget("", sourceChain, 100))
+	require.Contains(t, out, "no logs found for this message")
+}
+
+func TestLifecycle_NoTarget(t *testing.T) {
+	_, err := format.GetFormatter("lifecycle", format.Options{})
+	require.Error(t, err)
+
+	_, err = format.GetFormatter("lifecycle", format.Options{SourceChain: sourceChain})
+	require.Error(t, err)
+
+	_, err = format.GetFormatter("lifecycle", format.Options{MessageID: "0x01"})
+	require.NoError(t, err)
+}
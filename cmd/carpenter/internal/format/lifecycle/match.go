@@ -0,0 +1,240 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field names used across the commit and exec plugin logs to refer to chains, sequence numbers
+// and messages. Data structures are logged as JSON, so the names come from their json tags.
+var (
+	chainKeys = []string{
+		"chain", "chainSel", "chainSelector", "sourceChain", "sourceChainSelector", "sourceChainSel",
+	}
+	seqNumKeys      = []string{"seqNum", "seqNr", "sequenceNumber"}
+	seqNumListKeys  = []string{"seqNums"}
+	seqNumRangeKeys = []string{"seqNumRange", "seqNumsRange", "sequenceNumberRange", "seqNumberRange"}
+	messageIDKeys   = []string{"messageId", "messageID", "msgID"}
+)
+
+// target is the message being tracked, either by ID or by (sourceChain, seqNum).
+type target struct {
+	// messageID is lower case without the 0x prefix.
+	messageID   string
+	sourceChain uint64
+	seqNum      uint64
+}
+
+func newTarget(messageID string, sourceChain, seqNum uint64) target {
+	return target{
+		messageID:   normalizeID(messageID),
+		sourceChain: sourceChain,
+		seqNum:      seqNum,
+	}
+}
+
+func (t target) hasID() bool {
+	return t.messageID != ""
+}
+
+func (t target) hasChainSeqNum() bool {
+	return t.sourceChain != 0 && t.seqNum != 0
+}
+
+func (t target) String() string {
+	var parts []string
+	if t.hasID() {
+		parts = append(parts, "0x"+t.messageID)
+	}
+	if t.hasChainSeqNum() {
+		parts = append(parts, fmt.Sprintf("sourceChain=%d seqNum=%d", t.sourceChain, t.seqNum))
+	}
+	return strings.Join(parts, " ")
+}
+
+// learn fills in the missing half of the target, i.e. the (sourceChain, seqNum) of a message
+// when tracking by ID, by looking for an object that has both, like a message header.
+func (t target) learn(fields map[string]any) target {
+	if t.hasID() && t.hasChainSeqNum() {
+		return t
+	}
+	walkMaps(fields, func(m map[string]any) bool {
+		id, hasID := stringField(m, messageIDKeys)
+		chain, hasChain := numberField(m, chainKeys)
+		seqNum, hasSeqNum := numberField(m, seqNumKeys)
+		if !hasID || !hasChain || !hasSeqNum {
+			return false
+		}
+		switch {
+		case t.hasID() && normalizeID(id) == t.messageID:
+			t.sourceChain, t.seqNum = chain, seqNum
+			return true
+		case t.hasChainSeqNum() && chain == t.sourceChain && seqNum == t.seqNum:
+			t.messageID = normalizeID(id)
+			return true
+		}
+		return false
+	})
+	return t
+}
+
+// mentions reports whether the value refers to the message anywhere.
+func (t target) mentions(v any) bool {
+	found := false
+	walk(v, func(v any) bool {
+		switch val := v.(type) {
+		case string:
+			found = t.hasID() && normalizeID(val) == t.messageID
+		case map[string]any:
+			found = t.inObject(val)
+		}
+		return found
+	})
+	return found
+}
+
+// inObject checks whether a single object refers to the message by (sourceChain, seqNum).
+func (t target) inObject(m map[string]any) bool {
+	if !t.hasChainSeqNum() {
+		return false
+	}
+	chain, ok := numberField(m, chainKeys)
+	if !ok || chain != t.sourceChain {
+		return false
+	}
+	if seqNum, ok := numberField(m, seqNumKeys); ok && seqNum == t.seqNum {
+		return true
+	}
+	for _, key := range seqNumListKeys {
+		if list, ok := m[key].([]any); ok {
+			for _, item := range list {
+				if n, ok := toNumber(item); ok && n == t.seqNum {
+					return true
+				}
+			}
+		}
+	}
+	for _, key := range seqNumRangeKeys {
+		if start, end, ok := toRange(m[key]); ok && start <= t.seqNum && t.seqNum <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// seqNumAtLeast reports whether any {chainSel, seqNum} entry of the list is for the message's
+// source chain and has a sequence number of at least minSeqNum.
+func (t target) seqNumAtLeast(list any, minSeqNum uint64) bool {
+	entries, ok := list.([]any)
+	if !ok || !t.hasChainSeqNum() {
+		return false
+	}
+	for _, entry := range entries {
+		m, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		chain, ok1 := numberField(m, chainKeys)
+		seqNum, ok2 := numberField(m, seqNumKeys)
+		if ok1 && ok2 && chain == t.sourceChain && seqNum >= minSeqNum {
+			return true
+		}
+	}
+	return false
+}
+
+// walk visits v and everything nested in it until visit returns true.
+func walk(v any, visit func(v any) bool) bool {
+	if visit(v) {
+		return true
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		for _, item := range val {
+			if walk(item, visit) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if walk(item, visit) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// walkMaps visits every object nested in v until visit returns true.
+func walkMaps(v any, visit func(m map[string]any) bool) bool {
+	return walk(v, func(v any) bool {
+		m, ok := v.(map[string]any)
+		return ok && visit(m)
+	})
+}
+
+func stringField(m map[string]any, keys []string) (string, bool) {
+	for _, key := range keys {
+		if s, ok := m[key].(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func numberField(m map[string]any, keys []string) (uint64, bool) {
+	for _, key := range keys {
+		if n, ok := toNumber(m[key]); ok {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// toNumber converts JSON numbers and numeric strings, some fields are encoded as strings
+// to avoid losing precision on large chain selectors.
+func toNumber(v any) (uint64, bool) {
+	switch val := v.(type) {
+	case json.Number:
+		n, err := strconv.ParseUint(val.String(), 10, 64)
+		return n, err == nil
+	case float64:
+		if val < 0 {
+			return 0, false
+		}
+		return uint64(val), true
+	case string:
+		n, err := strconv.ParseUint(val, 10, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toRange parses a sequence number range, encoded as [start, end] in JSON or "[start -> end]"
+// when logged with its String method.
+func toRange(v any) (uint64, uint64, bool) {
+	switch val := v.(type) {
+	case []any:
+		if len(val) != 2 {
+			return 0, 0, false
+		}
+		start, ok1 := toNumber(val[0])
+		end, ok2 := toNumber(val[1])
+		return start, end, ok1 && ok2
+	case string:
+		var start, end uint64
+		if _, err := fmt.Sscanf(val, "[%d -> %d]", &start, &end); err != nil {
+			return 0, 0, false
+		}
+		return start, end, true
+	default:
+		return 0, 0, false
+	}
+}
+
+func normalizeID(id string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(id)), "0x")
+}
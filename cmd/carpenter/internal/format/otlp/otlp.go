@@ -0,0 +1,279 @@
+// Package otlp converts logs into OpenTelemetry traces encoded as OTLP JSON, which can be
+// loaded into Jaeger or Tempo offline to get a flame-graph view of OCR round latency.
+//
+// Each OCR round (DON, plugin, seqNr) becomes a trace. Within a trace every oracle gets a
+// span per OCR phase and a child span per component (processor) that logged in that phase.
+// Log lines are attached to the innermost span as span events.
+package otlp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func init() {
+	format.Register("otlp", otlpFormatterFactory,
+		"Convert OCR rounds into OTLP JSON traces, one file per round when --output-dir is set.")
+}
+
+const scopeName = "carpenter"
+
+// OTLP span kind, see opentelemetry-proto trace.proto.
+const spanKindInternal = 1
+
+func otlpFormatterFactory(options format.Options) format.Formatter {
+	return newOTLPFormatter(options.OutputDir, os.Stdout)
+}
+
+func newOTLPFormatter(outputDir string, stdout io.Writer) *otlpFormatter {
+	return &otlpFormatter{
+		outputDir: outputDir,
+		stdout:    stdout,
+		rounds:    make(map[roundKey][]*parse.Data),
+	}
+}
+
+// roundKey identifies a single OCR round, which is exported as a trace.
+type roundKey struct {
+	donID  int
+	plugin string
+	seqNr  int
+}
+
+func (k roundKey) String() string {
+	return fmt.Sprintf("%d/%s/%d", k.donID, k.plugin, k.seqNr)
+}
+
+// otlpFormatter collects logs by OCR round and exports them when closed.
+type otlpFormatter struct {
+	outputDir string
+	stdout    io.Writer
+	rounds    map[roundKey][]*parse.Data
+}
+
+func (of *otlpFormatter) Format(data *parse.Data) {
+	if data.SequenceNumber == 0 {
+		// Not part of an OCR round.
+		return
+	}
+	key := roundKey{donID: data.DONID, plugin: data.Plugin, seqNr: data.SequenceNumber}
+	of.rounds[key] = append(of.rounds[key], data)
+}
+
+func (of *otlpFormatter) Close() error {
+	keys := maps.Keys(of.rounds)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].donID != keys[j].donID {
+			return keys[i].donID < keys[j].donID
+		}
+		if keys[i].plugin != keys[j].plugin {
+			return keys[i].plugin < keys[j].plugin
+		}
+		return keys[i].seqNr < keys[j].seqNr
+	})
+
+	if of.outputDir == "" {
+		// Everything goes into a single request.
+		var request traceRequest
+		for _, key := range keys {
+			request.ResourceSpans = append(request.ResourceSpans, buildTrace(key, of.rounds[key])...)
+		}
+		enc := json.NewEncoder(of.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(request)
+	}
+
+	if err := os.MkdirAll(of.outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", of.outputDir, err)
+	}
+	for _, key := range keys {
+		request := traceRequest{ResourceSpans: buildTrace(key, of.rounds[key])}
+		filename := filepath.Join(of.outputDir,
+			fmt.Sprintf("trace-don%d-%s-%d.json", key.donID, strings.ToLower(key.plugin), key.seqNr))
+		raw, err := json.MarshalIndent(request, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode trace %s: %w", key, err)
+		}
+		if err := os.WriteFile(filename, raw, 0o600); err != nil {
+			return fmt.Errorf("failed to write trace %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// buildTrace converts the logs of a single round into spans, grouped by oracle so that
+// each oracle shows up as its own service.
+func buildTrace(key roundKey, logs []*parse.Data) []resourceSpans {
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].GetTimestamp().Before(logs[j].GetTimestamp())
+	})
+
+	traceID := newID(16, key.String())
+	roundSpanID := newID(8, key.String(), "round")
+
+	var roundStart, roundEnd time.Time
+	byOracle := make(map[int][]*parse.Data)
+	for _, log := range logs {
+		ts := log.GetTimestamp()
+		if roundStart.IsZero() || ts.Before(roundStart) {
+			roundStart = ts
+		}
+		if ts.After(roundEnd) {
+			roundEnd = ts
+		}
+		byOracle[log.OracleID] = append(byOracle[log.OracleID], log)
+	}
+
+	oracles := maps.Keys(byOracle)
+	sort.Ints(oracles)
+
+	var result []resourceSpans
+	for i, oracleID := range oracles {
+		oracleKey := fmt.Sprintf("%s/%d", key, oracleID)
+		var spans []span
+
+		// The round span is the root of the trace, it is attributed to the first oracle.
+		if i == 0 {
+			spans = append(spans, span{
+				TraceID:           traceID,
+				SpanID:            roundSpanID,
+				Name:              fmt.Sprintf("%s round %d", key.plugin, key.seqNr),
+				Kind:              spanKindInternal,
+				StartTimeUnixNano: unixNano(roundStart),
+				EndTimeUnixNano:   unixNano(roundEnd),
+				Attributes: []keyValue{
+					intAttr("donID", key.donID),
+					stringAttr("plugin", key.plugin),
+					intAttr("ocrSeqNr", key.seqNr),
+				},
+			})
+		}
+
+		phaseSpans := make(map[string]*span)
+		componentSpans := make(map[string]*span)
+		var order []*span
+		for _, log := range byOracle[oracleID] {
+			phase := log.OCRPhase
+			if phase == "" {
+				phase = "unknown"
+			}
+
+			phaseSpan, ok := phaseSpans[phase]
+			if !ok {
+				phaseSpan = &span{
+					TraceID:      traceID,
+					SpanID:       newID(8, oracleKey, phase),
+					ParentSpanID: roundSpanID,
+					Name:         fmt.Sprintf("%s oracle %d", phase, oracleID),
+					Kind:         spanKindInternal,
+					Attributes: []keyValue{
+						stringAttr("ocrPhase", phase),
+						intAttr("oracleID", oracleID),
+					},
+				}
+				phaseSpans[phase] = phaseSpan
+				order = append(order, phaseSpan)
+			}
+			extend(phaseSpan, log.GetTimestamp())
+
+			target := phaseSpan
+			if log.Component != "" {
+				componentKey := phase + "/" + log.Component
+				componentSpan, ok := componentSpans[componentKey]
+				if !ok {
+					componentSpan = &span{
+						TraceID:      traceID,
+						SpanID:       newID(8, oracleKey, componentKey),
+						ParentSpanID: phaseSpan.SpanID,
+						Name:         log.Component,
+						Kind:         spanKindInternal,
+						Attributes: []keyValue{
+							stringAttr("component", log.Component),
+							stringAttr("ocrPhase", phase),
+							intAttr("oracleID", oracleID),
+						},
+					}
+					componentSpans[componentKey] = componentSpan
+					order = append(order, componentSpan)
+				}
+				extend(componentSpan, log.GetTimestamp())
+				target = componentSpan
+			}
+			target.Events = append(target.Events, logEvent(log))
+
+			if strings.EqualFold(log.GetLevel(), "error") {
+				target.Status = &status{Code: statusCodeError, Message: log.GetMessage()}
+			}
+		}
+
+		for _, s := range order {
+			s.StartTimeUnixNano = unixNano(s.start)
+			s.EndTimeUnixNano = unixNano(s.end)
+			spans = append(spans, *s)
+		}
+
+		result = append(result, resourceSpans{
+			Resource: resource{Attributes: []keyValue{
+				stringAttr("service.name", fmt.Sprintf("don%d-oracle%d", key.donID, oracleID)),
+				intAttr("donID", key.donID),
+				intAttr("oracleID", oracleID),
+			}},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName},
+				Spans: spans,
+			}},
+		})
+	}
+
+	return result
+}
+
+// extend widens the span so that it includes ts.
+func extend(s *span, ts time.Time) {
+	if s.start.IsZero() || ts.Before(s.start) {
+		s.start = ts
+	}
+	if ts.After(s.end) {
+		s.end = ts
+	}
+}
+
+func logEvent(log *parse.Data) event {
+	attrs := []keyValue{
+		stringAttr("level", log.GetLevel()),
+		stringAttr("caller", log.GetCaller()),
+	}
+	if err, ok := log.RawLoggerFields["err"]; ok {
+		attrs = append(attrs, stringAttr("err", fmt.Sprintf("%v", err)))
+	}
+	return event{
+		TimeUnixNano: unixNano(log.GetTimestamp()),
+		Name:         log.GetMessage(),
+		Attributes:   attrs,
+	}
+}
+
+// newID derives a deterministic trace or span ID of n bytes from the parts, so exporting the
+// same logs twice produces the same traces.
+func newID(n int, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:n])
+}
+
+func unixNano(ts time.Time) string {
+	return strconv.FormatInt(ts.UnixNano(), 10)
+}
@@ -0,0 +1,84 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func logAt(oracleID, seqNr int, phase, component string, offset time.Duration) *parse.Data {
+	base := time.Date(2025, 1, 20, 11, 50, 22, 0, time.UTC)
+	return &parse.Data{
+		ProdTimestamp:  base.Add(offset).Format(time.RFC3339Nano),
+		ProdLevel:      "info",
+		ProdMessage:    "message",
+		Plugin:         "Commit",
+		Component:      component,
+		DONID:          1,
+		OracleID:       oracleID,
+		SequenceNumber: seqNr,
+		OCRPhase:       phase,
+	}
+}
+
+func TestOTLPFormatter_Stdout(t *testing.T) {
+	var out bytes.Buffer
+	of := newOTLPFormatter("", &out)
+	of.Format(logAt(0, 5, "obs", "MerkleRoot", 0))
+	of.Format(logAt(0, 5, "obs", "MerkleRoot", 300*time.Millisecond))
+	of.Format(logAt(0, 5, "obs", "", 400*time.Millisecond))
+	of.Format(logAt(1, 5, "otcm", "ChainFee", time.Second))
+	of.Format(logAt(1, 0, "", "", 0)) // ignored, not in a round.
+	require.NoError(t, of.Close())
+
+	var request traceRequest
+	require.NoError(t, json.Unmarshal(out.Bytes(), &request))
+	require.Len(t, request.ResourceSpans, 2, "one resource per oracle")
+
+	oracle0 := request.ResourceSpans[0].ScopeSpans[0].Spans
+	oracle1 := request.ResourceSpans[1].ScopeSpans[0].Spans
+	require.Len(t, oracle0, 3, "round, phase and component spans")
+	require.Len(t, oracle1, 2, "phase and component spans")
+
+	round, phase, component := oracle0[0], oracle0[1], oracle0[2]
+	require.Equal(t, "Commit round 5", round.Name)
+	require.Empty(t, round.ParentSpanID)
+	require.Equal(t, round.SpanID, phase.ParentSpanID)
+	require.Equal(t, phase.SpanID, component.ParentSpanID)
+	require.Equal(t, "MerkleRoot", component.Name)
+	require.Len(t, component.Events, 2)
+	require.Len(t, phase.Events, 1)
+
+	for _, s := range append(oracle0, oracle1...) {
+		require.Equal(t, round.TraceID, s.TraceID)
+		require.Len(t, s.TraceID, 32)
+		require.Len(t, s.SpanID, 16)
+	}
+
+	start := time.Date(2025, 1, 20, 11, 50, 22, 0, time.UTC)
+	require.Equal(t, unixNano(start), round.StartTimeUnixNano)
+	require.Equal(t, unixNano(start.Add(time.Second)), round.EndTimeUnixNano)
+	require.Equal(t, unixNano(start.Add(300*time.Millisecond)), component.EndTimeUnixNano)
+	require.Equal(t, unixNano(start.Add(400*time.Millisecond)), phase.EndTimeUnixNano)
+}
+
+func TestOTLPFormatter_OutputDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "traces")
+	of := newOTLPFormatter(dir, nil)
+	of.Format(logAt(0, 5, "obs", "", 0))
+	of.Format(logAt(0, 6, "obs", "", 0))
+	require.NoError(t, of.Close())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "trace-don1-commit-5.json", entries[0].Name())
+	require.Equal(t, "trace-don1-commit-6.json", entries[1].Name())
+}
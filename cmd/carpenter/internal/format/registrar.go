@@ -10,6 +10,18 @@ import (
 
 // Options is a struct that holds options for all formatters.
 type Options struct {
+	// OutputDir is where formatters that produce files write them.
+	// When empty they write to stdout instead.
+	OutputDir string
+
+	// MessageID, or SourceChain and SeqNum, select a message for formatters that follow a single message.
+	MessageID   string
+	SourceChain uint64
+	SeqNum      uint64
+
+	// Fields are logger fields included by the structured formatters, nested fields are
+	// separated with dots.
+	Fields []string
 }
 
 // FormatterFactory is a function that returns a Formatter, implemented by formatter to apply options.
@@ -20,6 +32,12 @@ type Formatter interface {
 	Format(data *parse.Data)
 }
 
+// Validator is implemented by formatters that require some options, they are
+// validated before any input is read.
+type Validator interface {
+	Validate() error
+}
+
 // formatterFactories is a map of formatters by name.
 var formatterFactories = make(map[string]FormatterFactory)
 
@@ -55,7 +73,13 @@ func GetFormatter(name string, options Options) (Formatter, error) {
 	if !ok {
 		return nil, fmt.Errorf("formatter %s not found", name)
 	}
-	return factory(options), nil
+	formatter := factory(options)
+	if v, ok := formatter.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options for formatter %s: %w", name, err)
+		}
+	}
+	return formatter, nil
 }
 
 func GetFormatters() []string {
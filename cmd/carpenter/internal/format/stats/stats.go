@@ -0,0 +1,451 @@
+// Package stats consumes the whole log stream and prints aggregate statistics and anomalies,
+// intended as a first-pass triage tool, i.e. on CI failure logs.
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/logutil"
+)
+
+func init() {
+	format.Register("stats", statsFormatterFactory,
+		"Print aggregate statistics: errors by caller and component, phase latencies, reports and rounds without outcome.")
+
+	section = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#3366FF"))
+	highlight = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF9933"))
+}
+
+var section lipgloss.Style
+var highlight lipgloss.Style
+
+const (
+	padding = "    "
+	// topN limits the number of entries printed in ranked tables.
+	topN = 10
+	// maxListedRounds limits how many sequence numbers are listed for rounds without outcome.
+	maxListedRounds = 20
+)
+
+// reportsBuiltRegex matches the report plugin log "Report building complete: built N reports"
+// and captures the number of reports.
+var reportsBuiltRegex = regexp.MustCompile("built (\\d+) reports$")
+
+// durationFields are logger fields holding how long a plugin function took.
+var durationFields = []string{"observationDuration", "outcomeDuration"}
+
+func statsFormatterFactory(options format.Options) format.Formatter {
+	return newStatsFormatter(os.Stdout)
+}
+
+func newStatsFormatter(out io.Writer) *statsFormatter {
+	return &statsFormatter{
+		out:               out,
+		levels:            make(map[string]int),
+		problemsByCaller:  make(map[string]*levelCounts),
+		problemsByComp:    make(map[string]*levelCounts),
+		phaseSpans:        make(map[phaseKey]*span),
+		durations:         make(map[string][]time.Duration),
+		reportsByRound:    make(map[roundKey]int),
+		rounds:            make(map[roundKey]map[string]bool),
+		recurringMessages: make(map[string]*occurrences),
+	}
+}
+
+type levelCounts struct {
+	errors int
+	warns  int
+}
+
+// roundKey identifies an OCR round.
+type roundKey struct {
+	donID  int
+	plugin string
+	seqNr  int
+}
+
+// phaseKey identifies the part of a round that a single oracle spent in a phase.
+type phaseKey struct {
+	roundKey
+	oracleID int
+	phase    string
+}
+
+type span struct {
+	first, last time.Time
+}
+
+type occurrences struct {
+	count       int
+	first, last time.Time
+}
+
+// statsFormatter aggregates statistics across all logs.
+type statsFormatter struct {
+	out io.Writer
+
+	total  int
+	levels map[string]int
+
+	problemsByCaller map[string]*levelCounts
+	problemsByComp   map[string]*levelCounts
+
+	phaseSpans map[phaseKey]*span
+	durations  map[string][]time.Duration
+
+	// reportsByRound is the number of reports built in each round, every oracle of the
+	// round logs it.
+	reportsByRound map[roundKey]int
+
+	// rounds tracks which phases were seen in each round.
+	rounds map[roundKey]map[string]bool
+
+	recurringMessages map[string]*occurrences
+}
+
+func (sf *statsFormatter) Format(data *parse.Data) {
+	sf.total++
+	level := strings.ToLower(data.GetLevel())
+	sf.levels[level]++
+	ts := data.GetTimestamp()
+
+	if isError(level) || level == "warn" {
+		caller := orUnknown(data.GetCaller())
+		component := orUnknown(data.Component)
+		if sf.problemsByCaller[caller] == nil {
+			sf.problemsByCaller[caller] = &levelCounts{}
+		}
+		if sf.problemsByComp[component] == nil {
+			sf.problemsByComp[component] = &levelCounts{}
+		}
+		if isError(level) {
+			sf.problemsByCaller[caller].errors++
+			sf.problemsByComp[component].errors++
+		} else {
+			sf.problemsByCaller[caller].warns++
+			sf.problemsByComp[component].warns++
+		}
+	}
+
+	if isError(level) {
+		msg := data.GetMessage()
+		o := sf.recurringMessages[msg]
+		if o == nil {
+			o = &occurrences{first: ts}
+			sf.recurringMessages[msg] = o
+		}
+		o.count++
+		if ts.Before(o.first) {
+			o.first = ts
+		}
+		if ts.After(o.last) {
+			o.last = ts
+		}
+	}
+
+	for _, field := range durationFields {
+		if d, ok := parseDuration(data.RawLoggerFields[field]); ok {
+			name := field
+			if data.Component != "" {
+				name = data.Component + "." + field
+			}
+			sf.durations[name] = append(sf.durations[name], d)
+		}
+	}
+
+	if matches := reportsBuiltRegex.FindStringSubmatch(data.GetMessage()); len(matches) > 1 {
+		if n, err := strconv.Atoi(matches[1]); err == nil {
+			rk := roundKey{donID: data.DONID, plugin: data.Plugin, seqNr: data.SequenceNumber}
+			sf.reportsByRound[rk] = max(sf.reportsByRound[rk], n)
+		}
+	}
+
+	if data.SequenceNumber != 0 && data.OCRPhase != "" {
+		rk := roundKey{donID: data.DONID, plugin: data.Plugin, seqNr: data.SequenceNumber}
+		if sf.rounds[rk] == nil {
+			sf.rounds[rk] = make(map[string]bool)
+		}
+		sf.rounds[rk][data.OCRPhase] = true
+
+		pk := phaseKey{roundKey: rk, oracleID: data.OracleID, phase: data.OCRPhase}
+		s := sf.phaseSpans[pk]
+		if s == nil {
+			sf.phaseSpans[pk] = &span{first: ts, last: ts}
+		} else {
+			if ts.Before(s.first) {
+				s.first = ts
+			}
+			if ts.After(s.last) {
+				s.last = ts
+			}
+		}
+	}
+}
+
+func (sf *statsFormatter) Close() error {
+	var b strings.Builder
+	sf.writeLevels(&b)
+	sf.writeProblems(&b, "Errors and warnings by caller", sf.problemsByCaller)
+	sf.writeProblems(&b, "Errors and warnings by component", sf.problemsByComp)
+	sf.writeLatencies(&b)
+	sf.writeReports(&b)
+	sf.writeRoundsWithoutOutcome(&b)
+	sf.writeRecurringErrors(&b)
+
+	_, err := io.WriteString(sf.out, b.String())
+	return err
+}
+
+func (sf *statsFormatter) writeLevels(b *strings.Builder) {
+	writeSection(b, "Log levels")
+	b.WriteString(fmt.Sprintf("%s%-10s %d\n", padding, "total", sf.total))
+	for _, level := range sortedKeys(sf.levels) {
+		b.WriteString(fmt.Sprintf("%s%-10s %d\n", padding, orUnknown(level), sf.levels[level]))
+	}
+}
+
+func (sf *statsFormatter) writeProblems(b *strings.Builder, title string, counts map[string]*levelCounts) {
+	writeSection(b, title)
+	if len(counts) == 0 {
+		b.WriteString(padding + "none\n")
+		return
+	}
+
+	keys := sortedKeys(counts)
+	sort.SliceStable(keys, func(i, j int) bool {
+		ci, cj := counts[keys[i]], counts[keys[j]]
+		if ci.errors != cj.errors {
+			return ci.errors > cj.errors
+		}
+		return ci.warns > cj.warns
+	})
+
+	b.WriteString(fmt.Sprintf("%s%-50s %8s %8s\n", padding, "", "errors", "warns"))
+	for i, key := range keys {
+		if i == topN {
+			b.WriteString(fmt.Sprintf("%s... %d more\n", padding, len(keys)-topN))
+			break
+		}
+		b.WriteString(fmt.Sprintf("%s%-50s %8d %8d\n", padding, key, counts[key].errors, counts[key].warns))
+	}
+}
+
+func (sf *statsFormatter) writeLatencies(b *strings.Builder) {
+	writeSection(b, "Phase latencies (first to last log of an oracle in a phase)")
+
+	byPhase := make(map[string][]time.Duration)
+	for key, s := range sf.phaseSpans {
+		byPhase[key.phase] = append(byPhase[key.phase], s.last.Sub(s.first))
+	}
+	if len(byPhase) == 0 {
+		b.WriteString(padding + "no OCR phase information found\n")
+	} else {
+		writeDurationHeader(b)
+		for _, phase := range orderedPhases(byPhase) {
+			writeDurationRow(b, phase, byPhase[phase])
+		}
+	}
+
+	if len(sf.durations) > 0 {
+		writeSection(b, "Reported durations")
+		writeDurationHeader(b)
+		for _, name := range sortedKeys(sf.durations) {
+			writeDurationRow(b, name, sf.durations[name])
+		}
+	}
+}
+
+func (sf *statsFormatter) writeReports(b *strings.Builder) {
+	writeSection(b, "Rounds by number of reports built")
+	if len(sf.reportsByRound) == 0 {
+		b.WriteString(padding + "no reports built\n")
+		return
+	}
+
+	roundsByReportCount := make(map[int]int)
+	total := 0
+	for _, n := range sf.reportsByRound {
+		roundsByReportCount[n]++
+		total += n
+	}
+	counts := make([]int, 0, len(roundsByReportCount))
+	for n := range roundsByReportCount {
+		counts = append(counts, n)
+	}
+	sort.Ints(counts)
+	for _, n := range counts {
+		b.WriteString(fmt.Sprintf("%s%3d reports: %d rounds\n", padding, n, roundsByReportCount[n]))
+	}
+	b.WriteString(fmt.Sprintf("%stotal: %d reports\n", padding, total))
+}
+
+func (sf *statsFormatter) writeRoundsWithoutOutcome(b *strings.Builder) {
+	writeSection(b, "Rounds without outcome")
+
+	var missing []roundKey
+	for key, phases := range sf.rounds {
+		// Only rounds which got as far as observing are expected to have an outcome.
+		if phases[logutil.PhaseObservation] && !phases[logutil.PhaseOutcome] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		b.WriteString(fmt.Sprintf("%snone of %d rounds\n", padding, len(sf.rounds)))
+		return
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].donID != missing[j].donID {
+			return missing[i].donID < missing[j].donID
+		}
+		if missing[i].plugin != missing[j].plugin {
+			return missing[i].plugin < missing[j].plugin
+		}
+		return missing[i].seqNr < missing[j].seqNr
+	})
+
+	b.WriteString(fmt.Sprintf("%s%s of %d rounds\n", padding,
+		highlight.Render(strconv.Itoa(len(missing))), len(sf.rounds)))
+	for i, key := range missing {
+		if i == maxListedRounds {
+			b.WriteString(fmt.Sprintf("%s... %d more\n", padding, len(missing)-maxListedRounds))
+			break
+		}
+		b.WriteString(fmt.Sprintf("%sDON %d %s seqNr %d\n", padding, key.donID, key.plugin, key.seqNr))
+	}
+}
+
+func (sf *statsFormatter) writeRecurringErrors(b *strings.Builder) {
+	writeSection(b, "Top recurring errors")
+	if len(sf.recurringMessages) == 0 {
+		b.WriteString(padding + "none\n")
+		return
+	}
+
+	messages := sortedKeys(sf.recurringMessages)
+	sort.SliceStable(messages, func(i, j int) bool {
+		return sf.recurringMessages[messages[i]].count > sf.recurringMessages[messages[j]].count
+	})
+	for i, msg := range messages {
+		if i == topN {
+			b.WriteString(fmt.Sprintf("%s... %d more\n", padding, len(messages)-topN))
+			break
+		}
+		o := sf.recurringMessages[msg]
+		b.WriteString(fmt.Sprintf("%s%6dx %s\n%s        first %s, last %s\n", padding, o.count, msg,
+			padding, o.first.Format(time.DateTime+".000"), o.last.Format(time.DateTime+".000")))
+	}
+}
+
+func writeSection(b *strings.Builder, title string) {
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(section.Render(title))
+	b.WriteString("\n")
+}
+
+func writeDurationHeader(b *strings.Builder) {
+	b.WriteString(fmt.Sprintf("%s%-40s %6s %10s %10s %10s\n", padding, "", "count", "p50", "p90", "max"))
+}
+
+func writeDurationRow(b *strings.Builder, name string, durations []time.Duration) {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	b.WriteString(fmt.Sprintf("%s%-40s %6d %10s %10s %10s\n", padding, name, len(durations),
+		percentile(durations, 50), percentile(durations, 90), durations[len(durations)-1]))
+}
+
+// percentile returns the p-th percentile of sorted durations using the nearest rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// orderedPhases returns the OCR phases in protocol order followed by any unknown phases.
+func orderedPhases(byPhase map[string][]time.Duration) []string {
+	known := []string{
+		logutil.PhaseQuery,
+		logutil.PhaseObservation,
+		logutil.PhaseOutcome,
+		logutil.PhaseReports,
+		logutil.PhaseShouldAccept,
+		logutil.PhaseShouldTransmit,
+	}
+	var result []string
+	for _, phase := range known {
+		if _, ok := byPhase[phase]; ok {
+			result = append(result, phase)
+		}
+	}
+	for _, phase := range sortedKeys(byPhase) {
+		if !slices.Contains(known, phase) {
+			result = append(result, phase)
+		}
+	}
+	return result
+}
+
+// parseDuration parses durations logged by zap, either as seconds or as a duration string.
+func parseDuration(v any) (time.Duration, bool) {
+	switch val := v.(type) {
+	case float64:
+		return time.Duration(val * float64(time.Second)), true
+	case json.Number:
+		seconds, err := val.Float64()
+		return time.Duration(seconds * float64(time.Second)), err == nil
+	case string:
+		d, err := time.ParseDuration(val)
+		return d, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func isError(level string) bool {
+	switch level {
+	case "error", "critical", "panic", "fatal", "dpanic", "crit":
+		return true
+	default:
+		return false
+	}
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
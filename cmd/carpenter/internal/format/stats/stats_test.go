@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func logAt(level, msg string, seqNr int, phase string, offset time.Duration) *parse.Data {
+	base := time.Date(2025, 1, 20, 11, 50, 22, 0, time.UTC)
+	return &parse.Data{
+		ProdTimestamp:   base.Add(offset).Format(time.RFC3339Nano),
+		ProdLevel:       level,
+		ProdMessage:     msg,
+		ProdCaller:      "merkleroot/observation.go:620",
+		Plugin:          "Commit",
+		Component:       "MerkleRoot",
+		DONID:           1,
+		SequenceNumber:  seqNr,
+		OCRPhase:        phase,
+		RawLoggerFields: map[string]any{},
+	}
+}
+
+func TestStatsFormatter(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	var out bytes.Buffer
+	sf := newStatsFormatter(&out)
+
+	sf.Format(logAt("info", "start", 1, "obs", 0))
+	obs := logAt("info", "sending merkle root processor observation", 1, "obs", 200*time.Millisecond)
+	obs.RawLoggerFields["observationDuration"] = 0.2
+	sf.Format(obs)
+	sf.Format(logAt("info", "outcome", 1, "otcm", time.Second))
+	sf.Format(logAt("info", "Report building complete: built 2 reports", 1, "rprt", time.Second))
+	// every oracle of the round logs the reports it built.
+	sf.Format(logAt("info", "Report building complete: built 2 reports", 1, "rprt", time.Second))
+	// round 2 never reaches the outcome.
+	sf.Format(logAt("error", "call to MsgsBetweenSeqNums failed", 2, "obs", 2*time.Second))
+	sf.Format(logAt("error", "call to MsgsBetweenSeqNums failed", 3, "obs", 3*time.Second))
+	sf.Format(logAt("warn", "RMN signatures not available", 3, "otcm", 4*time.Second))
+
+	require.NoError(t, sf.Close())
+	result := out.String()
+
+	require.Contains(t, result, "total      8\n")
+	require.Contains(t, result, "error      2\n")
+	require.Regexp(t, `merkleroot/observation.go:620\s+2\s+1\n`, result)
+	require.Regexp(t, `MerkleRoot\s+2\s+1\n`, result)
+	require.Regexp(t, `obs\s+3\s+0s\s+200ms\s+200ms\n`, result)
+	require.Regexp(t, `MerkleRoot.observationDuration\s+1\s+200ms`, result)
+	require.Contains(t, result, "  2 reports: 1 rounds\n")
+	require.Contains(t, result, "1 of 3 rounds\n    DON 1 Commit seqNr 2\n")
+	require.Contains(t, result, "     2x call to MsgsBetweenSeqNums failed\n")
+	require.Contains(t, result, "first 2025-01-20 11:50:24.000, last 2025-01-20 11:50:25.000")
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	require.Equal(t, time.Duration(5), percentile(durations, 50))
+	require.Equal(t, time.Duration(9), percentile(durations, 90))
+	require.Equal(t, time.Duration(1), percentile(durations[:1], 90))
+	require.Equal(t, time.Duration(0), percentile(nil, 50))
+}
@@ -0,0 +1,54 @@
+package structured
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func init() {
+	format.Register("csv", csvFormatterFactory,
+		"Print a CSV row per log with the normalized fields and any --fields.")
+}
+
+func csvFormatterFactory(options format.Options) format.Formatter {
+	return newCSVFormatter(os.Stdout, options.Fields)
+}
+
+func newCSVFormatter(out io.Writer, fields []string) *csvFormatter {
+	return &csvFormatter{writer: csv.NewWriter(out), fields: fields}
+}
+
+// csvFormatter writes the header before the first row. Rows are flushed as they are written
+// so the output can be consumed while following logs.
+type csvFormatter struct {
+	writer      *csv.Writer
+	fields      []string
+	wroteHeader bool
+}
+
+func (cf *csvFormatter) Format(data *parse.Data) {
+	if !cf.wroteHeader {
+		cf.write(slices.Concat(columns, cf.fields))
+		cf.wroteHeader = true
+	}
+	cf.write(newRecord(data, cf.fields).values(cf.fields))
+}
+
+func (cf *csvFormatter) write(row []string) {
+	if err := cf.writer.Write(row); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write row: %s\n", err)
+		return
+	}
+	cf.writer.Flush()
+}
+
+func (cf *csvFormatter) Close() error {
+	cf.writer.Flush()
+	return cf.writer.Error()
+}
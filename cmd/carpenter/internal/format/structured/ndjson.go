@@ -0,0 +1,35 @@
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func init() {
+	format.Register("ndjson", ndjsonFormatterFactory,
+		"Print one JSON object per log with the normalized fields and any --fields.")
+}
+
+func ndjsonFormatterFactory(options format.Options) format.Formatter {
+	return newNDJSONFormatter(os.Stdout, options.Fields)
+}
+
+func newNDJSONFormatter(out io.Writer, fields []string) *ndjsonFormatter {
+	return &ndjsonFormatter{encoder: json.NewEncoder(out), fields: fields}
+}
+
+type ndjsonFormatter struct {
+	encoder *json.Encoder
+	fields  []string
+}
+
+func (nf *ndjsonFormatter) Format(data *parse.Data) {
+	if err := nf.encoder.Encode(newRecord(data, nf.fields)); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write record: %s\n", err)
+	}
+}
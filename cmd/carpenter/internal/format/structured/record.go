@@ -0,0 +1,114 @@
+// Package structured contains machine-readable formatters which emit the normalized log
+// fields, so carpenter can be used to normalize logs for data pipelines and spreadsheets.
+package structured
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+// columns are the normalized fields included in every record, selected logger fields follow.
+var columns = []string{
+	"timestamp", "level", "plugin", "component", "donID", "oracleID", "seqNr", "phase", "message",
+}
+
+// record is a normalized log line.
+type record struct {
+	Timestamp string         `json:"timestamp"`
+	Level     string         `json:"level"`
+	Plugin    string         `json:"plugin"`
+	Component string         `json:"component"`
+	DONID     int            `json:"donID"`
+	OracleID  int            `json:"oracleID"`
+	SeqNr     int            `json:"seqNr"`
+	Phase     string         `json:"phase"`
+	Message   string         `json:"message"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+func newRecord(data *parse.Data, fields []string) record {
+	r := record{
+		Level:     data.GetLevel(),
+		Plugin:    data.Plugin,
+		Component: data.Component,
+		DONID:     data.DONID,
+		OracleID:  data.OracleID,
+		SeqNr:     data.SequenceNumber,
+		Phase:     data.OCRPhase,
+		Message:   data.GetMessage(),
+	}
+	// GetTimestamp panics when there is no timestamp at all.
+	if data.ProdTimestamp != "" || data.TestTimestamp != "" {
+		r.Timestamp = data.GetTimestamp().UTC().Format(time.RFC3339Nano)
+	}
+	if len(fields) > 0 {
+		r.Fields = make(map[string]any, len(fields))
+		for _, name := range fields {
+			if v, ok := lookup(data.RawLoggerFields, name); ok {
+				r.Fields[name] = v
+			}
+		}
+	}
+	return r
+}
+
+// values returns the record as strings in column order followed by the selected fields.
+func (r record) values(fields []string) []string {
+	values := []string{
+		r.Timestamp,
+		r.Level,
+		r.Plugin,
+		r.Component,
+		strconv.Itoa(r.DONID),
+		strconv.Itoa(r.OracleID),
+		strconv.Itoa(r.SeqNr),
+		r.Phase,
+		r.Message,
+	}
+	for _, name := range fields {
+		values = append(values, valueString(r.Fields[name]))
+	}
+	return values
+}
+
+// lookup finds a logger field, nested fields are separated with dots (outcome.outcomeType).
+func lookup(fields map[string]any, name string) (any, bool) {
+	var current any = fields
+	for _, key := range strings.Split(name, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// valueString renders scalars as-is and objects or lists as JSON.
+func valueString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return ""
+		}
+		return string(raw)
+	}
+}
@@ -0,0 +1,83 @@
+package structured
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func testData() *parse.Data {
+	return &parse.Data{
+		ProdTimestamp:  "2025-01-20T13:50:22.325+02:00",
+		ProdLevel:      "info",
+		ProdMessage:    "sending outcome, with message",
+		Plugin:         "Commit",
+		Component:      "MerkleRoot",
+		DONID:          1,
+		OracleID:       2,
+		SequenceNumber: 10,
+		OCRPhase:       "otcm",
+		RawLoggerFields: map[string]any{
+			"sourceChain": float64(5009297550715157269),
+			"outcome": map[string]any{
+				"outcomeType": float64(1),
+				"rootsToReport": []any{
+					map[string]any{"chain": float64(1)},
+				},
+			},
+		},
+	}
+}
+
+func TestNDJSONFormatter(t *testing.T) {
+	var out bytes.Buffer
+	nf := newNDJSONFormatter(&out, []string{"sourceChain", "outcome.outcomeType", "missing"})
+	nf.Format(testData())
+	nf.Format(&parse.Data{ProdMessage: "no timestamp"})
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &got))
+	require.Equal(t, "2025-01-20T11:50:22.325Z", got["timestamp"])
+	require.Equal(t, "Commit", got["plugin"])
+	require.Equal(t, "otcm", got["phase"])
+	require.Equal(t, float64(2), got["oracleID"])
+	require.Equal(t, float64(10), got["seqNr"])
+	require.Equal(t, map[string]any{
+		"sourceChain":         float64(5009297550715157269),
+		"outcome.outcomeType": float64(1),
+	}, got["fields"])
+
+	got = nil
+	require.NoError(t, json.Unmarshal(lines[1], &got))
+	require.Equal(t, "", got["timestamp"])
+	require.Equal(t, "no timestamp", got["message"])
+	require.NotContains(t, got, "fields")
+}
+
+func TestCSVFormatter(t *testing.T) {
+	var out bytes.Buffer
+	cf := newCSVFormatter(&out, []string{"outcome.outcomeType", "outcome.rootsToReport"})
+	cf.Format(testData())
+	cf.Format(testData())
+	require.NoError(t, cf.Close())
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, []string{
+		"timestamp", "level", "plugin", "component", "donID", "oracleID", "seqNr", "phase", "message",
+		"outcome.outcomeType", "outcome.rootsToReport",
+	}, rows[0])
+	require.Equal(t, []string{
+		"2025-01-20T11:50:22.325Z", "info", "Commit", "MerkleRoot", "1", "2", "10", "otcm",
+		"sending outcome, with message", "1", `[{"chain":1}]`,
+	}, rows[1])
+}
@@ -0,0 +1,273 @@
+// Package timeline merges the logs of every oracle in a DON and prints a per-round timeline.
+// For each OCR round it shows when every oracle finished each phase, which makes it easy to
+// spot the oracle that was holding the round back.
+//
+// Provide the log files of all oracles at once, i.e.
+//
+//	carpenter --format timeline --filename node1.log --filename node2.log ...
+package timeline
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/logutil"
+)
+
+func init() {
+	format.Register("timeline", timelineFormatterFactory,
+		"Merge logs from all oracles of a DON and print a per-round timeline of each OCR phase.")
+
+	divider = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#75A3A3"))
+	header = lipgloss.NewStyle().
+		Bold(true)
+	slowest = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF9933"))
+	missing = lipgloss.NewStyle().
+		Faint(true)
+}
+
+var divider lipgloss.Style
+var header lipgloss.Style
+var slowest lipgloss.Style
+var missing lipgloss.Style
+
+// phases in the order they happen within an OCR round.
+var phases = []string{
+	logutil.PhaseQuery,
+	logutil.PhaseObservation,
+	logutil.PhaseOutcome,
+	logutil.PhaseReports,
+	logutil.PhaseShouldAccept,
+	logutil.PhaseShouldTransmit,
+}
+
+const (
+	padding     = "    "
+	columnWidth = 10
+)
+
+func timelineFormatterFactory(options format.Options) format.Formatter {
+	return newTimelineFormatter(os.Stdout)
+}
+
+func newTimelineFormatter(out io.Writer) *timelineFormatter {
+	return &timelineFormatter{
+		out:    out,
+		rounds: make(map[instanceKey]map[int]*round),
+	}
+}
+
+// instanceKey identifies a single OCR instance, commit and exec of the same DON run separate instances.
+type instanceKey struct {
+	donID  int
+	plugin string
+}
+
+// span is the time range in which an oracle logged within a phase.
+type span struct {
+	first time.Time
+	last  time.Time
+	logs  int
+}
+
+// round holds the spans of every oracle for every phase of a single OCR round.
+type round struct {
+	seqNr int
+	// phases maps phase -> oracleID -> span.
+	phases map[string]map[int]*span
+}
+
+// timelineFormatter holds log timings collected across all oracles.
+type timelineFormatter struct {
+	out    io.Writer
+	rounds map[instanceKey]map[int]*round
+}
+
+func (tf *timelineFormatter) Format(data *parse.Data) {
+	if data.SequenceNumber == 0 || data.OCRPhase == "" {
+		// Not part of an OCR round.
+		return
+	}
+
+	key := instanceKey{donID: data.DONID, plugin: data.Plugin}
+	if tf.rounds[key] == nil {
+		tf.rounds[key] = make(map[int]*round)
+	}
+	r := tf.rounds[key][data.SequenceNumber]
+	if r == nil {
+		r = &round{seqNr: data.SequenceNumber, phases: make(map[string]map[int]*span)}
+		tf.rounds[key][data.SequenceNumber] = r
+	}
+	if r.phases[data.OCRPhase] == nil {
+		r.phases[data.OCRPhase] = make(map[int]*span)
+	}
+
+	ts := data.GetTimestamp()
+	s := r.phases[data.OCRPhase][data.OracleID]
+	if s == nil {
+		r.phases[data.OCRPhase][data.OracleID] = &span{first: ts, last: ts, logs: 1}
+		return
+	}
+	if ts.Before(s.first) {
+		s.first = ts
+	}
+	if ts.After(s.last) {
+		s.last = ts
+	}
+	s.logs++
+}
+
+func (tf *timelineFormatter) Close() error {
+	keys := maps.Keys(tf.rounds)
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].donID != keys[j].donID {
+			return keys[i].donID < keys[j].donID
+		}
+		return keys[i].plugin < keys[j].plugin
+	})
+
+	for _, key := range keys {
+		rounds := tf.rounds[key]
+		oracles := oraclesOf(rounds)
+
+		if _, err := fmt.Fprintf(tf.out, "%s timeline for DON %d (%d oracles)\n",
+			key.plugin, key.donID, len(oracles)); err != nil {
+			return err
+		}
+
+		seqNrs := maps.Keys(rounds)
+		sort.Ints(seqNrs)
+		for _, seqNr := range seqNrs {
+			if _, err := fmt.Fprintln(tf.out, rounds[seqNr].render(oracles)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(tf.out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// oraclesOf returns the sorted IDs of all oracles that logged in any of the rounds.
+func oraclesOf(rounds map[int]*round) []int {
+	seen := make(map[int]struct{})
+	for _, r := range rounds {
+		for _, spans := range r.phases {
+			for oracleID := range spans {
+				seen[oracleID] = struct{}{}
+			}
+		}
+	}
+	oracles := maps.Keys(seen)
+	sort.Ints(oracles)
+	return oracles
+}
+
+// start returns the earliest timestamp logged in the round.
+func (r *round) start() time.Time {
+	var start time.Time
+	for _, spans := range r.phases {
+		for _, s := range spans {
+			if start.IsZero() || s.first.Before(start) {
+				start = s.first
+			}
+		}
+	}
+	return start
+}
+
+// slowest returns the oracle which finished the phase last, or -1 if fewer than two oracles logged in it.
+func (r *round) slowest(phase string) int {
+	spans := r.phases[phase]
+	if len(spans) < 2 {
+		return -1
+	}
+	oracleID := -1
+	var last time.Time
+	for id, s := range spans {
+		if oracleID == -1 || s.last.After(last) || (s.last.Equal(last) && id < oracleID) {
+			oracleID = id
+			last = s.last
+		}
+	}
+	return oracleID
+}
+
+// render prints a table with one row per phase and one column per oracle, each cell holds the
+// time at which the oracle finished the phase relative to the start of the round.
+func (r *round) render(oracles []int) string {
+	start := r.start()
+
+	var b strings.Builder
+	b.WriteString(divider.Render(fmt.Sprintf("%5d: %-40s", r.seqNr, fmt.Sprintf("started %s",
+		start.Format(time.TimeOnly+".000")))))
+
+	b.WriteString("\n")
+	b.WriteString(padding)
+	b.WriteString(header.Render(fmt.Sprintf("%-6s", "phase")))
+	for _, oracleID := range oracles {
+		b.WriteString(header.Render(fmt.Sprintf("%*s", columnWidth, fmt.Sprintf("oracle %d", oracleID))))
+	}
+	b.WriteString(header.Render(fmt.Sprintf("  %s", "lag")))
+
+	for _, phase := range phases {
+		spans, ok := r.phases[phase]
+		if !ok {
+			continue
+		}
+		slow := r.slowest(phase)
+
+		b.WriteString("\n")
+		b.WriteString(padding)
+		b.WriteString(fmt.Sprintf("%-6s", phase))
+
+		var ends []time.Duration
+		for _, oracleID := range oracles {
+			s, ok := spans[oracleID]
+			if !ok {
+				b.WriteString(missing.Render(fmt.Sprintf("%*s", columnWidth, "-")))
+				continue
+			}
+			end := s.last.Sub(start)
+			ends = append(ends, end)
+			cell := fmt.Sprintf("%*s", columnWidth, "+"+formatDuration(end))
+			if oracleID == slow {
+				cell = slowest.Render(cell)
+			}
+			b.WriteString(cell)
+		}
+
+		// lag is how far behind the fastest oracle the slowest one finished.
+		if len(ends) > 1 {
+			b.WriteString(fmt.Sprintf("  %s", formatDuration(slices.Max(ends)-slices.Min(ends))))
+		}
+	}
+
+	return b.String()
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	default:
+		return d.Round(time.Second).String()
+	}
+}
@@ -0,0 +1,57 @@
+package timeline
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func logAt(t *testing.T, oracleID, seqNr int, phase string, offset time.Duration) *parse.Data {
+	base, err := time.Parse(time.RFC3339, "2025-01-20T11:50:22Z")
+	require.NoError(t, err)
+	return &parse.Data{
+		ProdTimestamp:  base.Add(offset).Format(time.RFC3339Nano),
+		Plugin:         "Commit",
+		DONID:          1,
+		OracleID:       oracleID,
+		SequenceNumber: seqNr,
+		OCRPhase:       phase,
+	}
+}
+
+func TestTimelineFormatter(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	var out bytes.Buffer
+	tf := newTimelineFormatter(&out)
+
+	// round 7: oracle 2 is slow to observe.
+	tf.Format(logAt(t, 0, 7, "obs", 0))
+	tf.Format(logAt(t, 0, 7, "obs", 100*time.Millisecond))
+	tf.Format(logAt(t, 1, 7, "obs", 20*time.Millisecond))
+	tf.Format(logAt(t, 2, 7, "obs", 1500*time.Millisecond))
+	tf.Format(logAt(t, 0, 7, "otcm", 2*time.Second))
+	tf.Format(logAt(t, 1, 7, "otcm", 2*time.Second))
+	// logs without round information are ignored.
+	tf.Format(logAt(t, 3, 0, "", 0))
+
+	r := tf.rounds[instanceKey{donID: 1, plugin: "Commit"}][7]
+	require.NotNil(t, r)
+	require.Equal(t, 2, r.slowest("obs"))
+	require.Equal(t, 0, r.slowest("otcm"))
+	require.Equal(t, -1, r.slowest("rprt"))
+	require.Equal(t, 2, r.phases["obs"][0].logs)
+
+	require.NoError(t, tf.Close())
+	require.Contains(t, out.String(), "Commit timeline for DON 1 (3 oracles)\n")
+	require.Contains(t, out.String(), "    7: started 11:50:22.000")
+	require.Contains(t, out.String(), "    phase   oracle 0  oracle 1  oracle 2  lag\n")
+	require.Contains(t, out.String(), "    obs       +100ms     +20ms    +1.50s  1.48s\n")
+	require.Contains(t, out.String(), "    otcm      +2.00s    +2.00s         -  0ms\n")
+}
@@ -0,0 +1,366 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/filter"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+// view is the screen that is displayed.
+type view int
+
+const (
+	viewRounds view = iota
+	viewRound
+)
+
+// inputMode is the prompt that is being edited, if any.
+type inputMode int
+
+const (
+	inputNone inputMode = iota
+	inputFilter
+	inputJump
+)
+
+const (
+	// chrome is the number of lines used by the header and footer.
+	chrome         = 4
+	componentWidth = 28
+)
+
+const roundsHelp = "↑/↓ select • enter open • / filter • : jump to seqNr • q quit"
+const roundHelp = "↑/↓ processor • pgup/pgdn scroll • n/p next/prev round • : jump to seqNr • esc back • q quit"
+
+// model is the bubbletea model of the browser.
+type model struct {
+	logs   []*parse.Data
+	rounds []*round
+
+	filterText string
+	filterExpr filter.Expr
+
+	view   view
+	cursor int
+	offset int
+
+	components    []component
+	componentIdx  int
+	detail        viewport.Model
+	width, height int
+
+	mode   inputMode
+	input  textinput.Model
+	status string
+}
+
+func newModel(logs []*parse.Data) model {
+	input := textinput.New()
+	input.CharLimit = 512
+	m := model{
+		logs:   logs,
+		width:  120,
+		height: 40,
+		input:  input,
+		detail: viewport.New(120-componentWidth, 40-chrome),
+	}
+	m.rounds = groupRounds(logs)
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.detail.Width = max(m.width-componentWidth-1, 10)
+		m.detail.Height = m.listHeight()
+		m.scrollToCursor()
+		return m, nil
+	case tea.KeyMsg:
+		if m.mode != inputNone {
+			return m.updateInput(msg)
+		}
+		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			return m, tea.Quit
+		}
+		m.status = ""
+		if m.view == viewRound {
+			return m.updateRound(msg)
+		}
+		return m.updateRounds(msg)
+	}
+	return m, nil
+}
+
+func (m model) updateRounds(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup", "ctrl+u":
+		m.moveCursor(-m.listHeight())
+	case "pgdown", "ctrl+d", " ":
+		m.moveCursor(m.listHeight())
+	case "home", "g":
+		m.moveCursor(-len(m.rounds))
+	case "end", "G":
+		m.moveCursor(len(m.rounds))
+	case "enter", "right", "l":
+		if len(m.rounds) > 0 {
+			m.openRound()
+		}
+	case "/":
+		return m.startInput(inputFilter, "filter: ", m.filterText)
+	case ":":
+		return m.startInput(inputJump, "seqNr: ", "")
+	}
+	return m, nil
+}
+
+func (m model) updateRound(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace", "left", "h":
+		m.view = viewRounds
+	case "up", "k":
+		m.selectComponent(m.componentIdx - 1)
+	case "down", "j":
+		m.selectComponent(m.componentIdx + 1)
+	case "n":
+		m.moveCursor(1)
+		m.openRound()
+	case "p":
+		m.moveCursor(-1)
+		m.openRound()
+	case ":":
+		return m.startInput(inputJump, "seqNr: ", "")
+	default:
+		// Let the viewport handle scrolling keys.
+		var cmd tea.Cmd
+		m.detail, cmd = m.detail.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m model) startInput(mode inputMode, prompt, value string) (tea.Model, tea.Cmd) {
+	m.mode = mode
+	m.input.Prompt = prompt
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m model) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode = inputNone
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		mode := m.mode
+		m.mode = inputNone
+		m.input.Blur()
+		if mode == inputFilter {
+			m.applyFilter(strings.TrimSpace(m.input.Value()))
+		} else {
+			m.jump(strings.TrimSpace(m.input.Value()))
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// applyFilter regroups the rounds using only the logs which match the expression, an empty
+// expression clears the filter.
+func (m *model) applyFilter(text string) {
+	var expr filter.Expr
+	if text != "" {
+		var err error
+		expr, err = filter.ParseExpr(text)
+		if err != nil {
+			m.status = fmt.Sprintf("invalid filter: %s", err)
+			return
+		}
+	}
+
+	var logs []*parse.Data
+	for _, data := range m.logs {
+		if filter.Match(data, expr) {
+			logs = append(logs, data)
+		}
+	}
+	m.filterText, m.filterExpr = text, expr
+	m.rounds = groupRounds(logs)
+	m.view = viewRounds
+	m.cursor, m.offset = 0, 0
+}
+
+// jump selects the first round with the sequence number, or the next one after it.
+func (m *model) jump(text string) {
+	seqNr, err := strconv.Atoi(text)
+	if err != nil {
+		m.status = fmt.Sprintf("invalid seqNr %q", text)
+		return
+	}
+	best := -1
+	for i, r := range m.rounds {
+		if r.seqNr == seqNr {
+			best = i
+			break
+		}
+		if r.seqNr > seqNr && (best == -1 || r.seqNr < m.rounds[best].seqNr) {
+			best = i
+		}
+	}
+	if best == -1 {
+		m.status = fmt.Sprintf("no round with seqNr %d or later", seqNr)
+		return
+	}
+	if m.rounds[best].seqNr != seqNr {
+		m.status = fmt.Sprintf("no round with seqNr %d, showing %d", seqNr, m.rounds[best].seqNr)
+	}
+	m.cursor = best
+	m.scrollToCursor()
+	if m.view == viewRound {
+		m.openRound()
+	}
+}
+
+func (m *model) moveCursor(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.rounds)-1, 0))
+	m.scrollToCursor()
+}
+
+func (m *model) scrollToCursor() {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+}
+
+func (m *model) openRound() {
+	m.view = viewRound
+	m.components = m.rounds[m.cursor].components()
+	m.selectComponent(0)
+}
+
+func (m *model) selectComponent(idx int) {
+	if len(m.components) == 0 {
+		m.detail.SetContent("")
+		return
+	}
+	m.componentIdx = min(max(idx, 0), len(m.components)-1)
+	m.detail.SetContent(m.components[m.componentIdx].detail())
+	m.detail.GotoTop()
+}
+
+func (m model) listHeight() int {
+	return max(m.height-chrome, 1)
+}
+
+func (m model) View() string {
+	var body string
+	if m.view == viewRound {
+		body = m.roundView()
+	} else {
+		body = m.roundsView()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.footer())
+}
+
+func (m model) header(text string) string {
+	return section.Width(m.width).Render(text)
+}
+
+func (m model) roundsView() string {
+	title := fmt.Sprintf("%d rounds, %d logs", len(m.rounds), len(m.logs))
+	if m.filterText != "" {
+		title += ", filter: " + m.filterText
+	}
+
+	var b strings.Builder
+	b.WriteString(m.header(title))
+	b.WriteString("\n")
+	b.WriteString(columns.Render(fmt.Sprintf("  %-8s %4s %8s  %-12s %8s %7s %5s %4s %4s  %s",
+		"plugin", "don", "seqNr", "start", "duration", "oracles", "logs", "err", "warn", "phases")))
+	b.WriteString("\n")
+
+	if len(m.rounds) == 0 {
+		b.WriteString(faint.Render("  no rounds"))
+		b.WriteString("\n")
+	}
+	end := min(m.offset+m.listHeight(), len(m.rounds))
+	for i := m.offset; i < end; i++ {
+		r := m.rounds[i]
+		line := fmt.Sprintf("  %-8s %4d %8d  %-12s %8s %7d %5d %4d %4d  %s",
+			r.plugin, r.donID, r.seqNr, formatTime(r.start), r.end.Sub(r.start).Round(time.Millisecond),
+			len(r.oracles), len(r.logs), r.errors, r.warns, r.phaseList())
+		switch {
+		case i == m.cursor:
+			line = selected.Render(line)
+		case r.errors > 0:
+			line = problem.Render(line)
+		case r.warns > 0:
+			line = warning.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m model) roundView() string {
+	r := m.rounds[m.cursor]
+	title := fmt.Sprintf("%s DON %d seqNr %d, %s to %s, %d oracles, phases: %s",
+		r.plugin, r.donID, r.seqNr, formatTime(r.start), formatTime(r.end), len(r.oracles), r.phaseList())
+
+	var list strings.Builder
+	for i, c := range m.components {
+		line := fmt.Sprintf("%-*s", componentWidth-1, fmt.Sprintf(" %s (%d)", c.name, len(c.logs)))
+		if i == m.componentIdx {
+			line = selected.Render(line)
+		}
+		list.WriteString(line)
+		list.WriteString("\n")
+	}
+	left := lipgloss.NewStyle().Width(componentWidth).Height(m.listHeight()).Render(list.String())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.header(title),
+		lipgloss.JoinHorizontal(lipgloss.Top, left, m.detail.View()),
+	)
+}
+
+func (m model) footer() string {
+	if m.mode != inputNone {
+		return "\n" + m.input.View()
+	}
+	help := roundsHelp
+	if m.view == viewRound {
+		help = roundHelp
+	}
+	status := ""
+	if m.status != "" {
+		status = highlight.Render(m.status)
+	}
+	return status + "\n" + faint.Render(help)
+}
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/logutil"
+)
+
+// phases in the order they happen within an OCR round.
+var phases = []string{
+	logutil.PhaseQuery,
+	logutil.PhaseObservation,
+	logutil.PhaseOutcome,
+	logutil.PhaseReports,
+	logutil.PhaseShouldAccept,
+	logutil.PhaseShouldTransmit,
+}
+
+// round is a single OCR round of one plugin instance, combined across oracles.
+type round struct {
+	plugin string
+	donID  int
+	seqNr  int
+	logs   []*parse.Data
+
+	start   time.Time
+	end     time.Time
+	oracles map[int]struct{}
+	phases  map[string]struct{}
+	errors  int
+	warns   int
+}
+
+type roundKey struct {
+	plugin string
+	donID  int
+	seqNr  int
+}
+
+// groupRounds groups the logs by round, logs which are not part of a round are skipped.
+// Rounds are ordered by start time.
+func groupRounds(logs []*parse.Data) []*round {
+	byKey := make(map[roundKey]*round)
+	var rounds []*round
+	for _, data := range logs {
+		if data.SequenceNumber == 0 {
+			continue
+		}
+		key := roundKey{plugin: data.Plugin, donID: data.DONID, seqNr: data.SequenceNumber}
+		r, ok := byKey[key]
+		if !ok {
+			r = &round{
+				plugin:  data.Plugin,
+				donID:   data.DONID,
+				seqNr:   data.SequenceNumber,
+				oracles: make(map[int]struct{}),
+				phases:  make(map[string]struct{}),
+			}
+			byKey[key] = r
+			rounds = append(rounds, r)
+		}
+		r.add(data)
+	}
+
+	sort.SliceStable(rounds, func(i, j int) bool {
+		if !rounds[i].start.Equal(rounds[j].start) {
+			return rounds[i].start.Before(rounds[j].start)
+		}
+		return rounds[i].seqNr < rounds[j].seqNr
+	})
+	return rounds
+}
+
+func (r *round) add(data *parse.Data) {
+	ts := timestamp(data)
+	if !ts.IsZero() {
+		if r.start.IsZero() || ts.Before(r.start) {
+			r.start = ts
+		}
+		if ts.After(r.end) {
+			r.end = ts
+		}
+	}
+	r.logs = append(r.logs, data)
+	r.oracles[data.OracleID] = struct{}{}
+	if data.OCRPhase != "" {
+		r.phases[data.OCRPhase] = struct{}{}
+	}
+	switch strings.ToLower(data.GetLevel()) {
+	case "error", "crit", "panic", "fatal":
+		r.errors++
+	case "warn":
+		r.warns++
+	}
+}
+
+// phaseList returns the phases seen in the round in OCR order.
+func (r *round) phaseList() string {
+	var seen []string
+	for _, phase := range phases {
+		if _, ok := r.phases[phase]; ok {
+			seen = append(seen, phase)
+		}
+	}
+	return strings.Join(seen, " ")
+}
+
+// component is a processor (merkle roots, token prices, ...) within a round.
+type component struct {
+	name string
+	logs []*parse.Data
+}
+
+// components groups the round logs by the processor that logged them.
+func (r *round) components() []component {
+	var names []string
+	byName := make(map[string][]*parse.Data)
+	for _, data := range r.logs {
+		name := data.Component
+		if name == "" {
+			name = data.Plugin
+		}
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], data)
+	}
+	sort.Strings(names)
+
+	components := make([]component, 0, len(names))
+	for _, name := range names {
+		components = append(components, component{name: name, logs: byName[name]})
+	}
+	return components
+}
+
+// detail renders the observations and outcomes of a component. Identical values from
+// different oracles are shown once, logs are listed when there are neither.
+func (c component) detail() string {
+	var b strings.Builder
+	found := false
+	for _, field := range []string{"query", "observation", "outcome"} {
+		type value struct {
+			text    string
+			oracles []string
+		}
+		var values []*value
+		byText := make(map[string]*value)
+		for _, data := range c.logs {
+			raw, ok := data.RawLoggerFields[field]
+			if !ok {
+				continue
+			}
+			text := prettyJSON(raw)
+			v, ok := byText[text]
+			if !ok {
+				v = &value{text: text}
+				byText[text] = v
+				values = append(values, v)
+			}
+			oracle := fmt.Sprintf("%d", data.OracleID)
+			if len(v.oracles) == 0 || v.oracles[len(v.oracles)-1] != oracle {
+				v.oracles = append(v.oracles, oracle)
+			}
+		}
+		for _, v := range values {
+			found = true
+			b.WriteString(section.Render(fmt.Sprintf("%s%s (oracles %s)",
+				strings.ToUpper(field[:1]), field[1:], strings.Join(v.oracles, ", "))))
+			b.WriteString("\n")
+			b.WriteString(v.text)
+			b.WriteString("\n\n")
+		}
+	}
+
+	if !found {
+		b.WriteString(faint.Render("no observations or outcomes, logs:"))
+		b.WriteString("\n")
+		for _, data := range c.logs {
+			b.WriteString(logLine(data))
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func logLine(data *parse.Data) string {
+	line := fmt.Sprintf("%s %-5s oracle %d %s %s", formatTime(timestamp(data)), data.GetLevel(),
+		data.OracleID, data.OCRPhase, data.GetMessage())
+	switch strings.ToLower(data.GetLevel()) {
+	case "error", "crit", "panic", "fatal":
+		return problem.Render(line)
+	case "warn":
+		return warning.Render(line)
+	default:
+		return line
+	}
+}
+
+func prettyJSON(v any) string {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
+
+// timestamp returns the log time or the zero time, GetTimestamp panics on logs without one.
+func timestamp(data *parse.Data) time.Time {
+	if data.ProdTimestamp == "" && data.TestTimestamp == "" {
+		return time.Time{}
+	}
+	return data.GetTimestamp()
+}
+
+func formatTime(ts time.Time) string {
+	if ts.IsZero() {
+		return "-"
+	}
+	return ts.UTC().Format(time.TimeOnly + ".000")
+}
@@ -0,0 +1,72 @@
+// Package tui is an interactive terminal browser for OCR rounds. It lists the rounds of every
+// plugin instance and drills down into the observations and outcomes of each processor, with
+// live filter editing and jump-to-seqNr.
+//
+// All logs are read before the browser opens, i.e.
+//
+//	carpenter --format tui --filename node1.log --filename node2.log
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/format"
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func init() {
+	format.Register("tui", tuiFormatterFactory,
+		"Browse OCR rounds interactively, drill down into processor observations and outcomes.")
+
+	section = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#3366FF"))
+	columns = lipgloss.NewStyle().
+		Bold(true)
+	selected = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#75A3A3"))
+	highlight = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF9933"))
+	warning = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF9933"))
+	problem = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF3333"))
+	faint = lipgloss.NewStyle().
+		Faint(true)
+}
+
+var section lipgloss.Style
+var columns lipgloss.Style
+var selected lipgloss.Style
+var highlight lipgloss.Style
+var warning lipgloss.Style
+var problem lipgloss.Style
+var faint lipgloss.Style
+
+func tuiFormatterFactory(options format.Options) format.Formatter {
+	return &tuiFormatter{}
+}
+
+// tuiFormatter collects the logs and opens the browser when closed.
+type tuiFormatter struct {
+	logs []*parse.Data
+}
+
+func (tf *tuiFormatter) Format(data *parse.Data) {
+	tf.logs = append(tf.logs, data)
+}
+
+func (tf *tuiFormatter) Close() error {
+	if len(tf.logs) == 0 {
+		return fmt.Errorf("no logs to browse")
+	}
+	// Logs are usually piped through stdin, so keyboard input is read from the terminal.
+	_, err := tea.NewProgram(newModel(tf.logs), tea.WithAltScreen(), tea.WithInputTTY()).Run()
+	return err
+}
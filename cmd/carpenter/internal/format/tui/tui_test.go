@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/cmd/carpenter/internal/parse"
+)
+
+func logAt(oracleID, seqNr int, level, phase, component, msg string, fields map[string]any) *parse.Data {
+	base := time.Date(2025, 1, 20, 11, 50, 22, 0, time.UTC)
+	if fields == nil {
+		fields = map[string]any{}
+	}
+	return &parse.Data{
+		ProdTimestamp:   base.Add(time.Duration(seqNr) * time.Second).Format(time.RFC3339Nano),
+		ProdLevel:       level,
+		ProdMessage:     msg,
+		Plugin:          "Commit",
+		Component:       component,
+		DONID:           1,
+		OracleID:        oracleID,
+		SequenceNumber:  seqNr,
+		OCRPhase:        phase,
+		RawLoggerFields: fields,
+	}
+}
+
+func testLogs() []*parse.Data {
+	outcome := map[string]any{"outcomeType": float64(1)}
+	return []*parse.Data{
+		logAt(0, 0, "info", "", "", "creating new plugin instance", nil),
+		logAt(0, 5, "info", "obs", "MerkleRoot", "sending merkle root processor observation",
+			map[string]any{"observation": map[string]any{"seqNum": float64(10)}}),
+		logAt(1, 5, "info", "obs", "MerkleRoot", "sending merkle root processor observation",
+			map[string]any{"observation": map[string]any{"seqNum": float64(11)}}),
+		logAt(0, 5, "info", "otcm", "MerkleRoot", "sending outcome", map[string]any{"outcome": outcome}),
+		logAt(1, 5, "info", "otcm", "MerkleRoot", "sending outcome", map[string]any{"outcome": outcome}),
+		logAt(0, 5, "info", "obs", "ChainFee", "fee components", nil),
+		logAt(0, 6, "error", "obs", "MerkleRoot", "call to MsgsBetweenSeqNums failed", nil),
+		logAt(0, 9, "info", "obs", "MerkleRoot", "observing", nil),
+	}
+}
+
+func press(m tea.Model, keys ...string) model {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m.(model)
+}
+
+func TestModel(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	var m tea.Model = newModel(testLogs())
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+
+	view := m.View()
+	require.Contains(t, view, "3 rounds, 8 logs")
+	require.Contains(t, view, "Commit      1        5  11:50:27.000")
+	require.Contains(t, view, "obs otcm")
+
+	// Drill down into the first round, processors are sorted by name.
+	mm := press(m, "enter")
+	require.Equal(t, viewRound, mm.view)
+	view = mm.View()
+	require.Contains(t, view, "Commit DON 1 seqNr 5")
+	require.Contains(t, view, "ChainFee (1)")
+	require.Contains(t, view, "fee components")
+
+	// Identical outcomes are shown once for all oracles.
+	mm = press(mm, "down")
+	detail := mm.components[mm.componentIdx].detail()
+	require.Contains(t, detail, "Observation (oracles 0)")
+	require.Contains(t, detail, "Observation (oracles 1)")
+	require.Contains(t, detail, "Outcome (oracles 0, 1)")
+
+	mm = press(mm, "n")
+	require.Contains(t, mm.View(), "seqNr 6")
+	mm = press(mm, "esc")
+	require.Equal(t, viewRounds, mm.view)
+	require.Equal(t, 1, mm.cursor)
+
+	// Jump to a seqNr, missing ones select the next round.
+	mm = press(mm, ":", "9", "enter")
+	require.Equal(t, 2, mm.cursor)
+	mm = press(mm, ":", "7", "enter")
+	require.Equal(t, 2, mm.cursor)
+	require.Contains(t, mm.View(), "no round with seqNr 7, showing 9")
+	mm = press(mm, ":", "1", "0", "enter")
+	require.Contains(t, mm.View(), "no round with seqNr 10 or later")
+
+	// Live filter editing.
+	mm = press(mm, "/")
+	for _, r := range "LogLevel==error" {
+		mm = press(mm, string(r))
+	}
+	mm = press(mm, "enter")
+	require.Len(t, mm.rounds, 1)
+	require.Equal(t, 6, mm.rounds[0].seqNr)
+	require.Contains(t, mm.View(), "filter: LogLevel==error")
+
+	mm = press(mm, "/", "(", "enter")
+	require.Contains(t, mm.View(), "invalid filter")
+	require.Len(t, mm.rounds, 1)
+
+	// Clearing the filter shows all rounds again.
+	mm = press(mm, "/")
+	for range "LogLevel==error" {
+		mm = press(mm, "backspace")
+	}
+	mm = press(mm, "enter")
+	require.Len(t, mm.rounds, 3)
+}
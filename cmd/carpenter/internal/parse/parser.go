@@ -123,6 +123,18 @@ func sanitizeString(s string, logType LogType) string {
 	}
 }
 
+// decodeRawFields decodes JSON logger fields, numbers are decoded as json.Number so that
+// large integers such as chain selectors are kept exact.
+func decodeRawFields(fields string) (map[string]any, error) {
+	rawFields := make(map[string]any)
+	dec := json.NewDecoder(strings.NewReader(fields))
+	dec.UseNumber()
+	if err := dec.Decode(&rawFields); err != nil {
+		return nil, err
+	}
+	return rawFields, nil
+}
+
 func ParseLine(line string, logType LogType) (*Data, error) {
 	line = sanitizeString(line, logType)
 	if len(line) == 0 {
@@ -134,6 +146,8 @@ func ParseLine(line string, logType LogType) (*Data, error) {
 	case LogTypeJSON:
 		var obj map[string]interface{}
 		dec := json.NewDecoder(strings.NewReader(line))
+		// Keep numbers exact, chain selectors don't fit in a float64.
+		dec.UseNumber()
 		err := dec.Decode(&obj)
 		if err != nil {
 			return nil, fmt.Errorf("could not decode line from JSON (%s): %w", line, err)
@@ -194,8 +208,8 @@ func ParseLine(line string, logType LogType) (*Data, error) {
 
 		// parse the json fields into a map[string]any so that we can have the raw
 		// fields in the data struct.
-		var rawFields = make(map[string]any)
-		if err := json.Unmarshal([]byte(obj["jsonFields"]), &rawFields); err != nil {
+		rawFields, err := decodeRawFields(obj["jsonFields"])
+		if err != nil {
 			return nil, fmt.Errorf("could not parse json fields: %w, fields: %s", err, obj["jsonFields"])
 		}
 
@@ -256,8 +270,8 @@ func ParseLine(line string, logType LogType) (*Data, error) {
 		// if the json parse succeeds, we can still have the raw fields in the data struct.
 		// parse the json fields into a map[string]any so that we can have the raw
 		// fields in the data struct.
-		var rawFields = make(map[string]any)
-		if err := json.Unmarshal([]byte(namedMatches["jsonFields"]), &rawFields); err != nil {
+		rawFields, err := decodeRawFields(namedMatches["jsonFields"])
+		if err != nil {
 			return nil, fmt.Errorf("could not parse json fields: %w, fields: %s", err, namedMatches["jsonFields"])
 		}
 
@@ -323,15 +337,21 @@ func (data Data) GetTimestamp() time.Time {
 		var err2 error
 		parsedTs, err2 = time.Parse(time.TimeOnly, str)
 		if err2 != nil {
-			panic("could not parse timestamp: " + err1.Error())
-			return time.Time{}
+			// The mixed parsers store the timestamp using time.Time.String().
+			var err3 error
+			parsedTs, err3 = time.Parse(timeStringLayout, str)
+			if err3 != nil {
+				panic("could not parse timestamp: " + err1.Error())
+			}
 		}
 	}
 
 	return parsedTs
-	return parsedTs
 }
 
+// timeStringLayout is the layout produced by time.Time.String().
+const timeStringLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
 func (data Data) GetLevel() string {
 	if data.ProdLevel != "" {
 		return data.ProdLevel
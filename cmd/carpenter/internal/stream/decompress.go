@@ -0,0 +1,108 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// compression detects the compression format from the magic bytes at the start of the data,
+// the file extension is not trusted since CI artifacts are often renamed.
+func compression(r *bufio.Reader) string {
+	// Peek returns fewer bytes along with an error for short inputs, which is fine here.
+	magic, _ := r.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return "gzip"
+	case bytes.HasPrefix(magic, zstdMagic):
+		return "zstd"
+	default:
+		return ""
+	}
+}
+
+// isCompressed checks whether the file is gzip or zstd compressed.
+func isCompressed(filename string) (bool, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	return compression(bufio.NewReader(f)) != "", nil
+}
+
+// openInput opens a file, compressed files are transparently decompressed.
+func openInput(filename string) (io.ReadCloser, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", filename, err)
+	}
+	return decompress(filename, f), nil
+}
+
+// decompress wraps the reader with a decompressor if the data is compressed. The format is
+// detected on the first read so that waiting for stdin doesn't block initialization.
+// Closing the result closes the underlying reader as well.
+func decompress(name string, rc io.ReadCloser) *decompressReader {
+	return &decompressReader{name: name, source: rc}
+}
+
+type decompressReader struct {
+	name   string
+	source io.ReadCloser
+	reader io.Reader
+	// closeDecoder releases the decompressor, if any.
+	closeDecoder func() error
+}
+
+func (d *decompressReader) init() error {
+	buffered := bufio.NewReader(d.source)
+	switch compression(buffered) {
+	case "gzip":
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+		d.reader, d.closeDecoder = gz, gz.Close
+	case "zstd":
+		zr, err := zstd.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+		d.reader, d.closeDecoder = zr, func() error {
+			zr.Close()
+			return nil
+		}
+	default:
+		d.reader = buffered
+	}
+	return nil
+}
+
+func (d *decompressReader) Read(p []byte) (int, error) {
+	if d.reader == nil {
+		if err := d.init(); err != nil {
+			return 0, fmt.Errorf("error decompressing %s: %w", d.name, err)
+		}
+	}
+	return d.reader.Read(p)
+}
+
+func (d *decompressReader) Close() error {
+	var err error
+	if d.closeDecoder != nil {
+		err = d.closeDecoder()
+	}
+	return errors.Join(err, d.source.Close())
+}
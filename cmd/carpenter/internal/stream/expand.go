@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// expandFilenames resolves glob patterns and directories into the files they contain.
+// Directories are walked recursively, hidden files and directories are skipped. Other
+// names are kept as they are so that opening them reports the error.
+func expandFilenames(names []string) ([]string, error) {
+	var filenames []string
+	seen := make(map[string]bool)
+	add := func(filename string) {
+		if !seen[filename] {
+			seen[filename] = true
+			filenames = append(filenames, filename)
+		}
+	}
+
+	for _, name := range names {
+		matches := []string{name}
+		if strings.ContainsAny(name, "*?[") {
+			var err error
+			matches, err = filepath.Glob(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %w", name, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %s", name)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil || !info.IsDir() {
+				add(match)
+				continue
+			}
+			files, err := walkDir(match)
+			if err != nil {
+				return nil, err
+			}
+			if len(files) == 0 {
+				return nil, fmt.Errorf("no files found in %s", match)
+			}
+			for _, f := range files {
+				add(f)
+			}
+		}
+	}
+	return filenames, nil
+}
+
+func walkDir(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", path, err)
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
@@ -0,0 +1,250 @@
+package stream
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultFollowPollInterval is how often followed files are checked for new data,
+// truncation and rotation when no interval is configured.
+const DefaultFollowPollInterval = 250 * time.Millisecond
+
+// followStream multiplexes complete lines from several followed files into a single reader.
+// Followers are stopped when the stream is closed.
+type followStream struct {
+	reader *io.PipeReader
+	writer *io.PipeWriter
+
+	// writeMu makes sure lines from different files are never interleaved.
+	writeMu sync.Mutex
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newFollowStream(opt InputOptions) (*followStream, error) {
+	interval := opt.FollowPollInterval
+	if interval <= 0 {
+		interval = DefaultFollowPollInterval
+	}
+
+	// Fail early if any of the files is missing, a typo in a filename would
+	// otherwise block forever.
+	for _, filename := range opt.Filenames {
+		if _, err := os.Stat(filename); err != nil {
+			return nil, fmt.Errorf("error opening %s: %w", filename, err)
+		}
+	}
+
+	pr, pw := io.Pipe()
+	fs := &followStream{
+		reader: pr,
+		writer: pw,
+		done:   make(chan struct{}),
+	}
+
+	for _, filename := range opt.Filenames {
+		f := &follower{
+			filename:     filename,
+			interval:     interval,
+			skipExisting: opt.FollowSkipExisting,
+			emit:         fs.writeLine,
+			done:         fs.done,
+		}
+		fs.wg.Add(1)
+		go func() {
+			defer fs.wg.Done()
+			if err := f.run(); err != nil {
+				_ = fs.writer.CloseWithError(err)
+			}
+		}()
+	}
+
+	return fs, nil
+}
+
+func (fs *followStream) writeLine(line []byte) error {
+	fs.writeMu.Lock()
+	defer fs.writeMu.Unlock()
+	_, err := fs.writer.Write(line)
+	return err
+}
+
+func (fs *followStream) Read(p []byte) (int, error) {
+	return fs.reader.Read(p)
+}
+
+// Close stops all followers and unblocks any pending reads.
+func (fs *followStream) Close() error {
+	fs.closeOnce.Do(func() {
+		close(fs.done)
+		// Closing the reader unblocks followers waiting on a write.
+		_ = fs.reader.Close()
+		fs.wg.Wait()
+		_ = fs.writer.Close()
+	})
+	return nil
+}
+
+// follower tails a single file similar to 'tail -F'. It keeps following the
+// filename rather than the file handle, so it survives log rotation (the file
+// is replaced) and truncation (the file shrinks).
+type follower struct {
+	filename     string
+	interval     time.Duration
+	skipExisting bool
+	emit         func(line []byte) error
+	done         <-chan struct{}
+
+	file    *os.File
+	info    os.FileInfo
+	reader  *bufio.Reader
+	offset  int64
+	partial []byte
+}
+
+func (f *follower) run() error {
+	defer f.closeFile()
+
+	if err := f.open(f.skipExisting); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	for {
+		if f.file != nil {
+			if err := f.drain(); err != nil {
+				return err
+			}
+			if err := f.checkRotation(); err != nil {
+				return err
+			}
+		} else if err := f.open(false); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		select {
+		case <-f.done:
+			return nil
+		case <-time.After(f.interval):
+		}
+	}
+}
+
+// open opens the file by name, optionally seeking to the end of it.
+func (f *follower) open(seekEnd bool) error {
+	file, err := os.Open(f.filename)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error reading %s: %w", f.filename, err)
+	}
+
+	var offset int64
+	if seekEnd {
+		offset, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("error seeking %s: %w", f.filename, err)
+		}
+	}
+
+	f.file = file
+	f.info = info
+	f.offset = offset
+	f.reader = bufio.NewReader(file)
+	f.partial = nil
+	return nil
+}
+
+func (f *follower) closeFile() {
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+	f.file = nil
+	f.reader = nil
+}
+
+// drain emits every complete line currently available. An incomplete trailing
+// line is kept until the rest of it is written.
+func (f *follower) drain() error {
+	for {
+		chunk, err := f.reader.ReadBytes('\n')
+		f.offset += int64(len(chunk))
+		if len(chunk) > 0 {
+			f.partial = append(f.partial, chunk...)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("error reading %s: %w", f.filename, err)
+		}
+
+		line := f.partial
+		f.partial = nil
+		if err := f.emit(line); err != nil {
+			if errors.Is(err, io.ErrClosedPipe) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// checkRotation detects whether the followed file was truncated or replaced.
+func (f *follower) checkRotation() error {
+	info, err := os.Stat(f.filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// The file was moved away and not yet recreated, keep
+			// reading the old handle until a new file shows up.
+			return nil
+		}
+		return fmt.Errorf("error reading %s: %w", f.filename, err)
+	}
+
+	if !os.SameFile(info, f.info) {
+		// Rotated: flush what is left in the old file and start the new one from the beginning.
+		if err := f.drain(); err != nil {
+			return err
+		}
+		f.flushPartial()
+		f.closeFile()
+		if err := f.open(false); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	if info.Size() < f.offset {
+		// Truncated: start over from the beginning of the file.
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("error seeking %s: %w", f.filename, err)
+		}
+		f.offset = 0
+		f.partial = nil
+		f.reader.Reset(f.file)
+	}
+	f.info = info
+
+	return nil
+}
+
+// flushPartial emits an unterminated last line, used when a file will no longer be written to.
+func (f *follower) flushPartial() {
+	if len(f.partial) == 0 {
+		return
+	}
+	line := append(f.partial, '\n')
+	f.partial = nil
+	_ = f.emit(line)
+}
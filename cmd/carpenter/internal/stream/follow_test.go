@@ -0,0 +1,120 @@
+package stream
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func appendToFile(t *testing.T, filename, data string) {
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func readLines(t *testing.T, lines <-chan string, n int) []string {
+	var got []string
+	for i := 0; i < n; i++ {
+		select {
+		case line := <-lines:
+			got = append(got, line)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for line %d, got %v", i, got)
+		}
+	}
+	return got
+}
+
+func TestInitializeInputStream_Follow(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "node.log")
+	appendToFile(t, filename, "one\n")
+
+	input, err := InitializeInputStream(InputOptions{
+		Filenames:          []string{filename},
+		Follow:             true,
+		FollowPollInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	lines := make(chan string)
+	scanDone := make(chan struct{})
+	go func() {
+		defer close(scanDone)
+		scanner := bufio.NewScanner(input)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	require.Equal(t, []string{"one"}, readLines(t, lines, 1))
+
+	// partial lines are held back until they are complete.
+	appendToFile(t, filename, "tw")
+	appendToFile(t, filename, "o\nthree\n")
+	require.Equal(t, []string{"two", "three"}, readLines(t, lines, 2))
+
+	// truncation
+	require.NoError(t, os.Truncate(filename, 0))
+	time.Sleep(50 * time.Millisecond)
+	appendToFile(t, filename, "four\n")
+	require.Equal(t, []string{"four"}, readLines(t, lines, 1))
+
+	// rotation
+	require.NoError(t, os.Rename(filename, filename+".1"))
+	appendToFile(t, filename+".1", "five\n")
+	appendToFile(t, filename, "six\n")
+	require.Equal(t, []string{"five", "six"}, readLines(t, lines, 2))
+
+	require.NoError(t, input.Close())
+	select {
+	case <-scanDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reader did not stop after Close")
+	}
+}
+
+func TestInitializeInputStream_FollowMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.log")
+	b := filepath.Join(dir, "b.log")
+	appendToFile(t, a, "old a\n")
+	appendToFile(t, b, "old b\n")
+
+	input, err := InitializeInputStream(InputOptions{
+		Filenames:          []string{a, b},
+		Follow:             true,
+		FollowSkipExisting: true,
+		FollowPollInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer input.Close()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(input)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	// give the followers a moment to seek to the end of the files.
+	time.Sleep(50 * time.Millisecond)
+	appendToFile(t, a, "new a\n")
+	appendToFile(t, b, "new b\n")
+	require.ElementsMatch(t, []string{"new a", "new b"}, readLines(t, lines, 2))
+}
+
+func TestInitializeInputStream_FollowMissingFile(t *testing.T) {
+	_, err := InitializeInputStream(InputOptions{
+		Filenames: []string{filepath.Join(t.TempDir(), "missing.log")},
+		Follow:    true,
+	})
+	require.Error(t, err)
+}
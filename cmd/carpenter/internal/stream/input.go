@@ -1,49 +1,70 @@
 package stream
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 )
 
 type InputOptions struct {
+	// Filenames are files, directories or glob patterns to read. Directories are read
+	// recursively and gzip or zstd compressed files are decompressed. Lines from multiple
+	// files are interleaved by their timestamps.
 	Filenames []string
+
+	// Follow keeps reading the files as they grow, similar to 'tail -F'.
+	// Rotated and truncated files are picked up again from the beginning.
+	// It requires Filenames, stdin cannot be followed.
+	Follow bool
+	// FollowSkipExisting starts following at the end of each file instead of
+	// processing the data that is already there.
+	FollowSkipExisting bool
+	// FollowPollInterval controls how often files are checked for changes,
+	// DefaultFollowPollInterval is used when not set.
+	FollowPollInterval time.Duration
 }
 
 func InitializeInputStream(opt InputOptions) (io.ReadCloser, error) {
 	if len(opt.Filenames) == 0 {
-		return os.Stdin, nil
-	}
-	if len(opt.Filenames) > 1 {
-		return nil, fmt.Errorf("multiple input files are not yet supported")
+		if opt.Follow {
+			// stdin is read until it is closed, it cannot be followed.
+			return nil, errors.New("--follow requires --filename")
+		}
+		return decompress("stdin", os.Stdin), nil
 	}
 
-	filename := opt.Filenames[0]
-	f, err := os.Open(filename)
+	filenames, err := expandFilenames(opt.Filenames)
 	if err != nil {
-		return nil, fmt.Errorf("error opening %s: %w", filename, err)
+		return nil, err
 	}
+	opt.Filenames = filenames
 
-	return f, nil
-
-	// TODO: tail the file.
-	/*
-		if *cat {
-			inputStream = f
-		} else {
-			// Close the handle - we just wanted to verify it was valid
-			f.Close()
-
-			cmd := exec.Command("tail", "-n", "-1000", "-F", *filename)
-			var err error
-			inputStream, err = cmd.StdoutPipe()
-			if err != nil {
-				errorf("cannot collect tail -F output of file: %v", err)
+	if opt.Follow {
+		for _, filename := range filenames {
+			// Compressed files don't grow, appending to them isn't something we can follow.
+			if compressed, err := isCompressed(filename); err == nil && compressed {
+				return nil, fmt.Errorf("cannot follow compressed file %s", filename)
 			}
-			err = cmd.Start()
-			if err != nil {
-				errorf("cannot collect tail -F output of file: %v", err)
+		}
+		return newFollowStream(opt)
+	}
+
+	var inputs []io.ReadCloser
+	for _, filename := range filenames {
+		input, err := openInput(filename)
+		if err != nil {
+			var errs []error
+			for _, opened := range inputs {
+				errs = append(errs, opened.Close())
 			}
+			return nil, errors.Join(append([]error{err}, errs...)...)
 		}
-	*/
+		inputs = append(inputs, input)
+	}
+	if len(inputs) == 1 {
+		return inputs[0], nil
+	}
+	return newInterleavedReader(filenames, inputs), nil
 }
@@ -0,0 +1,112 @@
+package stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGzip(t *testing.T, filename, data string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(filename, buf.Bytes(), 0o600))
+}
+
+func writeZstd(t *testing.T, filename, data string) {
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = zw.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, os.WriteFile(filename, buf.Bytes(), 0o600))
+}
+
+func readAll(t *testing.T, opt InputOptions) string {
+	input, err := InitializeInputStream(opt)
+	require.NoError(t, err)
+	defer input.Close()
+	data, err := io.ReadAll(input)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestInitializeInputStream_Compressed(t *testing.T) {
+	dir := t.TempDir()
+	// The extension doesn't matter, the format is detected from the content.
+	gzFile := filepath.Join(dir, "node.log.gz")
+	zstFile := filepath.Join(dir, "node.log.artifact")
+	writeGzip(t, gzFile, "gzip line\n")
+	writeZstd(t, zstFile, "zstd line\n")
+
+	require.Equal(t, "gzip line\n", readAll(t, InputOptions{Filenames: []string{gzFile}}))
+	require.Equal(t, "zstd line\n", readAll(t, InputOptions{Filenames: []string{zstFile}}))
+
+	_, err := InitializeInputStream(InputOptions{Filenames: []string{gzFile}, Follow: true})
+	require.ErrorContains(t, err, "cannot follow compressed file")
+}
+
+func TestInitializeInputStream_Interleave(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "node1"), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "node2"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node1", "node.log"), []byte(
+		`{"ts":"2025-01-20T11:50:22.100Z","msg":"a1"}`+"\n"+
+			"continuation of a1\n"+
+			`{"ts":"2025-01-20T11:50:22.300Z","msg":"a2"}`), 0o600))
+	writeGzip(t, filepath.Join(dir, "node2", "node.log.gz"),
+		`{"ts":"2025-01-20T13:50:22.200+02:00","msg":"b1"}`+"\n"+
+			`{"ts":"2025-01-20T11:50:22.300Z","msg":"b2"}`+"\n")
+	// Hidden files are skipped when reading directories.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("hidden\n"), 0o600))
+
+	expected := `{"ts":"2025-01-20T11:50:22.100Z","msg":"a1"}` + "\n" +
+		"continuation of a1\n" +
+		`{"ts":"2025-01-20T13:50:22.200+02:00","msg":"b1"}` + "\n" +
+		`{"ts":"2025-01-20T11:50:22.300Z","msg":"a2"}` + "\n" +
+		`{"ts":"2025-01-20T11:50:22.300Z","msg":"b2"}` + "\n"
+
+	require.Equal(t, expected, readAll(t, InputOptions{Filenames: []string{dir}}))
+	require.Equal(t, expected, readAll(t, InputOptions{Filenames: []string{filepath.Join(dir, "node*", "node.log*")}}))
+}
+
+func TestInitializeInputStream_FollowStdin(t *testing.T) {
+	_, err := InitializeInputStream(InputOptions{Follow: true})
+	require.ErrorContains(t, err, "--follow requires --filename")
+}
+
+func TestExpandFilenames(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.log")
+	b := filepath.Join(dir, "sub", "b.log")
+	require.NoError(t, os.MkdirAll(filepath.Dir(b), 0o700))
+	require.NoError(t, os.WriteFile(a, nil, 0o600))
+	require.NoError(t, os.WriteFile(b, nil, 0o600))
+
+	filenames, err := expandFilenames([]string{filepath.Join(dir, "*.log"), dir})
+	require.NoError(t, err)
+	require.Equal(t, []string{a, b}, filenames)
+
+	// Missing files are kept so that opening them reports the error.
+	missing := filepath.Join(dir, "missing.log")
+	filenames, err = expandFilenames([]string{missing})
+	require.NoError(t, err)
+	require.Equal(t, []string{missing}, filenames)
+
+	_, err = expandFilenames([]string{filepath.Join(dir, "*.gz")})
+	require.ErrorContains(t, err, "no files match")
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.MkdirAll(empty, 0o700))
+	_, err = expandFilenames([]string{empty})
+	require.ErrorContains(t, err, "no files found")
+}
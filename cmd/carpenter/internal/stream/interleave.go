@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+)
+
+// timestampRegex finds the first timestamp in a log line, regardless of the log format.
+var timestampRegex = regexp.MustCompile(
+	`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?`)
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// lineTimestamp returns the first timestamp in the line, timestamps without a zone are UTC.
+func lineTimestamp(line []byte) (time.Time, bool) {
+	match := timestampRegex.Find(line)
+	if match == nil {
+		return time.Time{}, false
+	}
+	for _, layout := range timestampLayouts {
+		if ts, err := time.Parse(layout, string(match)); err == nil {
+			return ts, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// source is an input with its next line buffered.
+type source struct {
+	index  int
+	name   string
+	reader *bufio.Reader
+	closer io.Closer
+	line   []byte
+	ts     time.Time
+}
+
+// next buffers the next line. Lines without a timestamp keep the timestamp of the previous
+// line, so multi-line messages stay together.
+func (s *source) next() (bool, error) {
+	line, err := s.reader.ReadBytes('\n')
+	if len(line) == 0 {
+		if err == nil || errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading %s: %w", s.name, err)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("error reading %s: %w", s.name, err)
+	}
+	if line[len(line)-1] != '\n' {
+		line = append(line, '\n')
+	}
+	s.line = line
+	if ts, ok := lineTimestamp(line); ok {
+		s.ts = ts
+	}
+	return true, nil
+}
+
+// sourceHeap orders sources by the timestamp of their next line, ties are broken by the
+// order the inputs were given in.
+type sourceHeap []*source
+
+func (h sourceHeap) Len() int { return len(h) }
+func (h sourceHeap) Less(i, j int) bool {
+	if !h[i].ts.Equal(h[j].ts) {
+		return h[i].ts.Before(h[j].ts)
+	}
+	return h[i].index < h[j].index
+}
+func (h sourceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *sourceHeap) Push(x any)   { *h = append(*h, x.(*source)) }
+func (h *sourceHeap) Pop() any {
+	old := *h
+	s := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return s
+}
+
+// interleavedReader merges the lines of several inputs ordered by their timestamps. Each
+// input is expected to be ordered already, as log files are.
+type interleavedReader struct {
+	sources []*source
+	heap    sourceHeap
+	pending []byte
+	started bool
+}
+
+func newInterleavedReader(names []string, inputs []io.ReadCloser) *interleavedReader {
+	ir := &interleavedReader{}
+	for i, input := range inputs {
+		ir.sources = append(ir.sources, &source{
+			index:  i,
+			name:   names[i],
+			reader: bufio.NewReader(input),
+			closer: input,
+		})
+	}
+	return ir
+}
+
+// start buffers the first line of every input, this is delayed until the first read so
+// that stdin-like inputs don't block initialization.
+func (ir *interleavedReader) start() error {
+	ir.started = true
+	for _, s := range ir.sources {
+		ok, err := s.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			ir.heap = append(ir.heap, s)
+		}
+	}
+	heap.Init(&ir.heap)
+	return nil
+}
+
+func (ir *interleavedReader) Read(p []byte) (int, error) {
+	if !ir.started {
+		if err := ir.start(); err != nil {
+			return 0, err
+		}
+	}
+
+	for len(ir.pending) == 0 {
+		if ir.heap.Len() == 0 {
+			return 0, io.EOF
+		}
+		s := ir.heap[0]
+		ir.pending = s.line
+		ok, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			heap.Fix(&ir.heap, 0)
+		} else {
+			heap.Pop(&ir.heap)
+		}
+	}
+
+	n := copy(p, ir.pending)
+	ir.pending = ir.pending[n:]
+	return n, nil
+}
+
+func (ir *interleavedReader) Close() error {
+	var errs []error
+	for _, s := range ir.sources {
+		errs = append(errs, s.closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,216 @@
+// rmnsim runs the commit plugin RMN controller against a network of simulated RMN nodes and
+// prints the signed lane updates, it is meant for trying out RMN node behaviors locally.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmncrypto"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmnsim"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+type options struct {
+	nodes  int
+	f      int
+	fSign  uint64
+	rounds int
+	dest   string
+
+	latency        time.Duration
+	jitter         time.Duration
+	dropRate       float64
+	equivocate     int
+	wrongObsSig    int
+	wrongReportSig int
+
+	initialRequestTimer time.Duration
+	timeout             time.Duration
+	seed                int64
+	verbose             bool
+}
+
+func main() {
+	var opts options
+	flag.IntVar(&opts.nodes, "nodes", 4, "Number of simulated RMN nodes.")
+	flag.IntVar(&opts.f, "f", 1, "RMNHome F of the source chains.")
+	flag.Uint64Var(&opts.fSign, "f-sign", 1, "RMNRemote F.")
+	flag.IntVar(&opts.rounds, "rounds", 1, "Number of times report signatures are computed.")
+	flag.StringVar(&opts.dest, "dest", chainsel.FamilyEVM, "Chain family of the destination chain (evm).")
+	flag.DurationVar(&opts.latency, "latency", 0, "Response latency of every node.")
+	flag.DurationVar(&opts.jitter, "jitter", 0, "Random additional latency of every node.")
+	flag.Float64Var(&opts.dropRate, "drop", 0, "Probability of a node dropping a request.")
+	flag.IntVar(&opts.equivocate, "equivocate", 0, "Number of nodes observing and signing random roots.")
+	flag.IntVar(&opts.wrongObsSig, "wrong-obs-sig", 0, "Number of nodes signing observations with an unknown key.")
+	flag.IntVar(&opts.wrongReportSig, "wrong-report-sig", 0, "Number of nodes signing reports with an unknown key.")
+	flag.DurationVar(&opts.initialRequestTimer, "initial-request-timer", 100*time.Millisecond,
+		"Time the controller waits before requesting the remaining nodes.")
+	flag.DurationVar(&opts.timeout, "timeout", 5*time.Second, "Timeout of each round.")
+	flag.Int64Var(&opts.seed, "seed", 0, "Seed of the simulated latency and drops, time based when 0.")
+	flag.BoolVar(&opts.verbose, "v", false, "Print the controller and node logs.")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// behaviors assigns the misbehaviors to the first nodes, a node can have several of them.
+func behaviors(opts options) []rmnsim.NodeConfig {
+	sourceChains := []cciptypes.ChainSelector{
+		cciptypes.ChainSelector(chainsel.TEST_90000001.Selector),
+		cciptypes.ChainSelector(chainsel.TEST_90000002.Selector),
+	}
+
+	nodes := make([]rmnsim.NodeConfig, opts.nodes)
+	for i := range nodes {
+		nodes[i] = rmnsim.NodeConfig{
+			SupportedSourceChains: sourceChains,
+			Behavior: rmnsim.Behavior{
+				Latency:                   opts.latency,
+				Jitter:                    opts.jitter,
+				DropRate:                  opts.dropRate,
+				Equivocate:                i < opts.equivocate,
+				WrongObservationSignature: i < opts.wrongObsSig,
+				WrongReportSignature:      i < opts.wrongReportSig,
+			},
+		}
+	}
+	return nodes
+}
+
+// destination returns the destination lane, the RMNRemote address and the report version of the chain family.
+func destination(family string) (*rmnpb.LaneDest, cciptypes.UnknownAddress, string, error) {
+	switch family {
+	case chainsel.FamilyEVM:
+		return &rmnpb.LaneDest{
+				DestChainSelector: chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector,
+				OfframpAddress:    common.HexToAddress("0x0000000000000000000000000000000000000fff").Bytes(),
+			},
+			common.HexToAddress("0x0000000000000000000000000000000000000aaa").Bytes(),
+			"RMN_V1_6_ANY2EVM_REPORT", nil
+	default:
+		return nil, nil, "", fmt.Errorf("unsupported destination chain family %q", family)
+	}
+}
+
+func run(opts options) error {
+	lggr := logger.Nop()
+	if opts.verbose {
+		var err error
+		lggr, err = logger.NewWith(func(cfg *zap.Config) {
+			cfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+		})
+		if err != nil {
+			return fmt.Errorf("create logger: %w", err)
+		}
+	}
+
+	nodeConfigs := behaviors(opts)
+	fObserve := make(map[cciptypes.ChainSelector]int)
+	for _, chain := range nodeConfigs[0].SupportedSourceChains {
+		fObserve[chain] = opts.f
+	}
+
+	destChain, rmnRemoteAddress, reportVersion, err := destination(opts.dest)
+	if err != nil {
+		return err
+	}
+	backend, err := rmncrypto.GetForChain(cciptypes.ChainSelector(destChain.DestChainSelector))
+	if err != nil {
+		return err
+	}
+
+	network, err := rmnsim.NewNetwork(lggr, rmnsim.Config{
+		ConfigDigest:        cciptypes.Bytes32(crypto.Keccak256([]byte("rmnsim"))),
+		ReportVersionDigest: cciptypes.Bytes32(crypto.Keccak256([]byte(reportVersion))),
+		FObserve:            fObserve,
+		ReportSigner:        backend,
+		Seed:                opts.seed,
+	}, nodeConfigs)
+	if err != nil {
+		return fmt.Errorf("create network: %w", err)
+	}
+	remoteCfg := network.RemoteConfig(rmnRemoteAddress, opts.fSign)
+
+	controller := rmn.NewController(
+		lggr,
+		backend,
+		rmnsim.DefaultSignObservationPrefix,
+		network.NewPeerClient(),
+		network.Home(),
+		opts.initialRequestTimer,
+		opts.initialRequestTimer,
+		rmn.NoopMetrics{},
+	)
+	err = controller.InitConnection(context.Background(), cciptypes.Bytes32{}, remoteCfg.ConfigDigest, nil, nil)
+	if err != nil {
+		return fmt.Errorf("init connection: %w", err)
+	}
+	defer controller.Close()
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	var failed int
+	for round := 0; round < opts.rounds; round++ {
+		var updateRequests []*rmnpb.FixedDestLaneUpdateRequest
+		for i, chain := range nodeConfigs[0].SupportedSourceChains {
+			minSeqNr := uint64(round*10 + 1)
+			updateRequests = append(updateRequests, &rmnpb.FixedDestLaneUpdateRequest{
+				LaneSource: &rmnpb.LaneSource{
+					SourceChainSelector: uint64(chain),
+					OnrampAddress:       common.LeftPadBytes([]byte{byte(i + 1)}, 32),
+				},
+				ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: minSeqNr, MaxMsgNr: minSeqNr + 9},
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+		start := time.Now()
+		sigs, err := controller.ComputeReportSignatures(ctx, destChain, updateRequests, remoteCfg)
+		cancel()
+
+		result := map[string]any{
+			"round":    round,
+			"duration": time.Since(start).String(),
+		}
+		if err != nil {
+			failed++
+			result["error"] = err.Error()
+		} else {
+			result["laneUpdates"] = sigs.LaneUpdates
+			result["signatures"] = sigs.Signatures
+		}
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("encode result: %w", err)
+		}
+	}
+
+	for _, node := range network.Nodes() {
+		observations, reportSignatures := node.Requests()
+		fmt.Fprintf(os.Stderr, "node %d: %d observation requests, %d report signature requests\n",
+			node.ID(), observations, reportSignatures)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rounds failed", failed, opts.rounds)
+	}
+	return nil
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
-	plugincommon2 "github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
 	"github.com/smartcontractkit/chainlink-ccip/mocks/internal_/plugincommon"
 	reader2 "github.com/smartcontractkit/chainlink-ccip/mocks/internal_/reader"
 	"github.com/smartcontractkit/chainlink-ccip/mocks/pkg/reader"
@@ -131,7 +130,7 @@ func Test_processor_Observation(t *testing.T) {
 				ccipReader:      ccipReader,
 				oracleID:        oracleID,
 				homeChain:       homeChain,
-				metricsReporter: plugincommon2.NoopReporter{},
+				metricsReporter: NoopMetrics{},
 				obs:             newBaseObserver(ccipReader, tc.dstChain, oracleID, cs),
 			}
 
@@ -342,7 +341,7 @@ func Test_unique_chain_filter_in_Observation(t *testing.T) {
 				ccipReader:      ccipReader,
 				oracleID:        oracleID,
 				homeChain:       homeChain,
-				metricsReporter: plugincommon2.NoopReporter{},
+				metricsReporter: NoopMetrics{},
 				obs:             newBaseObserver(ccipReader, tc.dstChain, oracleID, cs),
 			}
 
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal/libs/mathslib"
 	"github.com/smartcontractkit/chainlink-ccip/pkg/consts"
 
@@ -21,7 +23,7 @@ import (
 
 func (p *processor) Outcome(
 	ctx context.Context,
-	_ Outcome,
+	prevOutcome Outcome,
 	_ Query,
 	aos []plugincommon.AttributedObservation[Observation],
 ) (Outcome, error) {
@@ -33,14 +35,16 @@ func (p *processor) Outcome(
 
 	consensusObs, err := p.getConsensusObservation(lggr, aos)
 	if err != nil {
-		return Outcome{}, fmt.Errorf("get consensus observation: %w", err)
+		// Keep the policy state, chain fees are not observed while previous prices are inflight.
+		return Outcome{UpdatePolicyState: prevOutcome.UpdatePolicyState},
+			fmt.Errorf("get consensus observation: %w", err)
 	}
 
 	// No need to update yet
 	if len(consensusObs.FeeComponents) == 0 {
 		lggr.Warn("no consensus on fee components, nothing to update",
 			"consensusObs", consensusObs)
-		return Outcome{}, nil
+		return Outcome{UpdatePolicyState: prevOutcome.UpdatePolicyState}, nil
 	}
 
 	chainFeeUSDPrices := make(map[cciptypes.ChainSelector]ComponentsUSDPrices)
@@ -90,11 +94,12 @@ func (p *processor) Outcome(
 		chainFeeUSDPrices[chain] = chainFeeUsd
 	}
 
-	gasPrices := p.getGasPricesToUpdate(
+	gasPrices, policyState := p.getGasPricesToUpdate(
 		lggr,
 		chainFeeUSDPrices,
 		consensusObs.ChainFeeUpdates,
 		consensusObs.TimestampNow,
+		prevOutcome.UpdatePolicyState,
 	)
 
 	// sort chainFeeUSDPrices based on chainSel
@@ -107,7 +112,7 @@ func (p *processor) Outcome(
 		"consensusTimestamp", consensusObs.TimestampNow,
 	)
 
-	out := Outcome{GasPrices: gasPrices}
+	out := Outcome{GasPrices: gasPrices, UpdatePolicyState: policyState}
 	return out, nil
 }
 
@@ -144,13 +149,13 @@ func (p *processor) getConsensusObservation(
 
 	twoFChainPlus1 := consensus.MakeMultiThreshold(fChains, consensus.TwoFPlus1)
 
-	feeComponents := consensus.GetConsensusMapAggregator(
+	feeComponents := consensus.GetConsensusMapKeyAggregator(
 		lggr,
 		"FeeComponents",
 		aggObs.FeeComponents,
 		twoFChainPlus1,
 		// Aggregator function
-		func(vals []types.ChainFeeComponents) types.ChainFeeComponents {
+		func(chain cciptypes.ChainSelector, vals []types.ChainFeeComponents) types.ChainFeeComponents {
 			executionFees := make([]cciptypes.BigInt, len(vals))
 			dataAvailabilityFees := make([]cciptypes.BigInt, len(vals))
 			for i, feeComp := range vals {
@@ -158,20 +163,20 @@ func (p *processor) getConsensusObservation(
 				dataAvailabilityFees[i] = cciptypes.NewBigInt(feeComp.DataAvailabilityFee)
 			}
 			return types.ChainFeeComponents{
-				ExecutionFee:        consensus.Median(executionFees, consensus.BigIntComparator).Int,
-				DataAvailabilityFee: consensus.Median(dataAvailabilityFees, consensus.BigIntComparator).Int,
+				ExecutionFee:        p.medianWithoutOutliers(lggr, chain, executionFeeComponent, executionFees).Int,
+				DataAvailabilityFee: p.medianWithoutOutliers(lggr, chain, dataAvFeeComponent, dataAvailabilityFees).Int,
 			}
 		},
 	)
 
-	nativeTokenPrices := consensus.GetConsensusMapAggregator(
+	nativeTokenPrices := consensus.GetConsensusMapKeyAggregator(
 		lggr,
 		"NativeTokenPrices",
 		aggObs.NativeTokenPrices,
 		twoFChainPlus1,
 		// Aggregator function
-		func(vals []cciptypes.BigInt) cciptypes.BigInt {
-			return consensus.Median(vals, consensus.BigIntComparator)
+		func(chain cciptypes.ChainSelector, vals []cciptypes.BigInt) cciptypes.BigInt {
+			return p.medianWithoutOutliers(lggr, chain, nativeTokenPriceComponent, vals)
 		},
 	)
 
@@ -186,6 +191,28 @@ func (p *processor) getConsensusObservation(
 	return consensusObs, nil
 }
 
+// medianWithoutOutliers returns the median of the observed values of a chain fee component, after rejecting the
+// values that are further than ChainFeeOutlierMADMultiplier median absolute deviations from their median.
+func (p *processor) medianWithoutOutliers(
+	lggr logger.Logger,
+	chain cciptypes.ChainSelector,
+	component string,
+	vals []cciptypes.BigInt,
+) cciptypes.BigInt {
+	inliers, outliers := consensus.RejectOutliers(vals, p.cfg.ChainFeeOutlierMADMultiplier)
+	if len(outliers) > 0 {
+		lggr.Warnw("rejected outlier chain fee observations",
+			"chain", chain,
+			"component", component,
+			"outliers", outliers,
+			"observations", vals,
+			"madMultiplier", p.cfg.ChainFeeOutlierMADMultiplier,
+		)
+		p.metricsReporter.TrackChainFeeRejection(chain, component, outlierRejection, len(outliers))
+	}
+	return consensus.Median(inliers, consensus.BigIntComparator)
+}
+
 func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]) AggregateObservation {
 	aggObs := AggregateObservation{
 		FeeComponents:     make(map[cciptypes.ChainSelector][]types.ChainFeeComponents),
@@ -225,8 +252,8 @@ func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]
 }
 
 // getGasPricesToUpdate checks which chain fees need to be updated based on the observed chain fee prices and
-// the fee quoter updates.
-// A chain fee is selected for update if it meets one of 2 conditions:
+// the fee quoter updates. The decision is delegated to the configured update policy, by default
+// a chain fee is selected for update if it meets one of 2 conditions:
 // 1. If time passed since the last update is greater than the stale threshold.
 // 2. If deviation between the fee quoter and latest observed chain fee exceeds the chain's configured threshold.
 func (p *processor) getGasPricesToUpdate(
@@ -234,17 +261,19 @@ func (p *processor) getGasPricesToUpdate(
 	currentChainUSDFees map[cciptypes.ChainSelector]ComponentsUSDPrices,
 	latestUpdates map[cciptypes.ChainSelector]Update,
 	consensusTimestamp time.Time,
-) []cciptypes.GasPriceChain {
+	policyState updatepolicy.State,
+) ([]cciptypes.GasPriceChain, updatepolicy.State) {
 	var gasPrices []cciptypes.GasPriceChain
 
 	destChainCfg, err := p.homeChain.GetChainConfig(p.destChain)
 	if err != nil {
 		lggr.Errorw("error getting dest chain config", "chain", p.destChain, "err", err)
-		return gasPrices
+		return gasPrices, policyState
 	}
 	execGasPriceDeviation := destChainCfg.Config.GasPriceDeviationPPB.Int64()
 	daGasPriceDeviation := destChainCfg.Config.DAGasPriceDeviationPPB.Int64()
 
+	candidates := make([]updatepolicy.Candidate, 0, len(currentChainUSDFees))
 	for chain, currentChainFee := range currentChainUSDFees {
 		chainCfg, err := p.homeChain.GetChainConfig(chain)
 		if err != nil {
@@ -253,84 +282,135 @@ func (p *processor) getGasPricesToUpdate(
 		}
 
 		feeConfig := chainCfg.Config
-		packedFee := cciptypes.NewBigInt(FeeComponentsToPackedFee(currentChainFee))
-		lastUpdate, exists := latestUpdates[chain]
-		lggr := logger.With(lggr,
-			"chain", chain,
-			"consensusTimestamp", consensusTimestamp,
-			"currentChainFee", currentChainFee,
-			"packedFee", packedFee,
-			"lastUpdate", lastUpdate)
-		// If the chain is not in the fee quoter updates or is stale, then we should update it
-		if !exists {
-			lggr.Infow("chain fee update needed: no previous update exists")
-			gasPrices = append(gasPrices, cciptypes.GasPriceChain{
-				ChainSel: chain,
-				GasPrice: packedFee,
-			})
-			continue
+		candidate := updatepolicy.Candidate{
+			Key: chainCandidateKey(chain),
+			Components: []updatepolicy.Component{
+				{Current: currentChainFee.ExecutionFeePriceUSD, DeviationPPB: execGasPriceDeviation},
+				{Current: currentChainFee.DataAvFeePriceUSD, DeviationPPB: daGasPriceDeviation},
+			},
+			Heartbeat:         p.cfg.RemoteGasPriceBatchWriteFrequency.Duration(),
+			DeviationDisabled: feeConfig.ChainFeeDeviationDisabled,
+		}
+		if lastUpdate, exists := latestUpdates[chain]; exists {
+			candidate.LastUpdate = lastUpdate.Timestamp
+			candidate.Components[0].Last = lastUpdate.ChainFee.ExecutionFeePriceUSD
+			candidate.Components[1].Last = lastUpdate.ChainFee.DataAvFeePriceUSD
 		}
 
-		nextUpdateTime := lastUpdate.Timestamp.Add(p.cfg.RemoteGasPriceBatchWriteFrequency.Duration())
-		if consensusTimestamp.After(nextUpdateTime) {
-			lggr.Infow("chain fee update needed: heartbeat time passed",
-				"nextUpdateTime", nextUpdateTime,
-				"consensusTimestamp", consensusTimestamp,
-				"heartbeatInterval", p.cfg.RemoteGasPriceBatchWriteFrequency)
-			gasPrices = append(gasPrices, cciptypes.GasPriceChain{
-				ChainSel: chain,
-				GasPrice: packedFee,
-			})
-			continue
+		// Chain fees can be updated even if the config is invalid when write frequency is reached.
+		if !candidate.DeviationDisabled {
+			if err := feeConfig.Validate(); err != nil {
+				lggr.Errorw("invalid fee config for chain", "chain", chain, "err", err)
+				candidate.DeviationDisabled = true
+			}
 		}
+		candidates = append(candidates, candidate)
+	}
+
+	decisions, nextPolicyState := p.updatePolicy.Select(consensusTimestamp, candidates, policyState)
 
-		if feeConfig.ChainFeeDeviationDisabled {
-			lggr.Debugw("chain fee deviation disabled")
+	selected := make(map[cciptypes.ChainSelector]updatepolicy.Reason, len(decisions))
+	for _, decision := range decisions {
+		chain, err := strconv.ParseUint(decision.Key, 10, 64)
+		if err != nil {
+			lggr.Errorw("invalid chain fee update decision", "key", decision.Key, "err", err)
 			continue
 		}
+		selected[cciptypes.ChainSelector(chain)] = decision.Reason
+	}
+
+	for chain, currentChainFee := range currentChainUSDFees {
+		lggr := logger.With(lggr,
+			"chain", chain,
+			"consensusTimestamp", consensusTimestamp,
+			"currentChainFee", currentChainFee,
+			"lastUpdate", latestUpdates[chain])
 
-		// Validating later as chain can be updated even if the config is invalid when write frequency is reached
-		if feeConfig.Validate() != nil {
-			lggr.Errorw("invalid fee config for chain", "err", err)
+		reason, ok := selected[chain]
+		if !ok {
+			lggr.Infow("chain fee update not needed",
+				"executionFeeDeviationPPB", execGasPriceDeviation,
+				"dataAvFeeDeviationPPB", daGasPriceDeviation)
 			continue
 		}
 
-		executionFeeDeviates := mathslib.Deviates(
-			currentChainFee.ExecutionFeePriceUSD,
-			lastUpdate.ChainFee.ExecutionFeePriceUSD,
-			execGasPriceDeviation,
-		)
+		if lastUpdate, exists := latestUpdates[chain]; exists {
+			currentChainFee = p.capChainFeeChange(lggr, chain, currentChainFee, lastUpdate.ChainFee)
+		}
+		packedFee := cciptypes.NewBigInt(FeeComponentsToPackedFee(currentChainFee))
+		lggr.Infow("chain fee update needed", "reason", reason, "packedFee", packedFee)
+		gasPrices = append(gasPrices, cciptypes.GasPriceChain{
+			ChainSel: chain,
+			GasPrice: packedFee,
+		})
+	}
 
-		dataAvFeeDeviates := mathslib.Deviates(
-			currentChainFee.DataAvFeePriceUSD,
-			lastUpdate.ChainFee.DataAvFeePriceUSD,
-			daGasPriceDeviation,
-		)
+	return gasPrices, nextPolicyState
+}
+
+// capChainFeeChange limits the change of the USD gas prices of a chain from their onchain values to the configured
+// ChainFeeMaxChangePPB of the chain. Larger changes reach the observed values over the next updates.
+func (p *processor) capChainFeeChange(
+	lggr logger.Logger,
+	chain cciptypes.ChainSelector,
+	current, last ComponentsUSDPrices,
+) ComponentsUSDPrices {
+	maxChangePPB, ok := p.cfg.ChainFeeMaxChangePPB[chain]
+	if !ok {
+		return current
+	}
 
-		if executionFeeDeviates || dataAvFeeDeviates {
-			lggr.Infow(
-				"chain fee update needed: deviation threshold exceeded for either execution or data availability fee",
-				"executionFeeDeviates", executionFeeDeviates,
-				"dataAvFeeDeviates", dataAvFeeDeviates,
-				"executionFeeDeviationPPB", feeConfig.GasPriceDeviationPPB,
-				"dataAvFeeDeviationPPB", feeConfig.DAGasPriceDeviationPPB)
-			gasPrices = append(gasPrices, cciptypes.GasPriceChain{
-				ChainSel: chain,
-				GasPrice: packedFee,
-			})
+	capped := ComponentsUSDPrices{
+		ExecutionFeePriceUSD: capChange(current.ExecutionFeePriceUSD, last.ExecutionFeePriceUSD, maxChangePPB),
+		DataAvFeePriceUSD:    capChange(current.DataAvFeePriceUSD, last.DataAvFeePriceUSD, maxChangePPB),
+	}
+	for _, c := range []struct {
+		component       string
+		current, capped *big.Int
+	}{
+		{executionFeeUSDComponent, current.ExecutionFeePriceUSD, capped.ExecutionFeePriceUSD},
+		{dataAvFeeUSDComponent, current.DataAvFeePriceUSD, capped.DataAvFeePriceUSD},
+	} {
+		if c.current.Cmp(c.capped) == 0 {
 			continue
 		}
+		lggr.Warnw("chain fee change exceeds the max change, capping the update",
+			"component", c.component,
+			"observed", c.current,
+			"capped", c.capped,
+			"maxChangePPB", maxChangePPB,
+		)
+		p.metricsReporter.TrackChainFeeRejection(chain, c.component, maxChangeRejection, 1)
+	}
+	return capped
+}
 
-		lggr.Infow("chain fee update not needed",
-			"chain", chain,
-			"currentChainFee", currentChainFee,
-			"lastUpdateTimestamp", lastUpdate.Timestamp,
-			"currentTimestamp", consensusTimestamp,
-			"executionFeeDeviationPPB", feeConfig.GasPriceDeviationPPB,
-			"dataAvFeeDeviationPPB", feeConfig.DAGasPriceDeviationPPB)
+// capChange returns value moved back within maxChangePPB parts per billion of last.
+// The value is returned as is when there is no last value to compare against.
+func capChange(value, last *big.Int, maxChangePPB uint64) *big.Int {
+	if last == nil || last.Sign() <= 0 {
+		return value
 	}
+	maxChange := new(big.Int).Mul(last, new(big.Int).SetUint64(maxChangePPB))
+	maxChange.Div(maxChange, big.NewInt(1e9))
+
+	upper := new(big.Int).Add(last, maxChange)
+	if value.Cmp(upper) > 0 {
+		return upper
+	}
+	lower := new(big.Int).Sub(last, maxChange)
+	if lower.Sign() < 0 {
+		lower.SetInt64(0)
+	}
+	if value.Cmp(lower) < 0 {
+		return lower
+	}
+	return value
+}
 
-	return gasPrices
+// chainCandidateKey is the update policy key of the chain fee of a chain.
+func chainCandidateKey(chain cciptypes.ChainSelector) string {
+	return strconv.FormatUint(uint64(chain), 10)
 }
 
 // chainFeeUpdateAggregator aggregates a slice of ChainFeeUpdates into a single Update
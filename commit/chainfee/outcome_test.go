@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal"
 	"github.com/smartcontractkit/chainlink-ccip/pkg/consts"
 
@@ -207,6 +208,154 @@ func TestGetConsensusObservation(t *testing.T) {
 	assert.Len(t, consensusObs.NativeTokenPrices, 2)
 }
 
+// rejectionsReporter records the chain fee rejections by chain, component and reason.
+type rejectionsReporter struct {
+	NoopMetrics
+	rejections map[cciptypes.ChainSelector]map[string]int
+}
+
+func (r *rejectionsReporter) TrackChainFeeRejection(chain cciptypes.ChainSelector, component, reason string, n int) {
+	if r.rejections == nil {
+		r.rejections = make(map[cciptypes.ChainSelector]map[string]int)
+	}
+	if r.rejections[chain] == nil {
+		r.rejections[chain] = make(map[string]int)
+	}
+	r.rejections[chain][component+"/"+reason] += n
+}
+
+func TestGetConsensusObservation_outliers(t *testing.T) {
+	lggr := logger.Test(t)
+	reporter := &rejectionsReporter{}
+	p := &processor{
+		lggr:            lggr,
+		destChain:       internal.EvmChainSelector,
+		fRoleDON:        1,
+		cfg:             pluginconfig.CommitOffchainConfig{ChainFeeOutlierMADMultiplier: 3},
+		metricsReporter: reporter,
+	}
+
+	execFees := []int64{100, 101, 99, 100, 1e12}
+	daFees := []int64{200, 200, 201, 199, 200}
+	nativePrices := []int64{1e18, 1e18 + 1e15, 1e18 - 1e15, 1, 1e18}
+
+	aos := make([]plugincommon.AttributedObservation[Observation], len(execFees))
+	for i := range execFees {
+		aos[i] = plugincommon.AttributedObservation[Observation]{
+			OracleID: commontypes.OracleID(i),
+			Observation: Observation{
+				FeeComponents: map[cciptypes.ChainSelector]types.ChainFeeComponents{
+					internal.EvmChainSelector: {
+						ExecutionFee:        big.NewInt(execFees[i]),
+						DataAvailabilityFee: big.NewInt(daFees[i]),
+					},
+				},
+				NativeTokenPrices: map[cciptypes.ChainSelector]cciptypes.BigInt{
+					internal.EvmChainSelector: cciptypes.NewBigIntFromInt64(nativePrices[i]),
+				},
+				FChain:       map[cciptypes.ChainSelector]int{internal.EvmChainSelector: 1},
+				TimestampNow: ts,
+			},
+		}
+	}
+
+	consensusObs, err := p.getConsensusObservation(lggr, aos)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), consensusObs.FeeComponents[internal.EvmChainSelector].ExecutionFee)
+	assert.Equal(t, big.NewInt(200), consensusObs.FeeComponents[internal.EvmChainSelector].DataAvailabilityFee)
+	assert.Equal(t, cciptypes.NewBigIntFromInt64(1e18), consensusObs.NativeTokenPrices[internal.EvmChainSelector])
+	assert.Equal(t, map[cciptypes.ChainSelector]map[string]int{
+		internal.EvmChainSelector: {
+			executionFeeComponent + "/" + outlierRejection:     1,
+			nativeTokenPriceComponent + "/" + outlierRejection: 1,
+		},
+	}, reporter.rejections)
+}
+
+func TestCapChange(t *testing.T) {
+	testCases := []struct {
+		name         string
+		value        *big.Int
+		last         *big.Int
+		maxChangePPB uint64
+		exp          *big.Int
+	}{
+		{name: "within the max change", value: big.NewInt(110), last: big.NewInt(100), maxChangePPB: 1e8,
+			exp: big.NewInt(110)},
+		{name: "increase is capped", value: big.NewInt(1000), last: big.NewInt(100), maxChangePPB: 1e8,
+			exp: big.NewInt(110)},
+		{name: "decrease is capped", value: big.NewInt(1), last: big.NewInt(100), maxChangePPB: 5e8,
+			exp: big.NewInt(50)},
+		{name: "decrease does not go below zero", value: big.NewInt(0), last: big.NewInt(100), maxChangePPB: 2e9,
+			exp: big.NewInt(0)},
+		{name: "no last value", value: big.NewInt(1000), last: nil, maxChangePPB: 1e8,
+			exp: big.NewInt(1000)},
+		{name: "zero last value", value: big.NewInt(1000), last: big.NewInt(0), maxChangePPB: 1e8,
+			exp: big.NewInt(1000)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, capChange(tc.value, tc.last, tc.maxChangePPB))
+		})
+	}
+}
+
+func TestProcessor_Outcome_maxChange(t *testing.T) {
+	ctx := tests.Context(t)
+	homeChainMock := mock_home_chain.NewMockHomeChain(t)
+	homeChainMock.EXPECT().GetChainConfig(mock.Anything).Return(defaultChainConfig, nil).Maybe()
+
+	reporter := &rejectionsReporter{}
+	p := &processor{
+		lggr:      logger.Test(t),
+		destChain: internal.EvmChainSelector,
+		fRoleDON:  1,
+		cfg: pluginconfig.CommitOffchainConfig{
+			RemoteGasPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Hour),
+			ChainFeeMaxChangePPB:              map[cciptypes.ChainSelector]uint64{internal.EvmChainSelector: 1e9},
+		},
+		metricsReporter: reporter,
+		updatePolicy:    updatepolicy.Default(),
+		homeChain:       homeChainMock,
+	}
+
+	// The execution fee spikes to 10x its onchain value, the data availability fee is unchanged.
+	aos := sameObs(5, Observation{
+		FeeComponents: map[cciptypes.ChainSelector]types.ChainFeeComponents{
+			internal.EvmChainSelector: {ExecutionFee: big.NewInt(1000), DataAvailabilityFee: big.NewInt(100)},
+		},
+		NativeTokenPrices: map[cciptypes.ChainSelector]cciptypes.BigInt{
+			internal.EvmChainSelector: cciptypes.NewBigInt(big.NewInt(1e18)),
+		},
+		ChainFeeUpdates: map[cciptypes.ChainSelector]Update{
+			internal.EvmChainSelector: {
+				Timestamp: ts.Add(-time.Minute),
+				ChainFee: ComponentsUSDPrices{
+					ExecutionFeePriceUSD: big.NewInt(100), DataAvFeePriceUSD: big.NewInt(100),
+				},
+			},
+		},
+		FChain:       map[cciptypes.ChainSelector]int{internal.EvmChainSelector: 1},
+		TimestampNow: ts,
+	})
+
+	outcome, err := p.Outcome(ctx, Outcome{}, Query{}, aos)
+	require.NoError(t, err)
+
+	// The execution fee can at most double in a single update.
+	expGasPrice := FeeComponentsToPackedFee(ComponentsUSDPrices{
+		ExecutionFeePriceUSD: big.NewInt(200),
+		DataAvFeePriceUSD:    big.NewInt(100),
+	})
+	require.Len(t, outcome.GasPrices, 1)
+	assert.Equal(t, internal.EvmChainSelector, outcome.GasPrices[0].ChainSel)
+	assert.Equal(t, expGasPrice, outcome.GasPrices[0].GasPrice.Int)
+	assert.Equal(t, map[cciptypes.ChainSelector]map[string]int{
+		internal.EvmChainSelector: {executionFeeUSDComponent + "/" + maxChangeRejection: 1},
+	}, reporter.rejections)
+}
+
 func TestProcessor_Outcome(t *testing.T) {
 	oneMinuteAgo := time.Now().Add(-time.Minute).UTC()
 	numOracles := 5 // Use a consistent number for generating aos
@@ -420,7 +569,8 @@ func TestProcessor_Outcome(t *testing.T) {
 				cfg: pluginconfig.CommitOffchainConfig{
 					RemoteGasPriceBatchWriteFrequency: tt.chainFeeWriteFrequency,
 				},
-				metricsReporter: plugincommon.NoopReporter{},
+				metricsReporter: NoopMetrics{},
+				updatePolicy:    updatepolicy.Default(),
 				homeChain:       homeChainMock,
 			}
 
@@ -6,6 +6,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/libocr/commontypes"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
 	"github.com/smartcontractkit/chainlink-ccip/internal/reader"
 	readerpkg "github.com/smartcontractkit/chainlink-ccip/pkg/reader"
@@ -22,9 +23,10 @@ type processor struct {
 	ccipReader      readerpkg.CCIPReader
 	cfg             pluginconfig.CommitOffchainConfig
 	chainSupport    plugincommon.ChainSupport
-	metricsReporter plugincommon.MetricsReporter
+	metricsReporter MetricsReporter
 	fRoleDON        int
 	obs             observer
+	updatePolicy    updatepolicy.Policy
 }
 
 func NewProcessor(
@@ -36,7 +38,7 @@ func NewProcessor(
 	offChainConfig pluginconfig.CommitOffchainConfig,
 	chainSupport plugincommon.ChainSupport,
 	fRoleDON int,
-	metricsReporter plugincommon.MetricsReporter,
+	metricsReporter MetricsReporter,
 ) plugincommon.PluginProcessor[Query, Observation, Outcome] {
 	var obs observer
 	baseObs := newBaseObserver(
@@ -56,6 +58,12 @@ func NewProcessor(
 		obs = baseObs
 	}
 
+	updatePolicy, err := updatepolicy.New(offChainConfig.ChainFeeUpdatePolicy)
+	if err != nil {
+		lggr.Errorw("invalid chain fee update policy, falling back to the default policy", "err", err)
+		updatePolicy = updatepolicy.Default()
+	}
+
 	p := &processor{
 		lggr:            lggr,
 		oracleID:        oracleID,
@@ -67,6 +75,7 @@ func NewProcessor(
 		cfg:             offChainConfig,
 		metricsReporter: metricsReporter,
 		obs:             obs,
+		updatePolicy:    updatePolicy,
 	}
 	return plugincommon.NewTrackedProcessor(lggr, p, processorLabel, metricsReporter)
 }
@@ -6,6 +6,9 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 )
 
@@ -17,12 +20,42 @@ const (
 	chainFeeUpdatesLabel   = "chainFeeUpdates"
 )
 
+// Labels of the chain fee values that can be rejected or capped, see MetricsReporter.
+const (
+	executionFeeComponent     = "executionFee"
+	dataAvFeeComponent        = "dataAvailabilityFee"
+	nativeTokenPriceComponent = "nativeTokenPrice"
+	executionFeeUSDComponent  = "executionFeeUSD"
+	dataAvFeeUSDComponent     = "dataAvailabilityFeeUSD"
+
+	outlierRejection   = "outlier"
+	maxChangeRejection = "maxChange"
+)
+
+// MetricsReporter exposes only relevant methods for reporting chain fees from metrics.Reporter
+type MetricsReporter interface {
+	TrackProcessorLatency(processor string, method string, latency time.Duration, err error)
+	TrackProcessorOutput(processor string, method plugincommon.MethodType, obs plugintypes.Trackable)
+	// TrackChainFeeRejection tracks count values of the component of a chain fee that were rejected or capped.
+	TrackChainFeeRejection(chain cciptypes.ChainSelector, component, reason string, count int)
+}
+
+type NoopMetrics struct{}
+
+func (n NoopMetrics) TrackProcessorLatency(string, string, time.Duration, error) {}
+
+func (n NoopMetrics) TrackProcessorOutput(string, plugincommon.MethodType, plugintypes.Trackable) {}
+
+func (n NoopMetrics) TrackChainFeeRejection(cciptypes.ChainSelector, string, string, int) {}
+
 type Query struct {
 }
 
 type Outcome struct {
 	// Each Gas Price is the combination of Execution and DataAvailability Fees using bitwise operations
 	GasPrices []cciptypes.GasPriceChain `json:"gasPrices"`
+	// UpdatePolicyState is the state of the chain fee update policy, carried to the next round.
+	UpdatePolicyState updatepolicy.State `json:"updatePolicyState"`
 }
 
 func (o Outcome) Stats() map[string]int {
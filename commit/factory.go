@@ -17,6 +17,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-ccip/commit/internal/builder"
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmncrypto"
 	"github.com/smartcontractkit/chainlink-ccip/commit/metrics"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
 	"github.com/smartcontractkit/chainlink-ccip/internal/reader"
@@ -50,11 +51,11 @@ const (
 
 	// maxObservationLength is set to the maximum size of an observation
 	// check factory_test for the calculation
-	maxObservationLength = 650_307
+	maxObservationLength = 959_774
 
 	// maxOutcomeLength is set to the maximum size of an outcome
 	// check factory_test for the calculation
-	maxOutcomeLength = 700_620
+	maxOutcomeLength = 1_742_351
 
 	// maxReportLength is set to an estimate of a maximum report size
 	// check factory_test for the calculation
@@ -77,6 +78,8 @@ type PluginFactory struct {
 	chainWriters      map[cciptypes.ChainSelector]types.ContractWriter
 	rmnPeerClient     rmn.PeerClient
 	rmnCrypto         cciptypes.RMNCrypto
+	priceFeedReaders  map[cciptypes.ChainSelector]readerpkg.PriceFeedReader
+	priceReportFetch  readerpkg.PriceReportFetcher
 }
 
 type CommitPluginFactoryParams struct {
@@ -91,7 +94,15 @@ type CommitPluginFactoryParams struct {
 	ContractReaders   map[cciptypes.ChainSelector]types.ContractReader
 	ContractWriters   map[cciptypes.ChainSelector]types.ContractWriter
 	RmnPeerClient     rmn.PeerClient
-	RmnCrypto         cciptypes.RMNCrypto
+	// RmnCrypto verifies the RMN report signatures, the rmncrypto backend of the destination chain family is used
+	// when not set.
+	RmnCrypto cciptypes.RMNCrypto
+	// PriceFeedReaders are the readers of the token price feeds of the chains whose feeds are not read with their
+	// contract reader, optional.
+	PriceFeedReaders map[cciptypes.ChainSelector]readerpkg.PriceFeedReader
+	// PriceReportFetcher fetches the reports of the offchain price report source, optional. The reports are fetched
+	// from pluginconfig.PriceReportSource.URL when not set.
+	PriceReportFetcher readerpkg.PriceReportFetcher
 }
 
 // NewCommitPluginFactory creates a new PluginFactory instance. For commit plugin, oracle instances are not managed by
@@ -110,6 +121,8 @@ func NewCommitPluginFactory(params CommitPluginFactoryParams) *PluginFactory {
 		chainWriters:      params.ContractWriters,
 		rmnPeerClient:     params.RmnPeerClient,
 		rmnCrypto:         params.RmnCrypto,
+		priceFeedReaders:  params.PriceFeedReaders,
+		priceReportFetch:  params.PriceReportFetcher,
 	}
 }
 
@@ -129,6 +142,10 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to validate commit offchain config: %w", err)
 	}
 
+	if err = offchainConfig.ValidateFeedAddresses(p.addrCodec); err != nil {
+		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to validate commit offchain config: %w", err)
+	}
+
 	var oracleIDToP2PID = make(map[commontypes.OracleID]ragep2ptypes.PeerID)
 	for oracleID, node := range p.ocrConfig.Config.Nodes {
 		oracleIDToP2PID[commontypes.OracleID(oracleID)] = node.P2pID
@@ -154,7 +171,15 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 
 	// Bind the RMNHome contract
 	var rmnHomeReader readerpkg.RMNHome
+	rmnCrypto := p.rmnCrypto
 	if offchainConfig.RMNEnabled {
+		if rmnCrypto == nil {
+			rmnCrypto, err = rmncrypto.GetForChain(p.ocrConfig.Config.ChainSelector)
+			if err != nil {
+				return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to get RMN crypto: %w", err)
+			}
+		}
+
 		rmnHomeAddress := p.ocrConfig.Config.RmnHomeAddress
 		rmnCr, ok := readers[p.homeChainSelector]
 		if !ok {
@@ -197,30 +222,69 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 		return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to create CCIP chain reader: %w", err)
 	}
 
-	// The node supports the chain that the token prices are on.
-	_, ok := readers[offchainConfig.PriceFeedChainSelector]
-	if ok {
-		// Bind all token aggregate contracts
-		var bcs []types.BoundContract
-		for _, info := range offchainConfig.TokenInfo {
-			bcs = append(bcs, types.BoundContract{
-				Address: string(info.AggregatorAddress),
+	// Bind all token aggregate and rate provider contracts on the feed chains that the node supports, unless the chain
+	// has its own price feed reader.
+	feedContracts := make(map[cciptypes.ChainSelector][]types.BoundContract)
+	for _, info := range offchainConfig.TokenInfo {
+		feeds := info.PriceFeeds(offchainConfig.PriceFeedChainSelector)
+		if info.Derived != nil {
+			derived := info.Derived.WithChain(offchainConfig.PriceFeedChainSelector)
+			feeds = append(feeds, derived.Feeds...)
+			for _, rate := range derived.Rates {
+				feedContracts[rate.ChainSelector] = append(feedContracts[rate.ChainSelector], types.BoundContract{
+					Address: string(rate.Address),
+					Name:    consts.ContractNameRateProvider,
+				})
+			}
+		}
+		for _, feed := range feeds {
+			feedContracts[feed.ChainSelector] = append(feedContracts[feed.ChainSelector], types.BoundContract{
+				Address: string(feed.AggregatorAddress),
 				Name:    consts.ContractNamePriceAggregator,
 			})
 		}
-		if err1 := readers[offchainConfig.PriceFeedChainSelector].Bind(ctx, bcs); err1 != nil {
-			return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to bind token price contracts: %w", err1)
+	}
+	for chain, bcs := range feedContracts {
+		if _, ok := readers[chain]; !ok {
+			continue
+		}
+		// The feeds are not read with the contract reader of the chain.
+		if _, ok := p.priceFeedReaders[chain]; ok {
+			continue
+		}
+		if err1 := readers[chain].Bind(ctx, bcs); err1 != nil {
+			return nil, ocr3types.ReportingPluginInfo{},
+				fmt.Errorf("failed to bind token price contracts on chain %d: %w", chain, err1)
 		}
 	}
 
-	onChainTokenPricesReader := readerpkg.NewPriceReader(
-		logutil.WithComponent(lggr, "PriceReader"),
-		readers,
-		offchainConfig.TokenInfo,
-		ccipReader,
-		offchainConfig.PriceFeedChainSelector,
-		p.addrCodec,
-	)
+	var priceReportReader readerpkg.PriceReportReader
+	if source := offchainConfig.PriceReportSource; source != nil {
+		fetcher := p.priceReportFetch
+		if fetcher == nil {
+			fetcher, err = readerpkg.NewHTTPPriceReportFetcher(
+				logutil.WithComponent(lggr, "PriceReportFetcher"), source.URL, source.Timeout.Duration())
+			if err != nil {
+				return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to create price report fetcher: %w", err)
+			}
+		}
+		priceReportReader, err = readerpkg.NewPriceReportReader(logutil.WithComponent(lggr, "PriceReportReader"),
+			fetcher, source.ConfigDigest, source.Signers, source.MinSigners)
+		if err != nil {
+			return nil, ocr3types.ReportingPluginInfo{}, fmt.Errorf("failed to create price report reader: %w", err)
+		}
+	}
+
+	onChainTokenPricesReader := readerpkg.NewPriceReader(readerpkg.PriceReaderParams{
+		Lggr:         logutil.WithComponent(lggr, "PriceReader"),
+		ChainReaders: readers,
+		TokenInfo:    offchainConfig.TokenInfo,
+		CCIPReader:   ccipReader,
+		FeedChain:    offchainConfig.PriceFeedChainSelector,
+		AddressCodec: p.addrCodec,
+		FeedReaders:  p.priceFeedReaders,
+		ReportReader: priceReportReader,
+	})
 
 	metricsReporter, err := metrics.NewPromReporter(lggr, p.ocrConfig.Config.ChainSelector)
 	if err != nil {
@@ -248,7 +312,7 @@ func (p *PluginFactory) NewReportingPlugin(ctx context.Context, config ocr3types
 			lggr,
 			p.homeChainReader,
 			rmnHomeReader,
-			p.rmnCrypto,
+			rmnCrypto,
 			p.rmnPeerClient,
 			config,
 			metricsReporter,
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot"
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
 	"github.com/smartcontractkit/chainlink-ccip/commit/tokenprice"
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal/mocks"
 	dt "github.com/smartcontractkit/chainlink-ccip/internal/plugincommon/discovery/discoverytypes"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
@@ -108,6 +110,27 @@ func Test_maxObservationLength(t *testing.T) {
 		}
 	}
 
+	// at most one committed root per source chain of the report waiting for transmission
+	merkleRootObs.CommittedRoots = make([]ccipocr3.MerkleRootChain, estimatedMaxNumberOfSourceChains)
+	for i := range merkleRootObs.CommittedRoots {
+		merkleRootObs.CommittedRoots[i] = ccipocr3.MerkleRootChain{
+			ChainSel:      math.MaxUint64,
+			OnRampAddress: make([]byte, 40),
+			SeqNumsRange:  ccipocr3.NewSeqNumRange(math.MaxUint64, math.MaxUint64),
+			MerkleRoot:    [32]byte{},
+		}
+	}
+
+	// the message hashes are spread across all the chains to account for the per chain overhead
+	merkleRootObs.MessageHashes = make(plugintypes.MessageHashes, estimatedMaxNumberOfSourceChains)
+	for i := range merkleroot.MaxObservedMessageHashes {
+		chain := ccipocr3.ChainSelector(math.MaxUint64 - uint64(i%estimatedMaxNumberOfSourceChains))
+		if merkleRootObs.MessageHashes[chain] == nil {
+			merkleRootObs.MessageHashes[chain] = make(map[ccipocr3.SeqNum]ccipocr3.Bytes32)
+		}
+		merkleRootObs.MessageHashes[chain][ccipocr3.SeqNum(math.MaxUint64-uint64(i))] = ccipocr3.Bytes32{1}
+	}
+
 	for i := range merkleRootObs.OnRampMaxSeqNums {
 		merkleRootObs.OnRampMaxSeqNums[i] = plugintypes.SeqNumChain{
 			ChainSel: math.MaxUint64,
@@ -184,6 +207,7 @@ func Test_maxOutcomeLength(t *testing.T) {
 			RootsToReport:                   make([]ccipocr3.MerkleRootChain, estimatedMaxNumberOfSourceChains),
 			OffRampNextSeqNums:              make([]plugintypes.SeqNumChain, estimatedMaxNumberOfSourceChains),
 			ReportTransmissionCheckAttempts: math.MaxUint64,
+			LaneWaitingRounds:               make(map[ccipocr3.ChainSelector]uint64, estimatedMaxNumberOfSourceChains),
 			RMNReportSignatures:             make([]ccipocr3.RMNECDSASignature, estimatedMaxRmnNodesCount),
 			RMNRemoteCfg: ccipocr3.RemoteConfig{
 				ContractAddress:  make([]byte, 20),
@@ -202,6 +226,11 @@ func Test_maxOutcomeLength(t *testing.T) {
 		},
 	}
 
+	for i := range estimatedMaxNumberOfSourceChains {
+		chain := ccipocr3.ChainSelector(math.MaxUint64 - uint64(i))
+		maxOutc.MerkleRootOutcome.LaneWaitingRounds[chain] = math.MaxUint64
+	}
+
 	for i := range maxOutc.MerkleRootOutcome.RangesSelectedForReport {
 		maxOutc.MerkleRootOutcome.RangesSelectedForReport[i] = plugintypes.ChainRange{
 			ChainSel:    math.MaxUint64,
@@ -243,6 +272,34 @@ func Test_maxOutcomeLength(t *testing.T) {
 		}
 	}
 
+	// the update policies state, with a moving average of every token price and chain fee and a full budget
+	// the largest nanos so that the encoded size does not depend on the current time
+	maxUpdateTime := time.Now().Truncate(time.Second).Add(time.Second - time.Nanosecond)
+	maxUpdates := make([]time.Time, pluginconfig.MaxMaxUpdatesPerHour)
+	for i := range maxUpdates {
+		maxUpdates[i] = maxUpdateTime
+	}
+	maxOutc.TokenPriceOutcome.UpdatePolicyState = updatepolicy.State{
+		Smoothed: make(map[string][]ccipocr3.BigInt, estimatedMaxNumberOfPricedTokens),
+		Updates:  maxUpdates,
+	}
+	for i := range estimatedMaxNumberOfPricedTokens {
+		tokenID := generateStringWithCounter(i, 20)
+		maxOutc.TokenPriceOutcome.UpdatePolicyState.Smoothed[tokenID] = []ccipocr3.BigInt{
+			ccipocr3.NewBigIntFromInt64(math.MaxInt64),
+		}
+	}
+	maxOutc.ChainFeeOutcome.UpdatePolicyState = updatepolicy.State{
+		Smoothed: make(map[string][]ccipocr3.BigInt, estimatedMaxNumberOfSourceChains),
+		Updates:  maxUpdates,
+	}
+	for i := range estimatedMaxNumberOfSourceChains {
+		chainKey := strconv.FormatUint(math.MaxUint64-uint64(i), 10)
+		maxOutc.ChainFeeOutcome.UpdatePolicyState.Smoothed[chainKey] = []ccipocr3.BigInt{
+			ccipocr3.NewBigIntFromInt64(math.MaxInt64), ccipocr3.NewBigIntFromInt64(math.MaxInt64),
+		}
+	}
+
 	b, err := ocrtypecodec.DefaultCommitCodec.EncodeOutcome(maxOutc)
 	require.NoError(t, err)
 
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
@@ -193,6 +194,7 @@ func buildMultiplePriceAndMerkleRootReports(
 
 // buildMultipleMerkleRootReports builds many reports of with at most maxMerkleRootsPerReport roots.
 // Any price reports in the outcome are included in the first merkle root.
+// Roots are added in the lane priority order scheduled by the merkle root processor.
 func buildMultipleMerkleRootReports(
 	lggr logger.Logger,
 	outcome committypes.Outcome,
@@ -209,7 +211,13 @@ func buildMultipleMerkleRootReports(
 		GasPriceUpdates:   outcome.ChainFeeOutcome.GasPrices,
 	}
 
-	for _, r := range outcome.MerkleRootOutcome.RootsToReport {
+	laneWaitingRounds := outcome.MerkleRootOutcome.LaneWaitingRounds
+	roots := slices.Clone(outcome.MerkleRootOutcome.RootsToReport)
+	sort.SliceStable(roots, func(i, j int) bool {
+		return merkleroot.LanePriorityLess(laneWaitingRounds, roots[i].ChainSel, roots[j].ChainSel)
+	})
+
+	for _, r := range roots {
 
 		// TODO: Support RMN.
 		/*
@@ -194,6 +194,38 @@ func TestReportBuilders(t *testing.T) {
 	}
 }
 
+func Test_buildMultipleMerkleRootReports_lanePriority(t *testing.T) {
+	root := func(chainSel ccipocr3.ChainSelector) ccipocr3.MerkleRootChain {
+		return ccipocr3.MerkleRootChain{
+			ChainSel:      chainSel,
+			OnRampAddress: []byte{1, 2, 3},
+			SeqNumsRange:  ccipocr3.NewSeqNumRange(1, 2),
+			MerkleRoot:    ccipocr3.Bytes32{byte(chainSel)},
+		}
+	}
+	outcome := committypes.Outcome{
+		MerkleRootOutcome: merkleroot.Outcome{
+			OutcomeType:   merkleroot.ReportGenerated,
+			RootsToReport: []ccipocr3.MerkleRootChain{root(1), root(2), root(3), root(4)},
+			// chain 3 was scheduled in the first report, chain 1 is waiting the longest.
+			LaneWaitingRounds: map[ccipocr3.ChainSelector]uint64{1: 3, 2: 1, 4: 1},
+		},
+	}
+
+	cfg := pluginconfig.CommitOffchainConfig{MaxMerkleRootsPerReport: 1, MultipleReportsEnabled: true}
+	reports, err := buildMultipleMerkleRootReports(logger.Test(t), outcome, cfg)
+	require.NoError(t, err)
+
+	chains := make([]ccipocr3.ChainSelector, 0, len(reports))
+	for _, report := range reports {
+		require.Len(t, report.Report.UnblessedMerkleRoots, 1)
+		chains = append(chains, report.Report.UnblessedMerkleRoots[0].ChainSel)
+	}
+	require.Equal(t, []ccipocr3.ChainSelector{3, 1, 2, 4}, chains)
+	// the outcome is not modified.
+	require.Equal(t, ccipocr3.ChainSelector(1), outcome.MerkleRootOutcome.RootsToReport[0].ChainSel)
+}
+
 func Test_buildOneReport(t *testing.T) {
 	lggr := logger.Test(t)
 
@@ -0,0 +1,73 @@
+package merkleroot
+
+import (
+	"sort"
+
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// laneCandidate is a source chain with pending messages that is selected for the next report.
+type laneCandidate struct {
+	chainRange plugintypes.ChainRange
+	// pendingMsgs is the number of uncommitted messages of the lane, before the range is truncated to the max
+	// merkle tree size.
+	pendingMsgs uint64
+	// waitingRounds is the number of consecutive range selections in which the lane had pending messages but was
+	// not scheduled in the first report. It counts range selections, it is not the age of any message.
+	waitingRounds uint64
+}
+
+// scheduleLanes ranks the candidate lanes and returns the waiting rounds of the lanes that do not fit in the first
+// of the reports built with at most maxLanes merkle roots, see LanePriorityLess.
+//
+// Lanes are ranked by their waiting rounds, then by their number of pending messages and then by chain selector.
+// The first maxLanes lanes start waiting from zero the next time they have pending messages while the others wait
+// one more round, so a busy lane cannot take the first report again before a lane that is waiting for longer.
+// This guarantees that a lane with pending messages is in the first report within ceil(len(candidates)/maxLanes)
+// range selections.
+//
+// Scheduling does not leave any lane out of a round: the ranges of all the candidates are selected and a root of
+// each is built into one of the reports of the same round, scheduling only decides which report it lands in. The
+// only gain of the first report is its position: it carries the price updates and comes first in the reports of the
+// round. The ranking is not based on the age of the oldest uncommitted message of a lane, which is not observed,
+// but on waitingRounds.
+//
+// If maxLanes is zero or all the lanes fit in a single report no lane is waiting and nil is returned.
+func scheduleLanes(candidates []laneCandidate, maxLanes uint64) map[cciptypes.ChainSelector]uint64 {
+	if maxLanes == 0 || uint64(len(candidates)) <= maxLanes {
+		return nil
+	}
+
+	ranked := make([]laneCandidate, len(candidates))
+	copy(ranked, candidates)
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].waitingRounds != ranked[j].waitingRounds {
+			return ranked[i].waitingRounds > ranked[j].waitingRounds
+		}
+		if ranked[i].pendingMsgs != ranked[j].pendingMsgs {
+			return ranked[i].pendingMsgs > ranked[j].pendingMsgs
+		}
+		return ranked[i].chainRange.ChainSel < ranked[j].chainRange.ChainSel
+	})
+
+	waitingRounds := make(map[cciptypes.ChainSelector]uint64, uint64(len(ranked))-maxLanes)
+	for _, c := range ranked[maxLanes:] {
+		waitingRounds[c.chainRange.ChainSel] = c.waitingRounds + 1
+	}
+	return waitingRounds
+}
+
+// LanePriorityLess reports whether the lane of chain a should be reported before the lane of chain b, given the
+// LaneWaitingRounds of the outcome that selected them. Lanes scheduled in the first report have no waiting rounds
+// and come first, the remaining lanes follow from the longest waiting one.
+func LanePriorityLess(laneWaitingRounds map[cciptypes.ChainSelector]uint64, a, b cciptypes.ChainSelector) bool {
+	wa, wb := laneWaitingRounds[a], laneWaitingRounds[b]
+	if (wa == 0) != (wb == 0) {
+		return wa == 0
+	}
+	if wa != wb {
+		return wa > wb
+	}
+	return a < b
+}
@@ -0,0 +1,108 @@
+package merkleroot
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+func Test_scheduleLanes(t *testing.T) {
+	candidate := func(chainSel cciptypes.ChainSelector, pendingMsgs, waitingRounds uint64) laneCandidate {
+		return laneCandidate{
+			chainRange:    plugintypes.ChainRange{ChainSel: chainSel, SeqNumRange: cciptypes.NewSeqNumRange(1, 1)},
+			pendingMsgs:   pendingMsgs,
+			waitingRounds: waitingRounds,
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		candidates []laneCandidate
+		maxLanes   uint64
+		expWaiting map[cciptypes.ChainSelector]uint64
+	}{
+		{
+			name:       "no candidates",
+			maxLanes:   1,
+			expWaiting: nil,
+		},
+		{
+			name:       "no limit",
+			candidates: []laneCandidate{candidate(1, 10, 0), candidate(2, 5, 3)},
+			maxLanes:   0,
+			expWaiting: nil,
+		},
+		{
+			name:       "all lanes fit",
+			candidates: []laneCandidate{candidate(1, 10, 0), candidate(2, 5, 3)},
+			maxLanes:   2,
+			expWaiting: nil,
+		},
+		{
+			name:       "more pending messages first",
+			candidates: []laneCandidate{candidate(1, 10, 0), candidate(2, 50, 0), candidate(3, 20, 0)},
+			maxLanes:   1,
+			expWaiting: map[cciptypes.ChainSelector]uint64{1: 1, 3: 1},
+		},
+		{
+			name:       "longest waiting first",
+			candidates: []laneCandidate{candidate(1, 10, 2), candidate(2, 50, 0), candidate(3, 20, 1)},
+			maxLanes:   2,
+			expWaiting: map[cciptypes.ChainSelector]uint64{2: 1},
+		},
+		{
+			name:       "ties broken by chain selector",
+			candidates: []laneCandidate{candidate(3, 10, 1), candidate(2, 10, 1), candidate(1, 10, 1)},
+			maxLanes:   1,
+			expWaiting: map[cciptypes.ChainSelector]uint64{2: 2, 3: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expWaiting, scheduleLanes(tc.candidates, tc.maxLanes))
+		})
+	}
+}
+
+func Test_scheduleLanes_noStarvation(t *testing.T) {
+	const maxLanes = 2
+	// chain 1 is always much busier than the others.
+	pendingMsgs := map[cciptypes.ChainSelector]uint64{1: 1000, 2: 1, 3: 1, 4: 1, 5: 1}
+
+	var waiting map[cciptypes.ChainSelector]uint64
+	roundsSinceFirst := make(map[cciptypes.ChainSelector]int)
+	for round := 0; round < 20; round++ {
+		candidates := make([]laneCandidate, 0, len(pendingMsgs))
+		for chainSel := cciptypes.ChainSelector(1); chainSel <= 5; chainSel++ {
+			candidates = append(candidates, laneCandidate{
+				chainRange:    plugintypes.ChainRange{ChainSel: chainSel},
+				pendingMsgs:   pendingMsgs[chainSel],
+				waitingRounds: waiting[chainSel],
+			})
+		}
+		waiting = scheduleLanes(candidates, maxLanes)
+
+		for chainSel := range pendingMsgs {
+			if _, ok := waiting[chainSel]; ok {
+				roundsSinceFirst[chainSel]++
+			} else {
+				roundsSinceFirst[chainSel] = 0
+			}
+			// ceil(5/2) = 3 selections, i.e. at most 2 rounds of waiting.
+			require.LessOrEqual(t, roundsSinceFirst[chainSel], 2, "chain %d starved", chainSel)
+		}
+	}
+}
+
+func TestLanePriorityLess(t *testing.T) {
+	waiting := map[cciptypes.ChainSelector]uint64{2: 1, 3: 4, 4: 1}
+
+	chains := []cciptypes.ChainSelector{4, 3, 2, 5, 1}
+	sort.Slice(chains, func(i, j int) bool { return LanePriorityLess(waiting, chains[i], chains[j]) })
+	require.Equal(t, []cciptypes.ChainSelector{1, 5, 3, 2, 4}, chains)
+}
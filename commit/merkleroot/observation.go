@@ -1,15 +1,16 @@
 package merkleroot
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
-	"golang.org/x/sync/errgroup"
 
 	chainsel "github.com/smartcontractkit/chain-selectors"
 	"github.com/smartcontractkit/libocr/commontypes"
@@ -20,6 +21,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
 
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
@@ -34,6 +36,14 @@ import (
 	ragep2ptypes "github.com/smartcontractkit/libocr/ragep2p/types"
 )
 
+// maxCommittedReports is the maximum number of CommitReportAccepted events that are read when checking for the
+// transmission of a report.
+const maxCommittedReports = 256
+
+// MaxObservedMessageHashes is the maximum number of message hashes of an observation, it bounds the observation
+// size, see messageHashesChains.
+const MaxObservedMessageHashes = 4096
+
 var ErrSignaturesNotProvidedByLeader = errors.New("rmn signatures were not provided by the leader, " +
 	"in most cases this indicates that the RMN nodes did not include any chain in their response")
 
@@ -262,15 +272,26 @@ func (p *Processor) getObservation(
 			lggr.Debugw("fetched RMN-enabled chains from rmnHome", "rmnEnabledChains", rmnEnabledChains)
 		}
 
+		merkleRoots, msgHashes := p.observer.ObserveMerkleRoots(ctx, previousOutcome.RangesSelectedForReport)
 		return Observation{
-			MerkleRoots:      p.observer.ObserveMerkleRoots(ctx, previousOutcome.RangesSelectedForReport),
+			MerkleRoots:      merkleRoots,
+			MessageHashes:    msgHashes,
 			FChain:           p.observer.ObserveFChain(ctx),
 			RMNEnabledChains: rmnEnabledChains,
 		}, nextState, nil
 	case waitingForReportTransmission:
+		sourceChains := make([]cciptypes.ChainSelector, 0, len(previousOutcome.RootsToReport))
+		for _, root := range previousOutcome.RootsToReport {
+			sourceChains = append(sourceChains, root.ChainSel)
+		}
 		return Observation{
 			OffRampNextSeqNums: p.observer.ObserveOffRampNextSeqNums(ctx),
 			FChain:             p.observer.ObserveFChain(ctx),
+			CommittedRoots: selectCommittedRoots(
+				p.observer.ObserveCommittedMerkleRoots(
+					ctx, time.Now().Add(-p.offchainCfg.ReportTransmissionLookback), sourceChains),
+				previousOutcome.RootsToReport,
+			),
 		}, nextState, nil
 	default:
 		return Observation{},
@@ -291,9 +312,18 @@ type Observer interface {
 	// NOTE: Make sure that caller supports the destination chain.
 	ObserveLatestOnRampSeqNums(ctx context.Context) []plugintypes.SeqNumChain
 
-	// ObserveMerkleRoots computes and returns the merkle roots for the provided sequence number ranges.
+	// ObserveMerkleRoots computes and returns the merkle roots for the provided sequence number ranges, along with
+	// the hashes of the messages of the roots, up to MaxObservedMessageHashes. If only a prefix of a range is available
+	// the root of the prefix is returned.
 	// NOTE: Make sure that caller supports the provided chains.
-	ObserveMerkleRoots(ctx context.Context, ranges []plugintypes.ChainRange) []cciptypes.MerkleRootChain
+	ObserveMerkleRoots(
+		ctx context.Context, ranges []plugintypes.ChainRange) ([]cciptypes.MerkleRootChain, plugintypes.MessageHashes)
+
+	// ObserveCommittedMerkleRoots observes the merkle roots of the given source chains that were committed on the
+	// destination chain since the provided time, i.e. the roots of the CommitReportAccepted events.
+	// NOTE: Make sure that caller supports the destination chain.
+	ObserveCommittedMerkleRoots(
+		ctx context.Context, since time.Time, sourceChains []cciptypes.ChainSelector) []cciptypes.MerkleRootChain
 
 	// ObserveRMNRemoteCfg observes the RMN remote config from the configured destination chain.
 	// Check implementation specific details to learn if external calls are made, if values are cached, etc...
@@ -426,10 +456,17 @@ func (o *asyncObserver) ObserveLatestOnRampSeqNums(_ context.Context) []pluginty
 // ObserveMerkleRoots observes the merkle roots for the given sequence number ranges.
 // It directly calls the base observer since this values cannot be known in advance.
 func (o *asyncObserver) ObserveMerkleRoots(
-	ctx context.Context, ranges []plugintypes.ChainRange) []cciptypes.MerkleRootChain {
+	ctx context.Context, ranges []plugintypes.ChainRange) ([]cciptypes.MerkleRootChain, plugintypes.MessageHashes) {
 	return o.syncObserver.ObserveMerkleRoots(ctx, ranges)
 }
 
+// ObserveCommittedMerkleRoots observes the committed merkle roots by directly calling the base observer since they
+// depend on the report that is waiting for transmission.
+func (o *asyncObserver) ObserveCommittedMerkleRoots(
+	ctx context.Context, since time.Time, sourceChains []cciptypes.ChainSelector) []cciptypes.MerkleRootChain {
+	return o.syncObserver.ObserveCommittedMerkleRoots(ctx, since, sourceChains)
+}
+
 // ObserveRMNRemoteCfg observes the RMN Remote Config by directly calling the base observer since this value is cached.
 func (o *asyncObserver) ObserveRMNRemoteCfg(ctx context.Context) cciptypes.RemoteConfig {
 	return o.syncObserver.ObserveRMNRemoteCfg(ctx)
@@ -594,20 +631,25 @@ func (o observerImpl) ObserveLatestOnRampSeqNums(ctx context.Context) []pluginty
 	return latestOnRampSeqNums
 }
 
-// ObserveMerkleRoots computes the merkle roots for the given sequence number ranges
+// ObserveMerkleRoots computes the merkle roots for the given sequence number ranges.
+// If only a prefix of a range can be fetched and hashed, e.g. the source chain reader is lagging, the root of the
+// prefix is observed. The message hashes of the observed roots are returned so that the oracles can agree on a
+// prefix when they don't agree on the roots, at most MaxObservedMessageHashes of them (see messageHashesChains).
 func (o observerImpl) ObserveMerkleRoots(
 	ctx context.Context,
 	ranges []plugintypes.ChainRange,
-) []cciptypes.MerkleRootChain {
+) ([]cciptypes.MerkleRootChain, plugintypes.MessageHashes) {
 	lggr := logutil.WithContextValues(ctx, o.lggr)
 
 	supportedChains, err := o.chainSupport.SupportedChains(o.oracleID)
 	if err != nil {
 		lggr.Warnw("call to supportedChains failed", "err", err)
-		return nil
+		return nil, nil
 	}
 
 	var roots []cciptypes.MerkleRootChain
+	msgHashes := make(plugintypes.MessageHashes)
+	msgHashesChains := messageHashesChains(ranges)
 	rootsMu := &sync.Mutex{}
 	wg := sync.WaitGroup{}
 	for _, chainRange := range ranges {
@@ -621,38 +663,39 @@ func (o observerImpl) ObserveMerkleRoots(
 					return
 				}
 
-				if uint64(len(msgs)) != uint64(chainRange.SeqNumRange.End()-chainRange.SeqNumRange.Start()+1) {
-					lggr.Warnw("call to MsgsBetweenSeqNums returned unexpected number of messages, chain skipped",
-						"chain", chainRange.ChainSel,
-						"range", chainRange.SeqNumRange,
-						"expected", chainRange.SeqNumRange.End()-chainRange.SeqNumRange.Start()+1,
-						"actual", len(msgs),
-					)
+				msgs = consecutiveMessagesPrefix(chainRange.SeqNumRange.Start(), msgs)
+				if len(msgs) == 0 {
+					lggr.Warnw("call to MsgsBetweenSeqNums did not return the first message of the range, chain skipped",
+						"chain", chainRange.ChainSel, "range", chainRange.SeqNumRange)
 					return
 				}
 
-				// If the returned messages do not match the sequence numbers range
-				// there is nothing to observe for this chain since messages are missing.
-				msgIdx := 0
-				for seqNum := chainRange.SeqNumRange.Start(); seqNum <= chainRange.SeqNumRange.End(); seqNum++ {
-					msgSeqNum := msgs[msgIdx].Header.SequenceNumber
-					if msgSeqNum != seqNum {
-						lggr.Warnw("message sequence number does not match seqNum range, chain skipped",
-							"chain", chainRange.ChainSel,
-							"seqNum", seqNum,
-							"msgSeqNum", msgSeqNum,
-							"range", chainRange.SeqNumRange,
-						)
-						return
-					}
-					msgIdx++
+				hashes, err := o.hashMessages(ctx, lggr, msgs)
+				if err != nil {
+					lggr.Warnw("failed to hash all the messages", "chain", chainRange.ChainSel, "err", err)
+				}
+				if len(hashes) == 0 {
+					return
+				}
+
+				observedRange := cciptypes.NewSeqNumRange(
+					chainRange.SeqNumRange.Start(),
+					chainRange.SeqNumRange.Start()+cciptypes.SeqNum(len(hashes)-1),
+				)
+				if observedRange != chainRange.SeqNumRange {
+					lggr.Warnw("observing a prefix of the range, messages are missing or could not be hashed",
+						"chain", chainRange.ChainSel,
+						"range", chainRange.SeqNumRange,
+						"observedRange", observedRange,
+					)
 				}
 
-				root, err := o.computeMerkleRoot(ctx, lggr, msgs)
+				root, err := merkleRootFromHashes(hashes)
 				if err != nil {
-					lggr.Warnw("call to computeMerkleRoot failed", "err", err)
+					lggr.Warnw("failed to compute merkle root", "err", err)
 					return
 				}
+				lggr.Infow("Computed merkle root", "hashes", hashes, "root", root.String())
 
 				onRampAddress, err := o.ccipReader.GetContractAddress(consts.ContractNameOnRamp, chainRange.ChainSel)
 				if err != nil {
@@ -666,69 +709,214 @@ func (o observerImpl) ObserveMerkleRoots(
 
 				merkleRoot := cciptypes.MerkleRootChain{
 					ChainSel:      chainRange.ChainSel,
-					SeqNumsRange:  chainRange.SeqNumRange,
+					SeqNumsRange:  observedRange,
 					OnRampAddress: onRampAddress,
 					MerkleRoot:    root,
 				}
 
 				rootsMu.Lock()
+				defer rootsMu.Unlock()
 				roots = append(roots, merkleRoot)
-				rootsMu.Unlock()
+				if !msgHashesChains.Contains(chainRange.ChainSel) {
+					return
+				}
+				hashesBySeqNum := make(map[cciptypes.SeqNum]cciptypes.Bytes32, len(hashes))
+				for i, h := range hashes {
+					hashesBySeqNum[observedRange.Start()+cciptypes.SeqNum(i)] = h
+				}
+				msgHashes[chainRange.ChainSel] = hashesBySeqNum
 			}()
 		}
 	}
 	wg.Wait()
 
-	return roots
+	if len(roots) == 0 {
+		return nil, nil
+	}
+	if len(msgHashes) == 0 {
+		return roots, nil
+	}
+	return roots, msgHashes
+}
+
+// messageHashesChains returns the chains whose message hashes are observed along with their roots. The ranges are
+// taken in ascending chain selector order while their total length fits MaxObservedMessageHashes, the ranges are
+// the selected ranges of the previous outcome so that all the oracles observe the hashes of the same chains.
+func messageHashesChains(ranges []plugintypes.ChainRange) mapset.Set[cciptypes.ChainSelector] {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b plugintypes.ChainRange) int {
+		return cmp.Compare(a.ChainSel, b.ChainSel)
+	})
+
+	chains := mapset.NewSet[cciptypes.ChainSelector]()
+	total := 0
+	for _, r := range sorted {
+		total += r.SeqNumRange.Length()
+		if total > MaxObservedMessageHashes {
+			break
+		}
+		chains.Add(r.ChainSel)
+	}
+	return chains
+}
+
+// consecutiveMessagesPrefix returns the largest prefix of msgs with consecutive sequence numbers starting at start.
+// Messages should be sorted by sequence number.
+func consecutiveMessagesPrefix(start cciptypes.SeqNum, msgs []cciptypes.Message) []cciptypes.Message {
+	for i, msg := range msgs {
+		if msg.Header.SequenceNumber != start+cciptypes.SeqNum(i) {
+			return msgs[:i]
+		}
+	}
+	return msgs
 }
 
-// computeMerkleRoot computes the merkle root of a list of messages.
+// hashMessages hashes a list of messages.
 // Messages should be sorted by sequence number and not have any gaps.
-func (o observerImpl) computeMerkleRoot(
+// If a message cannot be hashed the error is returned along with the hashes of the messages that precede it.
+func (o observerImpl) hashMessages(
 	ctx context.Context,
 	lggr logger.Logger,
 	msgs []cciptypes.Message,
-) (cciptypes.Bytes32, error) {
-	hashes := make([][32]byte, len(msgs))
-	hashesStr := make([]string, len(hashes)) // also keep hashes as strings for logging purposes
+) ([]cciptypes.Bytes32, error) {
+	for i := 1; i < len(msgs); i++ {
+		// Assert there are no sequence number gaps in msgs
+		if msgs[i].Header.SequenceNumber != msgs[i-1].Header.SequenceNumber+1 {
+			return nil, fmt.Errorf("found non-consecutive sequence numbers when hashing messages, "+
+				"gap between sequence nums %d and %d, messages: %v", msgs[i-1].Header.SequenceNumber,
+				msgs[i].Header.SequenceNumber, msgs)
+		}
+	}
 
-	eg := &errgroup.Group{}
-	for i, msg := range msgs {
-		eg.Go(func() error {
-			// Assert there are no sequence number gaps in msgs
-			if i > 0 {
-				if msg.Header.SequenceNumber != msgs[i-1].Header.SequenceNumber+1 {
-					return fmt.Errorf("found non-consecutive sequence numbers when computing merkle root, "+
-						"gap between sequence nums %d and %d, messages: %v", msgs[i-1].Header.SequenceNumber,
-						msg.Header.SequenceNumber, msgs)
-				}
-			}
+	hashes := make([]cciptypes.Bytes32, len(msgs))
+	errs := make([]error, len(msgs))
 
+	wg := sync.WaitGroup{}
+	for i, msg := range msgs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 			msgHash, err := o.msgHasher.Hash(ctx, msg)
 			if err != nil {
 				lggr.Warnw("failed to hash message", "message", msg, "err", err)
-				return fmt.Errorf("hash message with id %s: %w", msg.Header.MessageID, err)
+				errs[i] = fmt.Errorf("hash message with id %s: %w", msg.Header.MessageID, err)
+				return
 			}
-
 			hashes[i] = msgHash
-			hashesStr[i] = msgHash.String()
-			return nil
-		})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return hashes[:i], err
+		}
 	}
+	return hashes, nil
+}
 
-	if err := eg.Wait(); err != nil {
-		return [32]byte{}, err
+// merkleRootFromHashes computes the merkle root of a list of message hashes.
+func merkleRootFromHashes(hashes []cciptypes.Bytes32) (cciptypes.Bytes32, error) {
+	leaves := make([][32]byte, len(hashes))
+	for i, h := range hashes {
+		leaves[i] = h
 	}
 
 	// TODO: Do not hard code the hash function, it should be derived from the message hasher
-	tree, err := merklemulti.NewTree(hashutil.NewKeccak(), hashes)
+	tree, err := merklemulti.NewTree(hashutil.NewKeccak(), leaves)
+	if err != nil {
+		return cciptypes.Bytes32{}, fmt.Errorf("failed to construct merkle tree from %d leaves: %w", len(leaves), err)
+	}
+	return tree.Root(), nil
+}
+
+// ObserveCommittedMerkleRoots reads the CommitReportAccepted events of the destination chain since the provided
+// time and returns their merkle roots that belong to the given source chains.
+// NOTE: An external call is made.
+func (o observerImpl) ObserveCommittedMerkleRoots(
+	ctx context.Context, since time.Time, sourceChains []cciptypes.ChainSelector) []cciptypes.MerkleRootChain {
+	lggr := logutil.WithContextValues(ctx, o.lggr)
+
+	if len(sourceChains) == 0 {
+		return nil
+	}
+
+	supportsDestChain, err := o.chainSupport.SupportsDestChain(o.oracleID)
+	if err != nil {
+		lggr.Warnw("call to SupportsDestChain failed", "err", err)
+		return nil
+	}
+
+	if !supportsDestChain {
+		lggr.Debugw("cannot observe committed merkle roots since destination chain is not supported")
+		return nil
+	}
+
+	reports, err := o.ccipReader.CommitReportsGTETimestamp(ctx, since, primitives.Unconfirmed, maxCommittedReports)
 	if err != nil {
-		return [32]byte{}, fmt.Errorf("failed to construct merkle tree from %d leaves: %w", len(hashes), err)
+		lggr.Warnw("call to CommitReportsGTETimestamp failed", "since", since, "err", err)
+		return nil
+	}
+
+	chains := mapset.NewSet(sourceChains...)
+	committedRoots := make([]cciptypes.MerkleRootChain, 0)
+	for _, report := range reports {
+		for _, root := range slices.Concat(report.Report.BlessedMerkleRoots, report.Report.UnblessedMerkleRoots) {
+			if chains.Contains(root.ChainSel) {
+				committedRoots = append(committedRoots, root)
+			}
+		}
 	}
 
-	root := tree.Root()
-	lggr.Infow("Computed merkle root", "hashes", hashesStr, "root", cciptypes.Bytes32(root).String())
-	return root, nil
+	lggr.Debugw("observed committed merkle roots", "since", since, "committedRoots", committedRoots)
+	return committedRoots
+}
+
+// selectCommittedRoots keeps at most one committed root per root to report, which is all that is needed to check
+// the transmission of the report (see findCommittedRoot): the root to report itself when it was committed, otherwise
+// the committed root of its chain with an overlapping range and the lowest range start. This bounds the committed
+// roots of an observation by the number of source chains of the report, whatever the number of committed reports.
+func selectCommittedRoots(
+	committedRoots []cciptypes.MerkleRootChain,
+	rootsToReport []cciptypes.MerkleRootChain,
+) []cciptypes.MerkleRootChain {
+	if len(committedRoots) == 0 {
+		return committedRoots
+	}
+
+	selected := make([]cciptypes.MerkleRootChain, 0, len(rootsToReport))
+	seenChains := mapset.NewSet[cciptypes.ChainSelector]()
+	for _, root := range rootsToReport {
+		if seenChains.Contains(root.ChainSel) {
+			continue
+		}
+
+		var competing *cciptypes.MerkleRootChain
+		committed := false
+		for i, committedRoot := range committedRoots {
+			if committedRoot.ChainSel != root.ChainSel {
+				continue
+			}
+			if committedRoot.MerkleRoot == root.MerkleRoot && committedRoot.SeqNumsRange == root.SeqNumsRange {
+				selected = append(selected, committedRoot)
+				committed = true
+				break
+			}
+			if committedRoot.SeqNumsRange.Overlaps(root.SeqNumsRange) && (competing == nil ||
+				committedRoot.SeqNumsRange.Start() < competing.SeqNumsRange.Start() ||
+				(committedRoot.SeqNumsRange.Start() == competing.SeqNumsRange.Start() &&
+					committedRoot.MerkleRoot.String() < competing.MerkleRoot.String())) {
+				competing = &committedRoots[i]
+			}
+		}
+		if !committed && competing != nil {
+			selected = append(selected, *competing)
+		}
+		if committed || competing != nil {
+			seenChains.Add(root.ChainSel)
+		}
+	}
+	return selected
 }
 
 // ObserveRMNRemoteCfg observes the RMN remote config for the given destination chain.
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,7 @@ import (
 	ragep2ptypes "github.com/smartcontractkit/libocr/ragep2p/types"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
@@ -62,6 +64,7 @@ func TestObservation(t *testing.T) {
 	}
 
 	thirtyTwoBytes := [32]byte{1, 2, 3}
+	msgHashes := plugintypes.MessageHashes{1: {5: {5}, 6: {6}, 7: {7}, 8: {8}, 9: {9}, 10: {10}}}
 	ctx := context.Background()
 
 	testCases := []struct {
@@ -113,7 +116,7 @@ func TestObservation(t *testing.T) {
 						ChainSel:     1,
 						SeqNumsRange: [2]cciptypes.SeqNum{5, 10},
 						MerkleRoot:   [32]byte{1},
-					}})
+					}}, msgHashes)
 				mockObserver.EXPECT().ObserveFChain(mock.Anything).Return(map[cciptypes.ChainSelector]int{1: 3})
 				mockCCIPReader.EXPECT().GetContractAddress(mock.Anything, mock.Anything).Return(offchainAddress, nil)
 			},
@@ -124,6 +127,7 @@ func TestObservation(t *testing.T) {
 						SeqNumsRange: [2]cciptypes.SeqNum{5, 10},
 						MerkleRoot:   [32]byte{1}},
 				},
+				MessageHashes:    msgHashes,
 				RMNEnabledChains: map[cciptypes.ChainSelector]bool{1: true},
 				FChain:           map[cciptypes.ChainSelector]int{1: 3},
 			},
@@ -131,18 +135,26 @@ func TestObservation(t *testing.T) {
 		{
 			name: "WaitingForReportTransmission",
 			prevOutcome: Outcome{
-				OutcomeType:  ReportInFlight,
-				RMNRemoteCfg: testhelpers.CreateRMNRemoteCfg(),
+				OutcomeType:   ReportInFlight,
+				RMNRemoteCfg:  testhelpers.CreateRMNRemoteCfg(),
+				RootsToReport: []cciptypes.MerkleRootChain{{ChainSel: 1, MerkleRoot: cciptypes.Bytes32{1}}},
 			},
 			query: Query{},
 			setupMocks: func() {
 				mockObserver.EXPECT().ObserveOffRampNextSeqNums(mock.Anything).Return(
 					[]plugintypes.SeqNumChain{{ChainSel: 1, SeqNum: 20}}).Once()
 				mockObserver.EXPECT().ObserveFChain(mock.Anything).Return(map[cciptypes.ChainSelector]int{1: 3})
+				mockObserver.EXPECT().ObserveCommittedMerkleRoots(
+					mock.Anything, mock.Anything, []cciptypes.ChainSelector{1}).Return(
+					[]cciptypes.MerkleRootChain{
+						{ChainSel: 1, MerkleRoot: cciptypes.Bytes32{2}},
+						{ChainSel: 1, MerkleRoot: cciptypes.Bytes32{1}},
+					}).Once()
 			},
 			expectedObs: Observation{
 				OffRampNextSeqNums: []plugintypes.SeqNumChain{{ChainSel: 1, SeqNum: 20}},
 				FChain:             map[cciptypes.ChainSelector]int{1: 3},
+				CommittedRoots:     []cciptypes.MerkleRootChain{{ChainSel: 1, MerkleRoot: cciptypes.Bytes32{1}}},
 			},
 		},
 		{
@@ -381,6 +393,158 @@ func Test_ObserveOffRampNextSeqNums(t *testing.T) {
 	}
 }
 
+func Test_selectCommittedRoots(t *testing.T) {
+	root := func(chainSel cciptypes.ChainSelector, start, end cciptypes.SeqNum, b byte) cciptypes.MerkleRootChain {
+		return cciptypes.MerkleRootChain{
+			ChainSel:     chainSel,
+			SeqNumsRange: cciptypes.NewSeqNumRange(start, end),
+			MerkleRoot:   cciptypes.Bytes32{b},
+		}
+	}
+	rootsToReport := []cciptypes.MerkleRootChain{root(1, 10, 20, 1), root(2, 10, 20, 2), root(3, 10, 20, 3)}
+
+	testCases := []struct {
+		name           string
+		committedRoots []cciptypes.MerkleRootChain
+		expected       []cciptypes.MerkleRootChain
+	}{
+		{
+			name: "no committed roots",
+		},
+		{
+			name: "the reported root is kept over competing roots",
+			committedRoots: []cciptypes.MerkleRootChain{
+				root(1, 5, 12, 4), root(1, 10, 20, 1), root(1, 1, 4, 5),
+			},
+			expected: []cciptypes.MerkleRootChain{root(1, 10, 20, 1)},
+		},
+		{
+			name: "the competing root with the lowest start is kept",
+			committedRoots: []cciptypes.MerkleRootChain{
+				root(2, 15, 25, 6), root(2, 5, 12, 7), root(2, 21, 30, 8),
+			},
+			expected: []cciptypes.MerkleRootChain{root(2, 5, 12, 7)},
+		},
+		{
+			name: "at most one root per reported chain",
+			committedRoots: []cciptypes.MerkleRootChain{
+				root(3, 10, 20, 3), root(1, 10, 20, 1), root(4, 10, 20, 9), root(3, 10, 20, 3),
+			},
+			expected: []cciptypes.MerkleRootChain{root(1, 10, 20, 1), root(3, 10, 20, 3)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, selectCommittedRoots(tc.committedRoots, rootsToReport))
+		})
+	}
+}
+
+func Test_ObserveCommittedMerkleRoots(t *testing.T) {
+	const nodeID commontypes.OracleID = 1
+	since := time.Unix(1000, 0)
+	root := func(chainSel cciptypes.ChainSelector, b byte) cciptypes.MerkleRootChain {
+		return cciptypes.MerkleRootChain{
+			ChainSel:     chainSel,
+			SeqNumsRange: cciptypes.NewSeqNumRange(1, 2),
+			MerkleRoot:   cciptypes.Bytes32{b},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		sourceChains []cciptypes.ChainSelector
+		expResult    []cciptypes.MerkleRootChain
+		getDeps      func(t *testing.T) (*common_mock.MockChainSupport, *reader_mock.MockCCIPReader)
+	}{
+		{
+			name:         "Happy path",
+			sourceChains: []cciptypes.ChainSelector{4, 7},
+			getDeps: func(t *testing.T) (*common_mock.MockChainSupport, *reader_mock.MockCCIPReader) {
+				chainSupport := common_mock.NewMockChainSupport(t)
+				chainSupport.EXPECT().SupportsDestChain(nodeID).Return(true, nil)
+				ccipReader := reader_mock.NewMockCCIPReader(t)
+				ccipReader.EXPECT().CommitReportsGTETimestamp(mock.Anything, since, primitives.Unconfirmed,
+					maxCommittedReports).Return([]cciptypes.CommitPluginReportWithMeta{
+					{Report: cciptypes.CommitPluginReport{
+						BlessedMerkleRoots:   []cciptypes.MerkleRootChain{root(4, 1)},
+						UnblessedMerkleRoots: []cciptypes.MerkleRootChain{root(5, 2), root(7, 3)},
+					}},
+					{Report: cciptypes.CommitPluginReport{
+						UnblessedMerkleRoots: []cciptypes.MerkleRootChain{root(4, 4)},
+					}},
+				}, nil)
+				return chainSupport, ccipReader
+			},
+			expResult: []cciptypes.MerkleRootChain{root(4, 1), root(7, 3), root(4, 4)},
+		},
+		{
+			name:         "nil is returned when there are no source chains",
+			sourceChains: nil,
+			getDeps: func(t *testing.T) (*common_mock.MockChainSupport, *reader_mock.MockCCIPReader) {
+				return common_mock.NewMockChainSupport(t), reader_mock.NewMockCCIPReader(t)
+			},
+			expResult: nil,
+		},
+		{
+			name:         "nil is returned when supportsDestChain is false",
+			sourceChains: []cciptypes.ChainSelector{4},
+			getDeps: func(t *testing.T) (*common_mock.MockChainSupport, *reader_mock.MockCCIPReader) {
+				chainSupport := common_mock.NewMockChainSupport(t)
+				chainSupport.EXPECT().SupportsDestChain(nodeID).Return(false, nil)
+				return chainSupport, reader_mock.NewMockCCIPReader(t)
+			},
+			expResult: nil,
+		},
+		{
+			name:         "nil is returned when reading commit reports fails",
+			sourceChains: []cciptypes.ChainSelector{4},
+			getDeps: func(t *testing.T) (*common_mock.MockChainSupport, *reader_mock.MockCCIPReader) {
+				chainSupport := common_mock.NewMockChainSupport(t)
+				chainSupport.EXPECT().SupportsDestChain(nodeID).Return(true, nil)
+				ccipReader := reader_mock.NewMockCCIPReader(t)
+				ccipReader.EXPECT().CommitReportsGTETimestamp(mock.Anything, since, primitives.Unconfirmed,
+					maxCommittedReports).Return(nil, errors.New("some error"))
+				return chainSupport, ccipReader
+			},
+			expResult: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := tests.Context(t)
+
+			chainSupport, ccipReader := tc.getDeps(t)
+			o := newObserverImpl(
+				logger.Test(t),
+				nil,
+				nodeID,
+				chainSupport,
+				ccipReader,
+				mocks.NewMessageHasher(),
+			)
+
+			assert.Equal(t, tc.expResult, o.ObserveCommittedMerkleRoots(ctx, since, tc.sourceChains))
+		})
+	}
+}
+
+func Test_messageHashesChains(t *testing.T) {
+	ranges := []plugintypes.ChainRange{
+		{ChainSel: 3, SeqNumRange: cciptypes.NewSeqNumRange(1, 10)},
+		{ChainSel: 1, SeqNumRange: cciptypes.NewSeqNumRange(1, MaxObservedMessageHashes-10)},
+		{ChainSel: 2, SeqNumRange: cciptypes.NewSeqNumRange(1, 10)},
+		{ChainSel: 4, SeqNumRange: cciptypes.NewSeqNumRange(1, 1)},
+	}
+	// chain 3 doesn't fit and the chains of the next ranges are skipped as well
+	assert.Equal(t, mapset.NewSet[cciptypes.ChainSelector](1, 2), messageHashesChains(ranges))
+
+	assert.Equal(t, mapset.NewSet[cciptypes.ChainSelector](2, 3, 4), messageHashesChains(
+		[]plugintypes.ChainRange{ranges[0], ranges[2], ranges[3]}))
+	assert.Empty(t, messageHashesChains(nil).ToSlice())
+}
+
 func Test_ObserveMerkleRoots(t *testing.T) {
 	testCases := []struct {
 		name                     string
@@ -553,7 +717,7 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 			},
 		},
 		{
-			name: "multiple chains, some of them have missing messages within the range",
+			name: "multiple chains, some of them have missing messages within the range, prefixes are observed",
 			ranges: []plugintypes.ChainRange{
 				{ChainSel: 8, SeqNumRange: cciptypes.SeqNumRange{10, 11}},
 				{ChainSel: 15, SeqNumRange: cciptypes.SeqNumRange{53, 55}},
@@ -568,13 +732,13 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 				// 8: valid messages
 				8: {{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x1a"), SequenceNumber: 10}}, {
 					Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x1b"), SequenceNumber: 11}}},
-				// 15: missing middle message of the range
+				// 15: missing middle message of the range, the prefix [53, 53] is observed
 				15: {{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x2a"), SequenceNumber: 53}}, {
 					Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x2c"), SequenceNumber: 55}}},
 				// 16: missing first message of the range
 				16: {{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x3a"), SequenceNumber: 64}}, {
 					Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x3c"), SequenceNumber: 65}}},
-				// 17: missing last message of the range
+				// 17: missing last message of the range, the prefix [73, 74] is observed
 				17: {{Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x4a"), SequenceNumber: 73}}, {
 					Header: cciptypes.RampMessageHeader{MessageID: mustNewMessageID("0x4c"), SequenceNumber: 74}}},
 				// 18: length of msgs is correct but sequence numbers are not
@@ -589,8 +753,10 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 			},
 			msgsBetweenSeqNumsErrors: map[cciptypes.ChainSelector]error{},
 			expMerkleRoots: map[cciptypes.ChainSelector]string{
-				8: "5b81aaf37240df67f3ab0e845f30e29f35fdf9169e2517c436c1c0c11224c97b",
-				9: "f1b02d28559f60a67b431e2c580ac1d6b3e0fd7319ff055c6c67408aa31788e4",
+				8:  "5b81aaf37240df67f3ab0e845f30e29f35fdf9169e2517c436c1c0c11224c97b",
+				9:  "f1b02d28559f60a67b431e2c580ac1d6b3e0fd7319ff055c6c67408aa31788e4",
+				15: "2a00000000000000000000000000000000000000000000000000000000000000",
+				17: "a8717a5e38ca44e3d3de7a13ba9da151eadab27aa6a294c7ed1cdd5c0be3a860",
 			},
 		},
 	}
@@ -637,20 +803,29 @@ func Test_ObserveMerkleRoots(t *testing.T) {
 				mocks.NewMessageHasher(),
 			)
 
-			roots := o.ObserveMerkleRoots(ctx, tc.ranges)
+			roots, msgHashes := o.ObserveMerkleRoots(ctx, tc.ranges)
 			if tc.expMerkleRoots == nil {
 				assert.Nil(t, roots)
+				assert.Nil(t, msgHashes)
 			} else {
 				assert.Len(t, roots, len(tc.expMerkleRoots))
+				assert.Len(t, msgHashes, len(tc.expMerkleRoots))
 				for _, root := range roots {
 					assert.Equal(t, tc.expMerkleRoots[root.ChainSel], hex.EncodeToString(root.MerkleRoot[:]))
+
+					// the hashes of the messages of the observed range are observed along with the root
+					hashes := msgHashes[root.ChainSel]
+					require.Len(t, hashes, root.SeqNumsRange.Length())
+					for _, seqNum := range root.SeqNumsRange.ToSlice() {
+						require.Contains(t, hashes, seqNum)
+					}
 				}
 			}
 		})
 	}
 }
 
-func Test_computeMerkleRoot(t *testing.T) {
+func Test_hashMessages_merkleRootFromHashes(t *testing.T) {
 	testCases := []struct {
 		name           string
 		messageHeaders []cciptypes.RampMessageHeader
@@ -743,7 +918,11 @@ func Test_computeMerkleRoot(t *testing.T) {
 				msgs = append(msgs, cciptypes.Message{Header: h})
 			}
 
-			rootBytes, err := p.computeMerkleRoot(context.Background(), p.lggr, msgs)
+			hashes, err := p.hashMessages(context.Background(), p.lggr, msgs)
+			var rootBytes cciptypes.Bytes32
+			if err == nil {
+				rootBytes, err = merkleRootFromHashes(hashes)
+			}
 
 			if tc.expErr {
 				assert.Error(t, err)
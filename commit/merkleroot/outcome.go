@@ -65,7 +65,15 @@ func (p *Processor) getOutcome(
 
 	switch nextState {
 	case selectingRangesForReport:
-		return reportRangesOutcome(q, lggr, consObservation, p.offchainCfg.MaxMerkleTreeSize, p.destChain),
+		return reportRangesOutcome(
+				q,
+				lggr,
+				consObservation,
+				previousOutcome,
+				p.offchainCfg.MaxMerkleTreeSize,
+				p.offchainCfg.MaxMerkleRootsPerReport,
+				p.destChain,
+			),
 			nextState,
 			nil
 	case buildingReport:
@@ -94,15 +102,20 @@ func (p *Processor) getOutcome(
 	}
 }
 
-// reportRangesOutcome determines the sequence number ranges for each chain to build a report from in the next round
+// reportRangesOutcome determines the sequence number ranges for each chain to build a report from in the next round.
+// If more chains than maxMerkleRootsPerReport have pending messages, the chains that go in the first report are
+// selected by scheduleLanes.
 func reportRangesOutcome(
 	_ Query,
 	lggr logger.Logger,
 	consObservation consensusObservation,
+	prevOutcome Outcome,
 	maxMerkleTreeSize uint64,
+	maxMerkleRootsPerReport uint64,
 	dstChain cciptypes.ChainSelector,
 ) Outcome {
 	rangesToReport := make([]plugintypes.ChainRange, 0)
+	candidates := make([]laneCandidate, 0)
 
 	observedOnRampMaxSeqNumsMap := consObservation.OnRampMaxSeqNums
 	observedOffRampNextSeqNumsMap := consObservation.OffRampNextSeqNums
@@ -136,6 +149,11 @@ func reportRangesOutcome(
 				SeqNumRange: rng.Limit(maxMerkleTreeSize),
 			}
 			rangesToReport = append(rangesToReport, chainRange)
+			candidates = append(candidates, laneCandidate{
+				chainRange:    chainRange,
+				pendingMsgs:   uint64(rng.Length()),
+				waitingRounds: prevOutcome.LaneWaitingRounds[chainSel],
+			})
 
 			if rng.End() != chainRange.SeqNumRange.End() { // Check if the range was truncated.
 				lggr.Debugf("Range for chain %d: %s (before truncate: %v)", chainSel, chainRange.SeqNumRange, rng)
@@ -145,6 +163,12 @@ func reportRangesOutcome(
 		}
 	}
 
+	laneWaitingRounds := scheduleLanes(candidates, maxMerkleRootsPerReport)
+	if len(laneWaitingRounds) > 0 {
+		lggr.Infow("more source chains with pending messages than maxMerkleRootsPerReport, scheduled lanes",
+			"maxMerkleRootsPerReport", maxMerkleRootsPerReport, "laneWaitingRounds", laneWaitingRounds)
+	}
+
 	// deterministic outcome
 	sort.Slice(rangesToReport, func(i, j int) bool { return rangesToReport[i].ChainSel < rangesToReport[j].ChainSel })
 	sort.Slice(offRampNextSeqNums, func(i, j int) bool {
@@ -168,6 +192,7 @@ func reportRangesOutcome(
 		RangesSelectedForReport: rangesToReport,
 		OffRampNextSeqNums:      offRampNextSeqNums,
 		RMNRemoteCfg:            rmnRemoteConfig,
+		LaneWaitingRounds:       laneWaitingRounds,
 	}
 
 	return outcome
@@ -218,6 +243,7 @@ func buildMerkleRootsOutcome(
 		OffRampNextSeqNums:  prevOutcome.OffRampNextSeqNums,
 		RMNReportSignatures: sigs,
 		RMNRemoteCfg:        prevOutcome.RMNRemoteCfg,
+		LaneWaitingRounds:   prevOutcome.LaneWaitingRounds,
 	}
 
 	return outcome, nil
@@ -357,6 +383,9 @@ type rootKey struct {
 // ReportTransmissionFailed to signify we stop checking for updates and start a new report generation phase. If no
 // update is detected, and we haven't exhausted our check attempts, output ReportInFlight to signify that we should
 // check again next round.
+// The committed merkle roots of the destination chain are checked as well, so that the transmission of a root, or a
+// competing root that commits an overlapping range, is detected without waiting for the seq nums to be updated.
+// When a competing root is committed the report can no longer be transmitted and ReportTransmissionFailed is output.
 func checkForReportTransmission(
 	lggr logger.Logger,
 	maxReportTransmissionCheckAttempts uint,
@@ -371,13 +400,46 @@ func checkForReportTransmission(
 		pendingSources[root.ChainSel] = struct{}{}
 	}
 
+	rootCommitted, competingRootCommitted := false, false
+	for _, root := range previousOutcome.RootsToReport {
+		committed, competing := findCommittedRoot(root, consensusObservation.CommittedRoots[root.ChainSel])
+		switch {
+		case committed:
+			lggr.Debugw("merkle root of the report committed", "root", root)
+			rootCommitted = true
+		case competing != nil:
+			lggr.Warnw("competing merkle root committed, report cannot be transmitted",
+				"root", root, "competingRoot", *competing)
+			competingRootCommitted = true
+		default:
+			continue
+		}
+		delete(pendingSources, root.ChainSel)
+	}
+
+	// if there is only one report, a competing root means that it cannot be transmitted anymore
+	// and any committed root means that it has been transmitted.
+	if !multipleReports && competingRootCommitted {
+		return Outcome{
+			OutcomeType:       ReportTransmissionFailed,
+			LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
+		}
+	}
+	if !multipleReports && rootCommitted {
+		return Outcome{
+			OutcomeType:       ReportTransmitted,
+			LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
+		}
+	}
+
 	for _, previousSeqNumChain := range previousOutcome.OffRampNextSeqNums {
 		if currentSeqNum, exists := consensusObservation.OffRampNextSeqNums[previousSeqNumChain.ChainSel]; exists {
 			if previousSeqNumChain.SeqNum < currentSeqNum {
 				// if there is only one report, any single update means the report has been transmitted.
 				if !multipleReports {
 					return Outcome{
-						OutcomeType: ReportTransmitted,
+						OutcomeType:       ReportTransmitted,
+						LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
 					}
 				}
 
@@ -397,15 +459,23 @@ func checkForReportTransmission(
 
 	// All pending sources have been updated, we can move to the next state.
 	if len(pendingSources) == 0 {
+		if competingRootCommitted {
+			return Outcome{
+				OutcomeType:       ReportTransmissionFailed,
+				LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
+			}
+		}
 		return Outcome{
-			OutcomeType: ReportTransmitted,
+			OutcomeType:       ReportTransmitted,
+			LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
 		}
 	}
 
 	if previousOutcome.ReportTransmissionCheckAttempts+1 >= maxReportTransmissionCheckAttempts {
 		lggr.Warnw("report not transmitted, max check attempts reached, moving to next state")
 		return Outcome{
-			OutcomeType: ReportTransmissionFailed,
+			OutcomeType:       ReportTransmissionFailed,
+			LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
 		}
 	}
 
@@ -415,8 +485,28 @@ func checkForReportTransmission(
 		ReportTransmissionCheckAttempts: previousOutcome.ReportTransmissionCheckAttempts + 1,
 		// Carry over the previous roots since they're still in-flight.
 		// We won't re-report since outcome type is ReportInFlight.
-		RootsToReport: previousOutcome.RootsToReport,
+		RootsToReport:     previousOutcome.RootsToReport,
+		LaneWaitingRounds: previousOutcome.LaneWaitingRounds,
+	}
+}
+
+// findCommittedRoot checks whether the given root is among the committed roots of its chain. If it is not, a
+// committed root of the chain with an overlapping range is returned, since the range of the given root can no longer
+// be committed.
+func findCommittedRoot(
+	root cciptypes.MerkleRootChain,
+	committedRoots []cciptypes.MerkleRootChain,
+) (bool, *cciptypes.MerkleRootChain) {
+	var competing *cciptypes.MerkleRootChain
+	for i, committedRoot := range committedRoots {
+		if committedRoot.MerkleRoot == root.MerkleRoot && committedRoot.SeqNumsRange == root.SeqNumsRange {
+			return true, nil
+		}
+		if competing == nil && committedRoot.SeqNumsRange.Overlaps(root.SeqNumsRange) {
+			competing = &committedRoots[i]
+		}
 	}
+	return false, competing
 }
 
 // getConsensusObservation Combine the list of observations into a single consensus observation
@@ -464,11 +554,136 @@ func getConsensusObservation(
 		OffRampNextSeqNums: getOffRampNextSequenceNumbersConsensus(lggr, uint(fDestChain), aggObs.OffRampNextSeqNums),
 		RMNRemoteConfig:    consensus.GetConsensusMap(lggr, "RMNRemote cfg", rmnRemoteConfigs, twoFChainPlus1),
 		FChain:             fChains,
+		CommittedRoots:     getCommittedRootsConsensus(lggr, uint(fDestChain), aggObs.CommittedRoots),
+	}
+
+	for chain, root := range getPrefixMerkleRootsConsensus(
+		lggr, consensusObs.MerkleRoots, consensusObs.RMNEnabledChains, aggObs.MessageHashes, twoFChainPlus1) {
+		consensusObs.MerkleRoots[chain] = root
 	}
 
 	return consensusObs, nil
 }
 
+// getPrefixMerkleRootsConsensus computes a merkle root for the chains that do not have a consensus merkle root,
+// e.g. because the source chain readers of some oracles are lagging and could only observe a prefix of the range.
+// The root is computed from the message hashes of the largest prefix of the range that at least 2fChain+1 oracles
+// observed the same hashes for.
+//
+// RMN-enabled chains are skipped, the RMN nodes sign the root of the selected range and the root of a prefix would
+// invalidate the RMN signatures of all the roots of the report (see filterRootsBasedOnRmnSigs).
+func getPrefixMerkleRootsConsensus(
+	lggr logger.Logger,
+	consensusRoots map[cciptypes.ChainSelector]cciptypes.MerkleRootChain,
+	rmnEnabledChains map[cciptypes.ChainSelector]bool,
+	observedHashes map[cciptypes.ChainSelector][]observedMessageHashes,
+	twoFChainPlus1 consensus.MultiThreshold[cciptypes.ChainSelector],
+) map[cciptypes.ChainSelector]cciptypes.MerkleRootChain {
+	prefixRoots := make(map[cciptypes.ChainSelector]cciptypes.MerkleRootChain)
+	for chain, observations := range observedHashes {
+		if _, exists := consensusRoots[chain]; exists || rmnEnabledChains[chain] {
+			continue
+		}
+
+		threshold, ok := twoFChainPlus1.Get(chain)
+		if !ok || uint(len(observations)) < uint(threshold) {
+			continue
+		}
+
+		root, ok := prefixMerkleRoot(observations, threshold)
+		if !ok {
+			lggr.Debugw("no consensus on a prefix of the range of the chain",
+				"chain", chain, "observations", len(observations), "threshold", threshold)
+			continue
+		}
+
+		lggr.Infow("no consensus on the merkle root of the chain, using the root of the agreed prefix of the range",
+			"chain", chain, "root", root)
+		prefixRoots[chain] = root
+	}
+	return prefixRoots
+}
+
+// prefixMerkleRoot returns the merkle root of the largest prefix of the observed range that at least threshold
+// observations agree on, starting with the first sequence number of the observed roots.
+func prefixMerkleRoot(
+	observations []observedMessageHashes,
+	threshold consensus.Threshold,
+) (cciptypes.MerkleRootChain, bool) {
+	agreeing := majorityGroup(observations, threshold, func(o observedMessageHashes) (string, bool) {
+		return fmt.Sprintf("%d", o.Root.SeqNumsRange.Start()), true
+	})
+	if len(agreeing) == 0 {
+		return cciptypes.MerkleRootChain{}, false
+	}
+	start := agreeing[0].Root.SeqNumsRange.Start()
+
+	var hashes []cciptypes.Bytes32
+	for seqNum := start; ; seqNum++ {
+		group := majorityGroup(agreeing, threshold, func(o observedMessageHashes) (string, bool) {
+			h, ok := o.Hashes[seqNum]
+			return h.String(), ok
+		})
+		if len(group) == 0 {
+			break
+		}
+		agreeing = group
+		hashes = append(hashes, group[0].Hashes[seqNum])
+	}
+	if len(hashes) == 0 {
+		return cciptypes.MerkleRootChain{}, false
+	}
+
+	// the onRamp address is observed along with the roots
+	agreeing = majorityGroup(agreeing, threshold, func(o observedMessageHashes) (string, bool) {
+		return o.Root.OnRampAddress.String(), true
+	})
+	if len(agreeing) == 0 {
+		return cciptypes.MerkleRootChain{}, false
+	}
+
+	merkleRoot, err := merkleRootFromHashes(hashes)
+	if err != nil {
+		return cciptypes.MerkleRootChain{}, false
+	}
+
+	return cciptypes.MerkleRootChain{
+		ChainSel:      agreeing[0].Root.ChainSel,
+		OnRampAddress: agreeing[0].Root.OnRampAddress,
+		SeqNumsRange:  cciptypes.NewSeqNumRange(start, start+cciptypes.SeqNum(len(hashes)-1)),
+		MerkleRoot:    merkleRoot,
+	}, true
+}
+
+// majorityGroup groups the observations by key and returns the largest group if it has at least threshold
+// observations, ties are broken by the smallest key. Observations without a key are ignored.
+func majorityGroup(
+	observations []observedMessageHashes,
+	threshold consensus.Threshold,
+	key func(observedMessageHashes) (string, bool),
+) []observedMessageHashes {
+	groups := make(map[string][]observedMessageHashes)
+	for _, o := range observations {
+		k, ok := key(o)
+		if !ok {
+			continue
+		}
+		groups[k] = append(groups[k], o)
+	}
+
+	var bestKey string
+	var best []observedMessageHashes
+	for k, group := range groups {
+		if len(group) > len(best) || (len(group) == len(best) && k < bestKey) {
+			bestKey, best = k, group
+		}
+	}
+	if uint(len(best)) < uint(threshold) {
+		return nil
+	}
+	return best
+}
+
 // getOffRampNextSequenceNumbersConsensus accepts a list of offramp sequence number observations per chain
 // and computes the consensus value for each chain.
 //
@@ -498,3 +713,47 @@ func getOffRampNextSequenceNumbersConsensus(
 		"offRampNextSeqNumsConsensus", offRampNextSeqNumsConsensus, "observations", observationsPerChain)
 	return offRampNextSeqNumsConsensus
 }
+
+// getCommittedRootsConsensus returns the committed merkle roots of each chain that at least fDestChain+1 oracles
+// observed, i.e. at least one honest oracle observed the CommitReportAccepted event of the root.
+func getCommittedRootsConsensus(
+	lggr logger.Logger,
+	fDestChain uint,
+	observationsPerChain map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain,
+) map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain {
+	committedRootsConsensus := make(map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain)
+	for sourceChain, observedRoots := range observationsPerChain {
+		counts := make(map[rootKey]uint)
+		roots := make(map[rootKey]cciptypes.MerkleRootChain)
+		for _, root := range observedRoots {
+			rk := rootKey{
+				ChainSel:      root.ChainSel,
+				SeqNumsRange:  root.SeqNumsRange,
+				MerkleRoot:    root.MerkleRoot,
+				OnRampAddress: root.OnRampAddress.String(),
+			}
+			counts[rk]++
+			roots[rk] = root
+		}
+
+		for rk, count := range counts {
+			if count >= fDestChain+1 {
+				committedRootsConsensus[sourceChain] = append(committedRootsConsensus[sourceChain], roots[rk])
+			}
+		}
+
+		sort.Slice(committedRootsConsensus[sourceChain], func(i, j int) bool {
+			a, b := committedRootsConsensus[sourceChain][i], committedRootsConsensus[sourceChain][j]
+			if a.SeqNumsRange.Start() != b.SeqNumsRange.Start() {
+				return a.SeqNumsRange.Start() < b.SeqNumsRange.Start()
+			}
+			return a.MerkleRoot.String() < b.MerkleRoot.String()
+		})
+	}
+
+	if len(committedRootsConsensus) > 0 {
+		lggr.Debugw("computed committedRootsConsensus",
+			"committedRootsConsensus", committedRootsConsensus, "observations", observationsPerChain)
+	}
+	return committedRootsConsensus
+}
@@ -1158,10 +1158,12 @@ func Test_reportRangesOutcome(t *testing.T) {
 	destChain := cciptypes.ChainSelector(4)
 
 	testCases := []struct {
-		name                 string
-		consensusObservation consensusObservation
-		merkleTreeSizeLimit  uint64
-		expectedOutcome      Outcome
+		name                    string
+		consensusObservation    consensusObservation
+		prevOutcome             Outcome
+		merkleTreeSizeLimit     uint64
+		maxMerkleRootsPerReport uint64
+		expectedOutcome         Outcome
 	}{
 		{
 			name:            "base empty outcome",
@@ -1225,11 +1227,58 @@ func Test_reportRangesOutcome(t *testing.T) {
 				RMNRemoteCfg: rmnRemoteCfg,
 			},
 		},
+		{
+			name: "more chains than max merkle roots per report",
+			consensusObservation: consensusObservation{
+				OnRampMaxSeqNums: map[cciptypes.ChainSelector]cciptypes.SeqNum{
+					1: 20,
+					2: 1000,
+					3: 10000,
+				},
+				OffRampNextSeqNums: map[cciptypes.ChainSelector]cciptypes.SeqNum{
+					1: 18,
+					2: 995,
+					3: 500,
+				},
+				RMNRemoteConfig: map[cciptypes.ChainSelector]cciptypes.RemoteConfig{
+					destChain: rmnRemoteCfg,
+				},
+			},
+			prevOutcome: Outcome{
+				LaneWaitingRounds: map[cciptypes.ChainSelector]uint64{1: 2},
+			},
+			merkleTreeSizeLimit:     5,
+			maxMerkleRootsPerReport: 1,
+			expectedOutcome: Outcome{
+				OutcomeType: ReportIntervalsSelected,
+				RangesSelectedForReport: []plugintypes.ChainRange{
+					{ChainSel: 1, SeqNumRange: cciptypes.NewSeqNumRange(18, 20)},
+					{ChainSel: 2, SeqNumRange: cciptypes.NewSeqNumRange(995, 999)},
+					{ChainSel: 3, SeqNumRange: cciptypes.NewSeqNumRange(500, 504)},
+				},
+				OffRampNextSeqNums: []plugintypes.SeqNumChain{
+					{ChainSel: 1, SeqNum: 18},
+					{ChainSel: 2, SeqNum: 995},
+					{ChainSel: 3, SeqNum: 500},
+				},
+				RMNRemoteCfg: rmnRemoteCfg,
+				// chain 1 has been waiting the longest, chain 3 has more pending messages than chain 2.
+				LaneWaitingRounds: map[cciptypes.ChainSelector]uint64{2: 1, 3: 1},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			outcome := reportRangesOutcome(Query{}, lggr, tc.consensusObservation, tc.merkleTreeSizeLimit, destChain)
+			outcome := reportRangesOutcome(
+				Query{},
+				lggr,
+				tc.consensusObservation,
+				tc.prevOutcome,
+				tc.merkleTreeSizeLimit,
+				tc.maxMerkleRootsPerReport,
+				destChain,
+			)
 			require.Equal(t, tc.expectedOutcome, outcome)
 		})
 	}
@@ -1382,6 +1431,121 @@ func TestCheckForReportTransmission(t *testing.T) {
 				ReportTransmissionCheckAttempts: 1,
 			}, // The function logs an error but continues execution.
 		},
+		{
+			name:                          "Root committed before seq nums are updated, multiReports disabled",
+			maxReportTransmissionAttempts: 3,
+			multipleReports:               false,
+			previousOutcome: Outcome{
+				RootsToReport: []cciptypes.MerkleRootChain{
+					{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+				},
+				OffRampNextSeqNums: []plugintypes.SeqNumChain{{ChainSel: source1, SeqNum: 10}},
+				LaneWaitingRounds:  map[cciptypes.ChainSelector]uint64{source2: 1},
+			},
+			consensusObservation: consensusObservation{
+				OffRampNextSeqNums: map[cciptypes.ChainSelector]cciptypes.SeqNum{source1: 10},
+				CommittedRoots: map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+					source1: {
+						{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(5, 9), MerkleRoot: cciptypes.Bytes32{9}},
+						{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					},
+				},
+			},
+			expectedOutcome: Outcome{
+				OutcomeType:       ReportTransmitted,
+				LaneWaitingRounds: map[cciptypes.ChainSelector]uint64{source2: 1},
+			},
+		},
+		{
+			name:                          "Competing root committed, multiReports disabled",
+			maxReportTransmissionAttempts: 3,
+			multipleReports:               false,
+			previousOutcome: Outcome{
+				RootsToReport: []cciptypes.MerkleRootChain{
+					{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					{ChainSel: source2, SeqNumsRange: cciptypes.NewSeqNumRange(20, 20), MerkleRoot: cciptypes.Bytes32{2}},
+				},
+				OffRampNextSeqNums: []plugintypes.SeqNumChain{
+					{ChainSel: source1, SeqNum: 10},
+					{ChainSel: source2, SeqNum: 20},
+				},
+			},
+			consensusObservation: consensusObservation{
+				OffRampNextSeqNums: map[cciptypes.ChainSelector]cciptypes.SeqNum{source1: 10, source2: 20},
+				CommittedRoots: map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+					source1: {
+						{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 11), MerkleRoot: cciptypes.Bytes32{3}},
+					},
+				},
+			},
+			expectedOutcome: Outcome{
+				OutcomeType: ReportTransmissionFailed,
+			},
+		},
+		{
+			name:                          "Root committed and competing root committed, multiReports enabled",
+			maxReportTransmissionAttempts: 3,
+			multipleReports:               true,
+			previousOutcome: Outcome{
+				RootsToReport: []cciptypes.MerkleRootChain{
+					{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					{ChainSel: source2, SeqNumsRange: cciptypes.NewSeqNumRange(20, 20), MerkleRoot: cciptypes.Bytes32{2}},
+				},
+				OffRampNextSeqNums: []plugintypes.SeqNumChain{
+					{ChainSel: source1, SeqNum: 10},
+					{ChainSel: source2, SeqNum: 20},
+				},
+			},
+			consensusObservation: consensusObservation{
+				OffRampNextSeqNums: map[cciptypes.ChainSelector]cciptypes.SeqNum{source1: 10, source2: 20},
+				CommittedRoots: map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+					source1: {
+						{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					},
+					source2: {
+						{ChainSel: source2, SeqNumsRange: cciptypes.NewSeqNumRange(20, 21), MerkleRoot: cciptypes.Bytes32{4}},
+					},
+				},
+			},
+			expectedOutcome: Outcome{
+				OutcomeType: ReportTransmissionFailed,
+			},
+		},
+		{
+			name:                          "Root committed, other root in flight, multiReports enabled",
+			maxReportTransmissionAttempts: 3,
+			multipleReports:               true,
+			previousOutcome: Outcome{
+				RootsToReport: []cciptypes.MerkleRootChain{
+					{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					{ChainSel: source2, SeqNumsRange: cciptypes.NewSeqNumRange(20, 20), MerkleRoot: cciptypes.Bytes32{2}},
+				},
+				OffRampNextSeqNums: []plugintypes.SeqNumChain{
+					{ChainSel: source1, SeqNum: 10},
+					{ChainSel: source2, SeqNum: 20},
+				},
+			},
+			consensusObservation: consensusObservation{
+				OffRampNextSeqNums: map[cciptypes.ChainSelector]cciptypes.SeqNum{source1: 10, source2: 20},
+				CommittedRoots: map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+					source1: {
+						{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					},
+				},
+			},
+			expectedOutcome: Outcome{
+				OutcomeType: ReportInFlight,
+				RootsToReport: []cciptypes.MerkleRootChain{
+					{ChainSel: source1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 12), MerkleRoot: cciptypes.Bytes32{1}},
+					{ChainSel: source2, SeqNumsRange: cciptypes.NewSeqNumRange(20, 20), MerkleRoot: cciptypes.Bytes32{2}},
+				},
+				OffRampNextSeqNums: []plugintypes.SeqNumChain{
+					{ChainSel: source1, SeqNum: 10},
+					{ChainSel: source2, SeqNum: 20},
+				},
+				ReportTransmissionCheckAttempts: 1,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1399,6 +1563,36 @@ func TestCheckForReportTransmission(t *testing.T) {
 	}
 }
 
+func Test_getCommittedRootsConsensus(t *testing.T) {
+	root := func(chainSel cciptypes.ChainSelector, start, end cciptypes.SeqNum, b byte) cciptypes.MerkleRootChain {
+		return cciptypes.MerkleRootChain{
+			ChainSel:      chainSel,
+			OnRampAddress: cciptypes.UnknownAddress{1},
+			SeqNumsRange:  cciptypes.NewSeqNumRange(start, end),
+			MerkleRoot:    cciptypes.Bytes32{b},
+		}
+	}
+
+	observations := map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+		1: {root(1, 20, 25, 2), root(1, 10, 15, 1), root(1, 20, 25, 2), root(1, 10, 15, 1), root(1, 30, 35, 3)},
+		2: {root(2, 10, 15, 4)},
+	}
+
+	// fDestChain=1 requires two observations of a root.
+	require.Equal(t,
+		map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+			1: {root(1, 10, 15, 1), root(1, 20, 25, 2)},
+		},
+		getCommittedRootsConsensus(logger.Test(t), 1, observations))
+
+	require.Equal(t,
+		map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+			1: {root(1, 10, 15, 1), root(1, 20, 25, 2), root(1, 30, 35, 3)},
+			2: {root(2, 10, 15, 4)},
+		},
+		getCommittedRootsConsensus(logger.Test(t), 0, observations))
+}
+
 func Test_getOffRampNextSequenceNumbersConsensus(t *testing.T) {
 	lggr := logger.Test(t)
 
@@ -1468,3 +1662,84 @@ func Test_getOffRampNextSequenceNumbersConsensus(t *testing.T) {
 		})
 	}
 }
+
+func Test_getConsensusObservation_prefixMerkleRoots(t *testing.T) {
+	const (
+		destChain = 100
+		chainA    = 1
+		chainB    = 2
+		chainC    = 3
+	)
+	onRamp := cciptypes.UnknownAddress{0x1}
+
+	// observe returns the root observed by an oracle that has the messages with the given hashes
+	observe := func(chain cciptypes.ChainSelector, start cciptypes.SeqNum, hashes ...cciptypes.Bytes32) (
+		cciptypes.MerkleRootChain, map[cciptypes.SeqNum]cciptypes.Bytes32) {
+		root, err := merkleRootFromHashes(hashes)
+		require.NoError(t, err)
+		bySeqNum := make(map[cciptypes.SeqNum]cciptypes.Bytes32, len(hashes))
+		for i, h := range hashes {
+			bySeqNum[start+cciptypes.SeqNum(i)] = h
+		}
+		return cciptypes.MerkleRootChain{
+			ChainSel:      chain,
+			OnRampAddress: onRamp,
+			SeqNumsRange:  cciptypes.NewSeqNumRange(start, start+cciptypes.SeqNum(len(hashes)-1)),
+			MerkleRoot:    root,
+		}, bySeqNum
+	}
+
+	h1, h2, h3, bad := cciptypes.Bytes32{1}, cciptypes.Bytes32{2}, cciptypes.Bytes32{3}, cciptypes.Bytes32{0xff}
+	observedHashes := []map[cciptypes.ChainSelector][]cciptypes.Bytes32{
+		// chainA: two oracles are lagging, chainB: all oracles observe the range,
+		// chainC: the oracles don't agree on the first message
+		{chainA: {h1, h2, h3}, chainB: {h1, h2}, chainC: {h1, h2}},
+		{chainA: {h1, h2, h3}, chainB: {h1, h2}, chainC: {bad, h2}},
+		{chainA: {h1, h2}, chainB: {h1, h2}, chainC: {h1, h2}},
+		{chainA: {h1}, chainB: {h1, h2}, chainC: {bad}},
+	}
+
+	attributedObservations := func(
+		rmnEnabledChains map[cciptypes.ChainSelector]bool) []plugincommon.AttributedObservation[Observation] {
+		aos := make([]plugincommon.AttributedObservation[Observation], 0, len(observedHashes))
+		for i, oracleHashes := range observedHashes {
+			obs := Observation{
+				MessageHashes:    plugintypes.MessageHashes{},
+				RMNEnabledChains: rmnEnabledChains,
+				FChain:           map[cciptypes.ChainSelector]int{destChain: 1, chainA: 1, chainB: 1, chainC: 1},
+			}
+			for chain, hashes := range oracleHashes {
+				root, bySeqNum := observe(chain, 10, hashes...)
+				obs.MerkleRoots = append(obs.MerkleRoots, root)
+				obs.MessageHashes[chain] = bySeqNum
+			}
+			aos = append(aos, plugincommon.AttributedObservation[Observation]{
+				OracleID:    commontypes.OracleID(i),
+				Observation: obs,
+			})
+		}
+		return aos
+	}
+
+	expRootA, _ := observe(chainA, 10, h1, h2) // the prefix observed by 3 oracles
+	expRootB, _ := observe(chainB, 10, h1, h2)
+
+	t.Run("RMN disabled", func(t *testing.T) {
+		consensusObs, err := getConsensusObservation(logger.Test(t), 1, destChain, attributedObservations(nil))
+		require.NoError(t, err)
+		require.Equal(t, map[cciptypes.ChainSelector]cciptypes.MerkleRootChain{
+			chainA: expRootA,
+			chainB: expRootB,
+		}, consensusObs.MerkleRoots)
+	})
+
+	t.Run("RMN enabled chains keep their range", func(t *testing.T) {
+		// the RMN nodes sign the root of the selected range, the root of a prefix would invalidate the signatures
+		consensusObs, err := getConsensusObservation(logger.Test(t), 1, destChain,
+			attributedObservations(map[cciptypes.ChainSelector]bool{chainA: true, chainB: true}))
+		require.NoError(t, err)
+		require.Equal(t, map[cciptypes.ChainSelector]cciptypes.MerkleRootChain{
+			chainB: expRootB,
+		}, consensusObs.MerkleRoots)
+	})
+}
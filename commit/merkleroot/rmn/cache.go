@@ -0,0 +1,226 @@
+// cache.go contains the cache of validated RMN responses reused by the controller across rounds.
+
+package rmn
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"slices"
+	"sync"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/patrickmn/go-cache"
+	"golang.org/x/exp/maps"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+const (
+	// responsesCacheExpiry is how long validated RMN responses are reused, it covers the retries of the same
+	// requests in the next few rounds while keeping the reused observations close to fresh ones.
+	responsesCacheExpiry = 30 * time.Second
+
+	// responsesCacheCleanupInterval is how often expired responses are evicted.
+	responsesCacheCleanupInterval = time.Minute
+
+	// responsesCacheMaxEntries bounds the number of cached observations and report signatures (each).
+	responsesCacheMaxEntries = 1024
+)
+
+// responsesCache keeps the validated signed observations and report signatures of the RMN nodes, so that a
+// request for the same lane updates doesn't have to query the RMN nodes again.
+//
+// Signed observations are cached per RMN node, keyed by the lane update requests they respond to (see requestKey).
+// Report signatures are cached per RMN node, keyed by
+// the report which includes the RMN remote config digest.
+type responsesCache struct {
+	mu               sync.Mutex
+	observations     *cache.Cache // observationKey -> rmnSignedObservationWithMeta
+	reportSignatures *cache.Cache // reportKey -> reportSigWithSignerAddress
+	maxEntries       int
+}
+
+func newResponsesCache(expiry time.Duration, maxEntries int) *responsesCache {
+	return &responsesCache{
+		observations:     cache.New(expiry, responsesCacheCleanupInterval),
+		reportSignatures: cache.New(expiry, responsesCacheCleanupInterval),
+		maxEntries:       maxEntries,
+	}
+}
+
+// requestKey returns a key unique to the lane update requests of a round as they are sent to the RMN nodes, i.e.
+// the RMN remote config digest, the destination lane and the requested interval of every source chain.
+func requestKey(
+	configDigest cciptypes.Bytes32,
+	destChain *rmnpb.LaneDest,
+	updateRequestsPerChain map[uint64]updateRequestWithMeta,
+) string {
+	sourceChains := maps.Keys(updateRequestsPerChain)
+	slices.Sort(sourceChains)
+
+	h := sha256.New()
+	h.Write(configDigest[:])
+	h.Write(binary.BigEndian.AppendUint64(nil, destChain.DestChainSelector))
+	writeWithLength(h, destChain.OfframpAddress)
+	for _, sourceChain := range sourceChains {
+		updateReq := updateRequestsPerChain[sourceChain].Data
+		h.Write(binary.BigEndian.AppendUint64(nil, updateReq.LaneSource.SourceChainSelector))
+		writeWithLength(h, updateReq.LaneSource.OnrampAddress)
+		h.Write(binary.BigEndian.AppendUint64(nil, updateReq.ClosedInterval.MinMsgNr))
+		h.Write(binary.BigEndian.AppendUint64(nil, updateReq.ClosedInterval.MaxMsgNr))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func observationKey(requestKey string, nodeID rmntypes.NodeID) string {
+	return fmt.Sprintf("%s/%d", requestKey, nodeID)
+}
+
+// reportKey returns a key unique to the report contents.
+func reportKey(report cciptypes.RMNReport) string {
+	h := sha256.New()
+	h.Write(report.ReportVersionDigest[:])
+	if report.DestChainID.Int != nil {
+		h.Write(report.DestChainID.Bytes())
+	}
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(report.DestChainSelector)))
+	writeWithLength(h, report.RmnRemoteContractAddress)
+	writeWithLength(h, report.OfframpAddress)
+	h.Write(report.RmnHomeContractConfigDigest[:])
+	for _, lu := range report.LaneUpdates {
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(lu.SourceChainSelector)))
+		writeWithLength(h, lu.OnRampAddress)
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(lu.MinSeqNr)))
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(lu.MaxSeqNr)))
+		h.Write(lu.MerkleRoot[:])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeWithLength(h hash.Hash, b []byte) {
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(b))))
+	h.Write(b)
+}
+
+func reportSignatureKey(reportKey string, nodeID rmntypes.NodeID) string {
+	return fmt.Sprintf("%s/%d", reportKey, nodeID)
+}
+
+// full returns true if no more entries can be added to c, expired entries are evicted first.
+func (r *responsesCache) full(c *cache.Cache) bool {
+	if c.ItemCount() < r.maxEntries {
+		return false
+	}
+	c.DeleteExpired()
+	return c.ItemCount() >= r.maxEntries
+}
+
+// addObservation caches a validated signed observation of the node in response to the provided lane update
+// requests.
+func (r *responsesCache) addObservation(
+	configDigest cciptypes.Bytes32,
+	destChain *rmnpb.LaneDest,
+	updateRequestsPerChain map[uint64]updateRequestWithMeta,
+	signedObs rmnSignedObservationWithMeta,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.full(r.observations) {
+		return
+	}
+	key := observationKey(requestKey(configDigest, destChain, updateRequestsPerChain), signedObs.RMNNodeID)
+	r.observations.SetDefault(key, signedObs)
+}
+
+// getObservations returns the cached signed observations of the nodes for the lane update requests. Observations
+// are only reused for the exact same requests (config digest, destination and intervals of every source chain),
+// e.g. when a report is retried, so that they passed the same validation as the observations received from the
+// nodes for these requests.
+func (r *responsesCache) getObservations(
+	configDigest cciptypes.Bytes32,
+	destChain *rmnpb.LaneDest,
+	updateRequestsPerChain map[uint64]updateRequestWithMeta,
+) []rmnSignedObservationWithMeta {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rk := requestKey(configDigest, destChain, updateRequestsPerChain)
+	var signedObservations []rmnSignedObservationWithMeta
+	for _, nodeID := range requestedNodeIDs(updateRequestsPerChain) {
+		v, ok := r.observations.Get(observationKey(rk, nodeID))
+		if !ok {
+			continue
+		}
+		signedObservations = append(signedObservations, v.(rmnSignedObservationWithMeta))
+	}
+	return signedObservations
+}
+
+// deleteObservations removes the cached observations of all the nodes for the lane update requests.
+func (r *responsesCache) deleteObservations(
+	configDigest cciptypes.Bytes32,
+	destChain *rmnpb.LaneDest,
+	updateRequestsPerChain map[uint64]updateRequestWithMeta,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rk := requestKey(configDigest, destChain, updateRequestsPerChain)
+	for _, nodeID := range requestedNodeIDs(updateRequestsPerChain) {
+		r.observations.Delete(observationKey(rk, nodeID))
+	}
+}
+
+// requestedNodeIDs returns the sorted ids of the nodes supporting any of the lane update requests.
+func requestedNodeIDs(updateRequestsPerChain map[uint64]updateRequestWithMeta) []rmntypes.NodeID {
+	nodeIDs := mapset.NewSet[rmntypes.NodeID]()
+	for _, updateReq := range updateRequestsPerChain {
+		nodeIDs = nodeIDs.Union(updateReq.RmnNodes)
+	}
+	ids := nodeIDs.ToSlice()
+	slices.Sort(ids)
+	return ids
+}
+
+// addReportSignature caches a validated report signature of the node.
+func (r *responsesCache) addReportSignature(
+	reportKey string,
+	nodeID rmntypes.NodeID,
+	sig reportSigWithSignerAddress,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.full(r.reportSignatures) {
+		return
+	}
+	r.reportSignatures.SetDefault(reportSignatureKey(reportKey, nodeID), sig)
+}
+
+// getReportSignatures returns the cached signatures of the signers for the report.
+func (r *responsesCache) getReportSignatures(
+	reportKey string,
+	signers []cciptypes.RemoteSignerInfo,
+) map[rmntypes.NodeID]reportSigWithSignerAddress {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sigs := make(map[rmntypes.NodeID]reportSigWithSignerAddress)
+	for _, signer := range signers {
+		nodeID := rmntypes.NodeID(signer.NodeIndex)
+		v, ok := r.reportSignatures.Get(reportSignatureKey(reportKey, nodeID))
+		if !ok {
+			continue
+		}
+		sigs[nodeID] = v.(reportSigWithSignerAddress)
+	}
+	return sigs
+}
@@ -0,0 +1,128 @@
+package rmn
+
+import (
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+func Test_responsesCache_observations(t *testing.T) {
+	digest := cciptypes.Bytes32{0x1}
+	destChain := &rmnpb.LaneDest{DestChainSelector: uint64(chainD1), OfframpAddress: chainD1OffRamp}
+
+	updateReq := func(chain cciptypes.ChainSelector, onRamp []byte, minSeqNr, maxSeqNr uint64) updateRequestWithMeta {
+		return updateRequestWithMeta{
+			Data: &rmnpb.FixedDestLaneUpdateRequest{
+				LaneSource:     &rmnpb.LaneSource{SourceChainSelector: uint64(chain), OnrampAddress: onRamp},
+				ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: minSeqNr, MaxMsgNr: maxSeqNr},
+			},
+			RmnNodes: mapset.NewSet[rmntypes.NodeID](1, 2),
+		}
+	}
+	signedObs := func(nodeID rmntypes.NodeID, reqs ...updateRequestWithMeta) rmnSignedObservationWithMeta {
+		obs := &rmnpb.Observation{LaneDest: destChain}
+		for _, req := range reqs {
+			obs.FixedDestLaneUpdates = append(obs.FixedDestLaneUpdates, &rmnpb.FixedDestLaneUpdate{
+				LaneSource: &rmnpb.LaneSource{
+					SourceChainSelector: req.Data.LaneSource.SourceChainSelector,
+					OnrampAddress:       observedOnRampAddress(req.Data.LaneSource.OnrampAddress),
+				},
+				ClosedInterval: req.Data.ClosedInterval,
+				Root:           []byte{0x1},
+			})
+		}
+		return rmnSignedObservationWithMeta{
+			SignedObservation: &rmnpb.SignedObservation{Observation: obs, Signature: []byte{byte(nodeID)}},
+			RMNNodeID:         nodeID,
+		}
+	}
+
+	s1 := updateReq(chainS1, chainS1OnRamp, 10, 20)
+	s2 := updateReq(chainS2, chainS2OnRamp, 100, 110)
+	s2Next := updateReq(chainS2, chainS2OnRamp, 111, 120)
+	reqs := map[uint64]updateRequestWithMeta{uint64(chainS1): s1, uint64(chainS2): s2}
+
+	c := newResponsesCache(time.Minute, 100)
+	obs1 := signedObs(1, s1, s2)
+	obs2 := signedObs(2, s1, s2)
+	c.addObservation(digest, destChain, reqs, obs1)
+	c.addObservation(digest, destChain, reqs, obs2)
+
+	// Exact same requests.
+	got := c.getObservations(digest, destChain, map[uint64]updateRequestWithMeta{
+		uint64(chainS1): s1, uint64(chainS2): s2,
+	})
+	assert.Equal(t, []rmnSignedObservationWithMeta{obs1, obs2}, got)
+
+	// Observations are not reused for different requests, even if they cover some of the lane updates.
+	assert.Empty(t, c.getObservations(digest, destChain, map[uint64]updateRequestWithMeta{uint64(chainS1): s1}))
+	assert.Empty(t, c.getObservations(digest, destChain, map[uint64]updateRequestWithMeta{
+		uint64(chainS1): s1, uint64(chainS2): s2Next,
+	}))
+
+	// Different config digest or destination.
+	assert.Empty(t, c.getObservations(cciptypes.Bytes32{0x2}, destChain, reqs))
+	assert.Empty(t, c.getObservations(digest, &rmnpb.LaneDest{DestChainSelector: 1, OfframpAddress: chainD1OffRamp},
+		reqs))
+
+	c.deleteObservations(digest, destChain, reqs)
+	assert.Empty(t, c.getObservations(digest, destChain, reqs))
+
+	// Expired observations are not reused.
+	c = newResponsesCache(time.Millisecond, 100)
+	c.addObservation(digest, destChain, reqs, obs1)
+	time.Sleep(5 * time.Millisecond)
+	assert.Empty(t, c.getObservations(digest, destChain, reqs))
+}
+
+func Test_responsesCache_reportSignatures(t *testing.T) {
+	report := cciptypes.NewRMNReport(
+		cciptypes.Bytes32{0x1},
+		cciptypes.NewBigIntFromInt64(1),
+		chainD1,
+		[]byte{0x2},
+		chainD1OffRamp,
+		cciptypes.Bytes32{0x3},
+		[]cciptypes.RMNLaneUpdate{{
+			SourceChainSelector: chainS1,
+			OnRampAddress:       chainS1OnRamp,
+			MinSeqNr:            10,
+			MaxSeqNr:            20,
+			MerkleRoot:          cciptypes.Bytes32{0x4},
+		}},
+	)
+	otherRoot := report
+	otherRoot.LaneUpdates = []cciptypes.RMNLaneUpdate{report.LaneUpdates[0]}
+	otherRoot.LaneUpdates[0].MerkleRoot = cciptypes.Bytes32{0x5}
+	otherDigest := report
+	otherDigest.RmnHomeContractConfigDigest = cciptypes.Bytes32{0x6}
+
+	require.Equal(t, reportKey(report), reportKey(report))
+	require.NotEqual(t, reportKey(report), reportKey(otherRoot))
+	require.NotEqual(t, reportKey(report), reportKey(otherDigest))
+
+	signers := []cciptypes.RemoteSignerInfo{{NodeIndex: 1}, {NodeIndex: 2}, {NodeIndex: 3}}
+	sig := reportSigWithSignerAddress{
+		reportSig:     &rmnpb.ReportSignature{Signature: &rmnpb.EcdsaSignature{R: []byte{1}, S: []byte{2}}},
+		signerAddress: []byte{0x1},
+	}
+
+	c := newResponsesCache(time.Minute, 2)
+	c.addReportSignature(reportKey(report), 1, sig)
+	c.addReportSignature(reportKey(report), 3, sig)
+	assert.Equal(t, map[rmntypes.NodeID]reportSigWithSignerAddress{1: sig, 3: sig},
+		c.getReportSignatures(reportKey(report), signers))
+	assert.Empty(t, c.getReportSignatures(reportKey(otherRoot), signers))
+
+	// The cache is bounded.
+	c.addReportSignature(reportKey(report), 2, sig)
+	assert.Len(t, c.getReportSignatures(reportKey(report), signers), 2)
+}
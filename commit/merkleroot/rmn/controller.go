@@ -121,6 +121,12 @@ type controller struct {
 	// After this timer expires we send additional report signature requests to the rest of the RMN nodes.
 	reportsInitialRequestTimerDuration time.Duration
 
+	// nodeHealth scores the RMN nodes based on their previous requests, healthy nodes are requested first.
+	nodeHealth *nodeHealth
+
+	// cache keeps the validated RMN responses so that retries for the same lane updates can reuse them.
+	cache *responsesCache
+
 	metricsReporter MetricsReporter
 }
 
@@ -149,6 +155,8 @@ func NewController(
 		ed25519Verifier:                         NewED25519Verifier(),
 		observationsInitialRequestTimerDuration: observationsInitialRequestTimerDuration,
 		reportsInitialRequestTimerDuration:      reportsInitialRequestTimerDuration,
+		nodeHealth:                              newNodeHealth(observationsInitialRequestTimerDuration),
+		cache:                                   newResponsesCache(responsesCacheExpiry, responsesCacheMaxEntries),
 		metricsReporter:                         metricsReporter,
 	}
 }
@@ -233,6 +241,8 @@ func (c *controller) ComputeReportSignatures(
 		rmnRemoteCfg,
 		rmnNodeInfo)
 	if err != nil {
+		// The RMN nodes might not sign the report because of the (cached) observations, start over next time.
+		c.cache.deleteObservations(rmnRemoteCfg.ConfigDigest, destChain, updatesPerChain)
 		return nil, fmt.Errorf("get rmn report signatures: %w", err)
 	}
 	lggr.Infow("received RMN report signatures",
@@ -302,25 +312,52 @@ func (c *controller) getRmnSignedObservations(
 
 	// Send to every RMN node all the lane update requests it supports until all chains have a sufficient amount.
 	// of initial observers. Upon timer expiration, additional requests are sent to the rest of the RMN nodes.
+	// Nodes are picked by descending health score and demoted nodes don't count as initial observers, so that
+	// additional nodes are requested right away instead of waiting for the timer.
 
 	chainsWithEnoughRequests := mapset.NewSet[uint64]()
-	for nodeID := range rmnNodeInfo {
+	healthyRequestedNodes := make(map[uint64]int) // sourceChain -> number of requested non-demoted nodes
+	for sourceChain := range updateRequestsPerChain {
+		requestedNodes[sourceChain] = mapset.NewSet[rmntypes.NodeID]()
+	}
+
+	// Observations cached from previous rounds for the same requests count as responses of their nodes.
+	cachedObservations := c.cache.getObservations(configDigest, destChain, updateRequestsPerChain)
+	for _, so := range cachedObservations {
+		for _, lu := range so.SignedObservation.Observation.FixedDestLaneUpdates {
+			sourceChain := lu.LaneSource.SourceChainSelector
+			requestedNodes[sourceChain].Add(so.RMNNodeID)
+			healthyRequestedNodes[sourceChain]++
+			if homeChainF, exist := homeFMap[cciptypes.ChainSelector(sourceChain)]; exist &&
+				consensus.GteFPlusOne(homeChainF, healthyRequestedNodes[sourceChain]) {
+				chainsWithEnoughRequests.Add(sourceChain)
+			}
+		}
+	}
+	if len(cachedObservations) > 0 {
+		lggr.Infow("reusing cached RMN signed observations", "signedObservations", cachedObservations)
+	}
+
+	rankedNodeIDs := rankNodes(c.nodeHealth, maps.Keys(rmnNodeInfo), func(id rmntypes.NodeID) rmntypes.NodeID {
+		return id
+	})
+	for _, nodeID := range rankedNodeIDs {
 		if chainsWithEnoughRequests.Cardinality() == len(updateRequestsPerChain) {
 			break // We have enough initial observers for all source chains.
 		}
 
 		for sourceChain, updateRequest := range updateRequestsPerChain {
-			// if this node cannot support the source chain, skip it
-			if !updateRequest.RmnNodes.Contains(nodeID) {
+			// if this node cannot support the source chain or already observed it, skip it
+			if !updateRequest.RmnNodes.Contains(nodeID) || requestedNodes[sourceChain].Contains(nodeID) {
 				continue
 			}
 
 			// add the node as a requested observer for this source chain
-			if _, ok := requestedNodes[sourceChain]; !ok {
-				requestedNodes[sourceChain] = mapset.NewSet[rmntypes.NodeID]()
-			}
 			requestedNodes[sourceChain].Add(nodeID)
 			requestsPerNode[nodeID] = append(requestsPerNode[nodeID], updateRequest.Data)
+			if !c.nodeHealth.isDemoted(nodeID) {
+				healthyRequestedNodes[sourceChain]++
+			}
 
 			// if we already have enough requests for this source chain, mark it
 			homeChainF, exist := homeFMap[cciptypes.ChainSelector(sourceChain)]
@@ -328,18 +365,25 @@ func (c *controller) getRmnSignedObservations(
 				lggr.Errorw("no home F for chain", "chain", sourceChain)
 				continue
 			}
-			if consensus.GteFPlusOne(homeChainF, requestedNodes[sourceChain].Cardinality()) {
+			if consensus.GteFPlusOne(homeChainF, healthyRequestedNodes[sourceChain]) {
 				chainsWithEnoughRequests.Add(sourceChain)
 			}
 		}
 	}
 
-	requestIDs := c.sendObservationRequests(lggr, destChain, requestsPerNode, rmnNodeInfo)
-
-	signedObservations, err := c.listenForRmnObservationResponses(
-		ctx, lggr, destChain, requestIDs, updateRequestsPerChain, requestedNodes, configDigest, homeFMap, rmnNodeInfo)
-	if err != nil && !errors.Is(err, ErrInsufficientObservationResponses) {
-		return nil, nil, fmt.Errorf("listen for rmn observation responses: %w", err)
+	signedObservations := cachedObservations
+	chainsWithCachedResponses := chainsWithSufficientObservationResponses(
+		lggr, updateRequestsPerChain, cachedObservations, homeFMap)
+	if chainsWithCachedResponses.Cardinality() < len(updateRequestsPerChain) {
+		requestIDs := c.sendObservationRequests(lggr, destChain, requestsPerNode, rmnNodeInfo)
+
+		var err error
+		signedObservations, err = c.listenForRmnObservationResponses(
+			ctx, lggr, destChain, requestIDs, updateRequestsPerChain, requestedNodes, configDigest, homeFMap,
+			rmnNodeInfo, cachedObservations)
+		if err != nil && !errors.Is(err, ErrInsufficientObservationResponses) {
+			return nil, nil, fmt.Errorf("listen for rmn observation responses: %w", err)
+		}
 	}
 
 	laneUpdatesToMakeProgressWith := make(map[uint64]updateRequestWithMeta)
@@ -399,9 +443,7 @@ func (c *controller) sendObservationRequests(
 			fixedDestLaneUpdateRequests = append(fixedDestLaneUpdateRequests, &rmnpb.FixedDestLaneUpdateRequest{
 				LaneSource: &rmnpb.LaneSource{
 					SourceChainSelector: request.LaneSource.SourceChainSelector,
-					// TODO check if we can remove the call for keepNRightBytes
-					// https://github.com/smartcontractkit/chainlink-ccip/pull/647/files#r1966165319
-					OnrampAddress: typconv.KeepNRightBytes(request.LaneSource.OnrampAddress, 20),
+					OnrampAddress:       observedOnRampAddress(request.LaneSource.OnrampAddress),
 				},
 				ClosedInterval: request.ClosedInterval,
 			})
@@ -426,7 +468,7 @@ func (c *controller) sendObservationRequests(
 		lggr := logger.With(lggr, "node", nodeID, "requestID", req.RequestId)
 		lggr.Infow("sending observation request", "laneUpdateRequests", requests)
 		if err := c.marshalAndSend(req, rmnNode); err != nil {
-			c.metricsReporter.TrackRmnRequest(RmnMethodObservation, 0, uint64(nodeID), "failed_to_send_request")
+			c.trackRequest(RmnMethodObservation, 0, uint64(nodeID), rmnErrFailedToSend)
 			lggr.Errorw("failed to send observation request", "err", err)
 			continue
 		}
@@ -452,17 +494,24 @@ func (c *controller) listenForRmnObservationResponses(
 	configDigest cciptypes.Bytes32,
 	homeFMap map[cciptypes.ChainSelector]int,
 	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
+	cachedObservations []rmnSignedObservationWithMeta,
 ) ([]rmnSignedObservationWithMeta, error) {
 	lggr.Infow("listening for RMN observation responses", "requestIDs",
 		mapset.NewSetFromMapKeys(inFlightRequests).String())
 
 	finishedRequestIDs := mapset.NewSet[uint64]()
-	rmnObservationResponses := make([]rmnSignedObservationWithMeta, 0)
+	rmnObservationResponses := slices.Clone(cachedObservations)
+	if rmnObservationResponses == nil {
+		rmnObservationResponses = make([]rmnSignedObservationWithMeta, 0)
+	}
 
 	initialObservationRequestTimer := time.NewTimer(c.observationsInitialRequestTimerDuration)
 	timerExpired := false
 
 	defer initialObservationRequestTimer.Stop()
+	defer func() {
+		c.trackUnansweredRequests(inFlightRequests, finishedRequestIDs, c.observationsInitialRequestTimerDuration)
+	}()
 	for {
 		select {
 		case resp := <-c.peerClient.Recv():
@@ -483,15 +532,17 @@ func (c *controller) listenForRmnObservationResponses(
 			)
 
 			if err != nil {
-				c.metricsReporter.TrackRmnRequest(RmnMethodObservation, latency, uint64(resp.RMNNodeID), "invalid_response")
+				c.trackRequest(RmnMethodObservation, latency, uint64(resp.RMNNodeID), rmnErrInvalidResponse)
 				lggr.Warnw("skipping an invalid RMN observation response", "err", err)
 				initialObservationRequestTimer.Reset(0) // immediately schedule the additional requests
 			} else {
-				c.metricsReporter.TrackRmnRequest(RmnMethodObservation, latency, uint64(resp.RMNNodeID), "")
-				rmnObservationResponses = append(rmnObservationResponses, rmnSignedObservationWithMeta{
+				c.trackRequest(RmnMethodObservation, latency, uint64(resp.RMNNodeID), "")
+				signedObs := rmnSignedObservationWithMeta{
 					SignedObservation: parsedResp.GetSignedObservation(),
 					RMNNodeID:         resp.RMNNodeID,
-				})
+				}
+				c.cache.addObservation(configDigest, destChain, lursPerChain, signedObs)
+				rmnObservationResponses = append(rmnObservationResponses, signedObs)
 			}
 
 			chainsWithSufficientResponses := chainsWithSufficientObservationResponses(
@@ -540,8 +591,8 @@ func (c *controller) listenForRmnObservationResponses(
 			// Report metrics for requests we never received responses for
 			for requestID, requestInfo := range inFlightRequests {
 				if !finishedRequestIDs.Contains(requestID) {
-					c.metricsReporter.TrackRmnRequest(RmnMethodObservation, requestInfo.Latency(),
-						requestInfo.nodeID, "timeout")
+					c.trackRequest(RmnMethodObservation, requestInfo.Latency(), requestInfo.nodeID, rmnErrTimeout)
+					finishedRequestIDs.Add(requestID)
 					lggr.Warnw("Timed out waiting for an observation response from RMN",
 						"requestID", requestID, "nodeID", requestInfo.nodeID, "latency", requestInfo.Latency())
 				}
@@ -665,7 +716,7 @@ func (c *controller) validateSignedObservationResponse(
 
 		// TODO check if we can remove the call for keepNRightBytes
 		// https://github.com/smartcontractkit/chainlink-ccip/pull/647/files#r1966165319
-		expOnRampAddress := typconv.KeepNRightBytes(updateReq.Data.LaneSource.OnrampAddress, 20)
+		expOnRampAddress := observedOnRampAddress(updateReq.Data.LaneSource.OnrampAddress)
 		if !bytes.Equal(expOnRampAddress, signedObsLu.LaneSource.OnrampAddress) {
 			return fmt.Errorf("unexpected lane source %v", signedObsLu.LaneSource)
 		}
@@ -731,9 +782,9 @@ func (c *controller) getRmnReportSignatures(
 		return fixedDestLaneUpdates[i].LaneSource.SourceChainSelector < fixedDestLaneUpdates[j].LaneSource.SourceChainSelector
 	})
 
-	destChainInfo, exists := chainsel.ChainBySelector(destChain.DestChainSelector)
-	if !exists {
-		return nil, fmt.Errorf("unknown dest chain selector %d", destChain.DestChainSelector)
+	evmDestChainID, err := evmChainIDOf(destChain.DestChainSelector)
+	if err != nil {
+		return nil, err
 	}
 
 	laneUpdates, err := NewLaneUpdatesFromPB(fixedDestLaneUpdates)
@@ -743,7 +794,7 @@ func (c *controller) getRmnReportSignatures(
 
 	rmnReport := cciptypes.NewRMNReport(
 		rmnRemoteCfg.RmnReportVersion,
-		cciptypes.NewBigIntFromInt64(int64(destChainInfo.EvmChainID)),
+		cciptypes.NewBigIntFromInt64(int64(evmDestChainID)),
 		cciptypes.ChainSelector(destChain.DestChainSelector),
 		rmnRemoteCfg.ContractAddress,
 		destChain.OfframpAddress,
@@ -751,14 +802,9 @@ func (c *controller) getRmnReportSignatures(
 		laneUpdates,
 	)
 
-	chainInfo, exists := chainsel.ChainBySelector(destChain.DestChainSelector)
-	if !exists {
-		return nil, fmt.Errorf("unknown chain selector %d", destChain.DestChainSelector)
-	}
-
 	reportSigReq := &rmnpb.ReportSignatureRequest{
 		Context: &rmnpb.ReportContext{
-			EvmDestChainId:              chainInfo.EvmChainID,
+			EvmDestChainId:              evmDestChainID,
 			RmnRemoteContractAddress:    rmnRemoteCfg.ContractAddress,
 			RmnHomeContractConfigDigest: rmnRemoteCfg.ConfigDigest[:],
 			LaneDest:                    destChain,
@@ -767,12 +813,25 @@ func (c *controller) getRmnReportSignatures(
 	}
 	remoteF := int(rmnRemoteCfg.FSign)
 	signers := rmnRemoteCfg.Signers
+
+	// Signatures cached from previous rounds for the same report don't have to be requested again.
+	rmnReportKey := reportKey(rmnReport)
+	cachedReportSigs := c.cache.getReportSignatures(rmnReportKey, signers)
+	if consensus.GteFPlusOne(remoteF, len(cachedReportSigs)) {
+		lggr.Infow("reusing cached RMN report signatures", "signers", maps.Keys(cachedReportSigs))
+		return &ReportSignatures{
+			Signatures:  sortAndParseReportSigs(maps.Values(cachedReportSigs)),
+			LaneUpdates: fixedDestLaneUpdates,
+		}, nil
+	}
+
 	inFlightRequests, signersRequested, err := c.sendReportSignatureRequest(
 		lggr,
 		reportSigReq,
 		signers,
 		remoteF,
-		rmnNodeInfo)
+		rmnNodeInfo,
+		mapset.NewSetFromMapKeys(cachedReportSigs))
 	if err != nil {
 		return nil, fmt.Errorf("send report signature request: %w", err)
 	}
@@ -782,11 +841,13 @@ func (c *controller) getRmnReportSignatures(
 		lggr,
 		inFlightRequests,
 		rmnReport,
+		rmnReportKey,
 		reportSigReq,
 		signersRequested,
 		signers,
 		remoteF,
-		rmnNodeInfo)
+		rmnNodeInfo,
+		maps.Values(cachedReportSigs))
 	if err != nil {
 		return nil, fmt.Errorf("listen for rmn report signatures: %w", err)
 	}
@@ -874,7 +935,9 @@ func selectRoots(
 	return selectedRoots, nil
 }
 
-// sendReportSignatureRequest sends the report signature request to #remoteF+1 random RMN nodes.
+// sendReportSignatureRequest sends the report signature request to #remoteF+1 RMN nodes, picked by descending
+// health score. Demoted nodes are requested in addition to the #remoteF+1 healthy ones and the signers which
+// already signed the report are not requested, they count towards the #remoteF+1.
 // If not enough requests were sent, it returns an error.
 func (c *controller) sendReportSignatureRequest(
 	lggr logger.Logger,
@@ -882,16 +945,24 @@ func (c *controller) sendReportSignatureRequest(
 	remoteSigners []cciptypes.RemoteSignerInfo,
 	remoteF int,
 	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
+	alreadySigned mapset.Set[rmntypes.NodeID],
 ) (
 	inFlightRequests map[uint64]InFlightRmnRequest, signersRequested mapset.Set[rmntypes.NodeID], err error) {
 	inFlightRequests = make(map[uint64]InFlightRmnRequest)
-	signersRequested = mapset.NewSet[rmntypes.NodeID]()
+	signersRequested = alreadySigned.Clone()
 
 	// Send the report signature request to at least #remoteF+1
-	for _, node := range randomShuffle(remoteSigners) {
-		if consensus.GteFPlusOne(remoteF, len(inFlightRequests)) {
+	healthyRequests := alreadySigned.Cardinality()
+	rankedSigners := rankNodes(c.nodeHealth, remoteSigners, func(s cciptypes.RemoteSignerInfo) rmntypes.NodeID {
+		return rmntypes.NodeID(s.NodeIndex)
+	})
+	for _, node := range rankedSigners {
+		if consensus.GteFPlusOne(remoteF, healthyRequests) {
 			break
 		}
+		if alreadySigned.Contains(rmntypes.NodeID(node.NodeIndex)) {
+			continue
+		}
 
 		req := &rmnpb.Request{
 			RequestId: newRequestID(lggr),
@@ -911,15 +982,18 @@ func (c *controller) sendReportSignatureRequest(
 		err := c.marshalAndSend(req, rmnNode)
 		if err != nil {
 			lggr.Warnw("failed to send report signature request", "node", node.NodeIndex, "err", err)
-			c.metricsReporter.TrackRmnRequest(RmnMethodReportSignature, 0, node.NodeIndex, "failed_to_send_request")
+			c.trackRequest(RmnMethodReportSignature, 0, node.NodeIndex, rmnErrFailedToSend)
 			continue
 		}
 
 		inFlightRequests[req.RequestId] = NewInFlightRmnRequest(node.NodeIndex)
 		signersRequested.Add(rmntypes.NodeID(node.NodeIndex))
+		if !c.nodeHealth.isDemoted(rmntypes.NodeID(node.NodeIndex)) {
+			healthyRequests++
+		}
 	}
 
-	if consensus.LtFPlusOne(remoteF, len(inFlightRequests)) {
+	if consensus.LtFPlusOne(remoteF, len(inFlightRequests)+alreadySigned.Cardinality()) {
 		return inFlightRequests, signersRequested, fmt.Errorf("not able to send to enough report signers")
 	}
 	return inFlightRequests, signersRequested, nil
@@ -938,18 +1012,23 @@ func (c *controller) listenForRmnReportSignatures(
 	lggr logger.Logger,
 	inFlightRequests map[uint64]InFlightRmnRequest,
 	rmnReport cciptypes.RMNReport,
+	rmnReportKey string,
 	reportSigReq *rmnpb.ReportSignatureRequest,
 	signersRequested mapset.Set[rmntypes.NodeID],
 	signers []cciptypes.RemoteSignerInfo,
 	remoteF int,
 	rmnNodeInfo map[rmntypes.NodeID]rmntypes.HomeNodeInfo,
+	cachedReportSigs []reportSigWithSignerAddress,
 ) ([]*rmnpb.EcdsaSignature, error) {
 	tReportsInitialRequest := time.NewTimer(c.reportsInitialRequestTimerDuration)
 	timerExpired := false
 
-	reportSigs := make([]reportSigWithSignerAddress, 0)
+	reportSigs := append(make([]reportSigWithSignerAddress, 0, len(cachedReportSigs)), cachedReportSigs...)
 	finishedRequests := mapset.NewSet[uint64]()
 	inFlightRequests = maps.Clone(inFlightRequests)
+	defer func() {
+		c.trackUnansweredRequests(inFlightRequests, finishedRequests, c.reportsInitialRequestTimerDuration)
+	}()
 	requestIDs := mapset.NewSetFromMapKeys(inFlightRequests)
 	lggr.Infof("waiting for report signatures, requestIDs: %s", requestIDs.String())
 
@@ -967,12 +1046,13 @@ func (c *controller) listenForRmnReportSignatures(
 			reportSig, err := c.validateReportSigResponse(ctx, responseTyp, resp.RMNNodeID, signers, rmnReport)
 
 			if err != nil {
-				c.metricsReporter.TrackRmnRequest(RmnMethodReportSignature, latency, uint64(resp.RMNNodeID), "invalid_response")
+				c.trackRequest(RmnMethodReportSignature, latency, uint64(resp.RMNNodeID), rmnErrInvalidResponse)
 				lggr.Warnw("skipping an invalid RMN report signature response", "err", err)
 				tReportsInitialRequest.Reset(0) // schedule additional requests if any
 			} else {
-				c.metricsReporter.TrackRmnRequest(RmnMethodReportSignature, latency, uint64(resp.RMNNodeID), "")
+				c.trackRequest(RmnMethodReportSignature, latency, uint64(resp.RMNNodeID), "")
 				lggr.Infow("received valid report signature", "node", resp.RMNNodeID, "requestID", responseTyp.RequestId)
+				c.cache.addReportSignature(rmnReportKey, resp.RMNNodeID, *reportSig)
 				reportSigs = append(reportSigs, *reportSig)
 			}
 
@@ -1023,8 +1103,8 @@ func (c *controller) listenForRmnReportSignatures(
 			// Report metrics for requests we never received responses for
 			for requestID, requestInfo := range inFlightRequests {
 				if !finishedRequests.Contains(requestID) {
-					c.metricsReporter.TrackRmnRequest(RmnMethodReportSignature, requestInfo.Latency(),
-						requestInfo.nodeID, "timeout")
+					c.trackRequest(RmnMethodReportSignature, requestInfo.Latency(), requestInfo.nodeID, rmnErrTimeout)
+					finishedRequests.Add(requestID)
 					lggr.Warnw("Timed out waiting for a report signature response from RMN",
 						"requestID", requestID, "nodeID", requestInfo.nodeID, "latency", requestInfo.Latency())
 				}
@@ -1129,6 +1209,30 @@ type rmnSignedObservationWithMeta struct {
 	RMNNodeID         rmntypes.NodeID
 }
 
+// trackRequest reports the outcome of an RMN request and updates the health score of the node.
+func (c *controller) trackRequest(method string, latency float64, nodeID uint64, err string) {
+	c.metricsReporter.TrackRmnRequest(method, latency, nodeID, err)
+	score := c.nodeHealth.record(rmntypes.NodeID(nodeID), latency, requestOutcomeFromErr(err))
+	c.metricsReporter.TrackRmnNodeScore(nodeID, score)
+}
+
+// trackUnansweredRequests penalizes the nodes that didn't respond within the initial request timer to requests
+// which are no longer awaited. They are not reported as timeouts since their responses were not needed.
+func (c *controller) trackUnansweredRequests(
+	inFlightRequests map[uint64]InFlightRmnRequest,
+	finishedRequests mapset.Set[uint64],
+	initialRequestTimer time.Duration,
+) {
+	for requestID, requestInfo := range inFlightRequests {
+		latency := requestInfo.Latency()
+		if finishedRequests.Contains(requestID) || latency < float64(initialRequestTimer.Milliseconds()) {
+			continue
+		}
+		score := c.nodeHealth.record(rmntypes.NodeID(requestInfo.nodeID), latency, requestOutcomeFailure)
+		c.metricsReporter.TrackRmnNodeScore(requestInfo.nodeID, score)
+	}
+}
+
 func (c *controller) marshalAndSend(req *rmnpb.Request, rmnNode rmntypes.HomeNodeInfo) error {
 	reqBytes, err := proto.Marshal(req)
 	if err != nil {
@@ -1174,6 +1278,14 @@ func (c *controller) parseResponse(
 	return responseTyp, requestInfo.Latency(), nil
 }
 
+// observedOnRampAddress converts the OnRamp address from 32bytes (abi encoded) to 20bytes (evm address),
+// which is how the RMN nodes observe it.
+// TODO check if we can remove the call for keepNRightBytes
+// https://github.com/smartcontractkit/chainlink-ccip/pull/647/files#r1966165319
+func observedOnRampAddress(onRampAddress []byte) []byte {
+	return typconv.KeepNRightBytes(onRampAddress, 20)
+}
+
 func randomShuffle[T any](s []T) []T {
 	ret := make([]T, len(s))
 	for i, randIndex := range rand.Perm(len(s)) {
@@ -1218,3 +1330,20 @@ func NewInFlightRmnRequest(nodeID uint64) InFlightRmnRequest {
 func (i *InFlightRmnRequest) Latency() float64 {
 	return float64(time.Since(i.sent).Milliseconds())
 }
+
+// evmChainIDOf returns the chain id of an EVM destination chain, the RMN report of the other chain families has no
+// chain id and 0 is returned.
+func evmChainIDOf(destChainSelector uint64) (uint64, error) {
+	family, err := chainsel.GetSelectorFamily(destChainSelector)
+	if err != nil {
+		return 0, fmt.Errorf("unknown dest chain selector %d: %w", destChainSelector, err)
+	}
+	if family != chainsel.FamilyEVM {
+		return 0, nil
+	}
+	chainInfo, exists := chainsel.ChainBySelector(destChainSelector)
+	if !exists {
+		return 0, fmt.Errorf("unknown dest chain selector %d", destChainSelector)
+	}
+	return chainInfo.EvmChainID, nil
+}
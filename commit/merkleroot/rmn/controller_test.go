@@ -432,6 +432,8 @@ func TestClient_ComputeReportSignatures(t *testing.T) {
 			reportsInitialRequestTimerDuration:      time.Minute,
 			ed25519Verifier:                         signatureVerifierAlwaysTrue{},
 			rmnCrypto:                               signatureVerifierAlwaysTrue{},
+			nodeHealth:                              newNodeHealth(time.Minute),
+			cache:                                   newResponsesCache(time.Minute, 100),
 			metricsReporter:                         NoopMetrics{},
 		}
 
@@ -1116,3 +1118,16 @@ func (a signatureVerifierAlwaysTrue) VerifyReportSignatures(
 	_ context.Context, _ []cciptypes.RMNECDSASignature, _ cciptypes.RMNReport, _ []cciptypes.UnknownAddress) error {
 	return nil
 }
+
+func Test_evmChainIDOf(t *testing.T) {
+	chainID, err := evmChainIDOf(chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector)
+	require.NoError(t, err)
+	assert.Equal(t, chainsel.ETHEREUM_TESTNET_SEPOLIA.EvmChainID, chainID)
+
+	chainID, err = evmChainIDOf(chainsel.SOLANA_DEVNET.Selector)
+	require.NoError(t, err)
+	assert.Zero(t, chainID)
+
+	_, err = evmChainIDOf(1)
+	require.Error(t, err)
+}
@@ -30,6 +30,20 @@ func (ED25519VerifierImpl) Verify(publicKey ed25519.PublicKey, message, sig []by
 	return ed25519.Verify(publicKey, message, sig)
 }
 
+// ObservationSigningMessage returns the message signed by RMN nodes for an observation:
+// sha256(signedObservationPrefix|sha256(observation)).
+func ObservationSigningMessage(signedObservationPrefix string, observation *rmnpb.Observation) ([]byte, error) {
+	observationBytes, err := proto.Marshal(observation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal observation: %w", err)
+	}
+
+	observationBytesSha256 := sha256.Sum256(observationBytes)
+	msg := append([]byte(signedObservationPrefix), observationBytesSha256[:]...)
+	msgSha256 := sha256.Sum256(msg)
+	return msgSha256[:], nil
+}
+
 // verifyObservationSignature verifies the signature of the RMN observation.
 //
 //	e.g. ed25519.sign(sha256("chainlink ccip 1.6 rmn observation"|sha256(observation)))
@@ -39,22 +53,18 @@ func verifyObservationSignature(
 	signedObs *rmnpb.SignedObservation,
 	verifier ED25519Verifier,
 ) error {
-	observationBytes, err := proto.Marshal(signedObs.GetObservation())
+	msg, err := ObservationSigningMessage(signedObservationPrefix, signedObs.GetObservation())
 	if err != nil {
-		return fmt.Errorf("failed to marshal observation: %w", err)
+		return err
 	}
 
-	observationBytesSha256 := sha256.Sum256(observationBytes)
-	msg := append([]byte(signedObservationPrefix), observationBytesSha256[:]...)
-	msgSha256 := sha256.Sum256(msg)
-
 	if rmnNode.OffchainPublicKey == nil {
 		return fmt.Errorf("node %d has no offchain public key", rmnNode.ID)
 	}
 	if len(*rmnNode.OffchainPublicKey) != ed25519.PublicKeySize {
 		return fmt.Errorf("node %d has an invalid offchain public key", rmnNode.ID)
 	}
-	isValid := verifier.Verify(*rmnNode.OffchainPublicKey, msgSha256[:], signedObs.Signature)
+	isValid := verifier.Verify(*rmnNode.OffchainPublicKey, msg, signedObs.Signature)
 	if !isValid {
 		return fmt.Errorf("observation signature does not match node %d public key", rmnNode.ID)
 	}
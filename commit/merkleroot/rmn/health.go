@@ -0,0 +1,169 @@
+// health.go contains the RMN node health scoring used by the controller to pick which nodes to request first.
+
+package rmn
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+)
+
+const (
+	// nodeHealthEWMAAlpha is the weight of the most recent request in the rolling node stats.
+	nodeHealthEWMAAlpha = 0.3
+
+	// nodeHealthRecoveryHalfLife is the time it takes for the penalties of a node to halve when it is not requested,
+	// so that demoted nodes are eventually preferred again.
+	nodeHealthRecoveryHalfLife = 10 * time.Minute
+
+	// demotedNodeScore is the score under which a node is demoted, a node which on average responds after the
+	// initial request timer or fails half of its requests ends up below it.
+	demotedNodeScore = 0.5
+)
+
+type requestOutcome int
+
+const (
+	requestOutcomeOK requestOutcome = iota
+	// requestOutcomeFailure is a request that could not be sent or was not responded to in time.
+	requestOutcomeFailure
+	// requestOutcomeInvalid is a response that failed validation, e.g. an invalid signature.
+	requestOutcomeInvalid
+)
+
+func requestOutcomeFromErr(err string) requestOutcome {
+	switch err {
+	case "":
+		return requestOutcomeOK
+	case rmnErrInvalidResponse:
+		return requestOutcomeInvalid
+	default:
+		return requestOutcomeFailure
+	}
+}
+
+// nodeStats are the rolling (exponentially weighted) stats of a single node.
+type nodeStats struct {
+	latencyMs   float64
+	failureRate float64
+	invalidRate float64
+	hasLatency  bool
+	updatedAt   time.Time
+}
+
+// nodeHealth keeps a rolling health score for each RMN node, from 0 (unusable) to 1 (healthy and fast).
+//
+//	score = (1 - failureRate) * (1 - invalidRate) / (1 + latency/latencyRef)
+//
+// Nodes that were never requested have a score of 1.
+type nodeHealth struct {
+	mu sync.Mutex
+	// latencyRef is the latency that halves the score of a node, i.e. the initial request timer.
+	latencyRef time.Duration
+	stats      map[rmntypes.NodeID]*nodeStats
+	now        func() time.Time
+}
+
+func newNodeHealth(latencyRef time.Duration) *nodeHealth {
+	if latencyRef <= 0 {
+		latencyRef = time.Second
+	}
+	return &nodeHealth{
+		latencyRef: latencyRef,
+		stats:      make(map[rmntypes.NodeID]*nodeStats),
+		now:        time.Now,
+	}
+}
+
+// record updates the stats of the node with the outcome of a request and returns the new node score.
+func (h *nodeHealth) record(nodeID rmntypes.NodeID, latencyMs float64, outcome requestOutcome) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	s, ok := h.stats[nodeID]
+	if !ok {
+		s = &nodeStats{updatedAt: now}
+		h.stats[nodeID] = s
+	}
+	h.recover(s, now)
+
+	failure, invalid := 0.0, 0.0
+	switch outcome {
+	case requestOutcomeFailure:
+		failure = 1
+	case requestOutcomeInvalid:
+		invalid = 1
+	}
+	s.failureRate = ewma(s.failureRate, failure)
+	s.invalidRate = ewma(s.invalidRate, invalid)
+
+	// The latency of failed requests is the time we gave up waiting and doesn't tell how slow the node is.
+	if outcome != requestOutcomeFailure {
+		if s.hasLatency {
+			s.latencyMs = ewma(s.latencyMs, latencyMs)
+		} else {
+			s.latencyMs = latencyMs
+			s.hasLatency = true
+		}
+	}
+
+	return h.score(s)
+}
+
+// recover decays the penalties of the node based on the time since its last update.
+func (h *nodeHealth) recover(s *nodeStats, now time.Time) {
+	elapsed := now.Sub(s.updatedAt)
+	s.updatedAt = now
+	if elapsed <= 0 {
+		return
+	}
+	decay := math.Pow(0.5, float64(elapsed)/float64(nodeHealthRecoveryHalfLife))
+	s.failureRate *= decay
+	s.invalidRate *= decay
+	s.latencyMs *= decay
+}
+
+func (h *nodeHealth) score(s *nodeStats) float64 {
+	latencyRefMs := float64(h.latencyRef.Milliseconds())
+	return (1 - s.failureRate) * (1 - s.invalidRate) / (1 + s.latencyMs/latencyRefMs)
+}
+
+// nodeScore returns the current score of the node.
+func (h *nodeHealth) nodeScore(nodeID rmntypes.NodeID) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.stats[nodeID]
+	if !ok {
+		return 1
+	}
+	decayed := *s
+	h.recover(&decayed, h.now())
+	return h.score(&decayed)
+}
+
+// isDemoted returns true if the node should not be counted on to respond to the initial requests.
+func (h *nodeHealth) isDemoted(nodeID rmntypes.NodeID) bool {
+	return h.nodeScore(nodeID) < demotedNodeScore
+}
+
+// rankNodes orders the items by descending node score, items of nodes with the same score are randomly ordered.
+func rankNodes[T any](h *nodeHealth, items []T, nodeID func(T) rmntypes.NodeID) []T {
+	ranked := randomShuffle(items)
+	scores := make(map[rmntypes.NodeID]float64, len(ranked))
+	for _, item := range ranked {
+		scores[nodeID(item)] = h.nodeScore(nodeID(item))
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[nodeID(ranked[i])] > scores[nodeID(ranked[j])]
+	})
+	return ranked
+}
+
+func ewma(prev, sample float64) float64 {
+	return nodeHealthEWMAAlpha*sample + (1-nodeHealthEWMAAlpha)*prev
+}
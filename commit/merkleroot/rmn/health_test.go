@@ -0,0 +1,90 @@
+package rmn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+)
+
+func newTestNodeHealth(now *time.Time) *nodeHealth {
+	h := newNodeHealth(time.Second)
+	h.now = func() time.Time { return *now }
+	return h
+}
+
+func Test_nodeHealth_record(t *testing.T) {
+	now := time.Now()
+	h := newTestNodeHealth(&now)
+
+	// Nodes that were never requested are considered healthy.
+	assert.Equal(t, 1.0, h.nodeScore(1))
+	assert.False(t, h.isDemoted(1))
+
+	// A node responding as slow as the initial request timer gets half the score.
+	assert.InDelta(t, 0.5, h.record(1, 1000, requestOutcomeOK), 1e-9)
+	assert.InDelta(t, 0.5, h.nodeScore(1), 1e-9)
+
+	// Fast responses improve the score.
+	for i := 0; i < 20; i++ {
+		h.record(1, 10, requestOutcomeOK)
+	}
+	assert.Greater(t, h.nodeScore(1), 0.95)
+
+	// Repeated failures and invalid responses demote the node.
+	for i := 0; i < 5; i++ {
+		h.record(2, 10, requestOutcomeFailure)
+		h.record(3, 10, requestOutcomeInvalid)
+	}
+	assert.True(t, h.isDemoted(2))
+	assert.True(t, h.isDemoted(3))
+	assert.False(t, h.isDemoted(1))
+
+	// The latency of failed requests is ignored.
+	h.record(4, 60_000, requestOutcomeFailure)
+	assert.InDelta(t, 1-nodeHealthEWMAAlpha, h.nodeScore(4), 1e-9)
+}
+
+func Test_nodeHealth_recovery(t *testing.T) {
+	now := time.Now()
+	h := newTestNodeHealth(&now)
+
+	for i := 0; i < 10; i++ {
+		h.record(1, 10, requestOutcomeFailure)
+	}
+	require.True(t, h.isDemoted(1))
+	before := h.nodeScore(1)
+
+	now = now.Add(nodeHealthRecoveryHalfLife)
+	assert.Greater(t, h.nodeScore(1), before)
+
+	now = now.Add(5 * nodeHealthRecoveryHalfLife)
+	assert.False(t, h.isDemoted(1))
+}
+
+func Test_rankNodes(t *testing.T) {
+	now := time.Now()
+	h := newTestNodeHealth(&now)
+
+	h.record(1, 900, requestOutcomeOK)
+	h.record(2, 10, requestOutcomeOK)
+	h.record(3, 10, requestOutcomeInvalid)
+	h.record(4, 100, requestOutcomeOK)
+
+	identity := func(id rmntypes.NodeID) rmntypes.NodeID { return id }
+	for i := 0; i < 10; i++ {
+		ranked := rankNodes(h, []rmntypes.NodeID{1, 2, 3, 4, 5}, identity)
+		// node 5 was never requested and comes first.
+		assert.Equal(t, []rmntypes.NodeID{5, 2, 4, 3, 1}, ranked)
+	}
+}
+
+func Test_requestOutcomeFromErr(t *testing.T) {
+	assert.Equal(t, requestOutcomeOK, requestOutcomeFromErr(""))
+	assert.Equal(t, requestOutcomeInvalid, requestOutcomeFromErr(rmnErrInvalidResponse))
+	assert.Equal(t, requestOutcomeFailure, requestOutcomeFromErr(rmnErrTimeout))
+	assert.Equal(t, requestOutcomeFailure, requestOutcomeFromErr(rmnErrFailedToSend))
+}
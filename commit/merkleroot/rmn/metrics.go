@@ -5,10 +5,21 @@ const (
 	RmnMethodReportSignature = "report_signature"
 )
 
+// Errors reported with TrackRmnRequest.
+const (
+	rmnErrFailedToSend    = "failed_to_send_request"
+	rmnErrInvalidResponse = "invalid_response"
+	rmnErrTimeout         = "timeout"
+)
+
 type MetricsReporter interface {
 	TrackRmnRequest(method string, latency float64, nodeID uint64, err string)
+	// TrackRmnNodeScore reports the health score (0 to 1) of an RMN node after each of its requests.
+	TrackRmnNodeScore(nodeID uint64, score float64)
 }
 
 type NoopMetrics struct{}
 
 func (n NoopMetrics) TrackRmnRequest(string, float64, uint64, string) {}
+
+func (n NoopMetrics) TrackRmnNodeScore(uint64, float64) {}
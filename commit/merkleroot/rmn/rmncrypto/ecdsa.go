@@ -0,0 +1,64 @@
+package rmncrypto
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// The RMN signatures only carry (r, s), the verifiers recover the signer with the recovery id set to 0 (v=27)
+// and compare it to the 20 bytes (ethereum style) address of the RMN node onchain key.
+
+// signReportHash signs the hash so that the signer is recovered with recovery id 0, signatures with the other
+// recovery id are flipped to the equivalent (r, n-s) signature.
+func signReportHash(key *ecdsa.PrivateKey, hash cciptypes.Bytes32) (cciptypes.RMNECDSASignature, error) {
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		return cciptypes.RMNECDSASignature{}, fmt.Errorf("sign report: %w", err)
+	}
+
+	var r, s cciptypes.Bytes32
+	copy(r[:], sig[:32])
+	copy(s[:], sig[32:64])
+	if sig[64] == 1 {
+		flipped := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(s[:]))
+		s = cciptypes.Bytes32{}
+		flipped.FillBytes(s[:])
+	}
+	return cciptypes.RMNECDSASignature{R: r, S: s}, nil
+}
+
+// verifyReportHashSignatures returns an error if any of the signatures of the hash is not from one of the signers.
+func verifyReportHashSignatures(
+	hash cciptypes.Bytes32,
+	sigs []cciptypes.RMNECDSASignature,
+	signerAddresses []cciptypes.UnknownAddress,
+) error {
+	signers := make(map[common.Address]struct{}, len(signerAddresses))
+	for _, addr := range signerAddresses {
+		if len(addr) != common.AddressLength {
+			return fmt.Errorf("invalid signer address %s", addr)
+		}
+		signers[common.BytesToAddress(addr)] = struct{}{}
+	}
+
+	for _, sig := range sigs {
+		raw := make([]byte, crypto.SignatureLength)
+		copy(raw[:32], sig.R[:])
+		copy(raw[32:64], sig.S[:])
+		pub, err := crypto.SigToPub(hash[:], raw)
+		if err != nil {
+			return fmt.Errorf("recover signer: %w", err)
+		}
+		addr := crypto.PubkeyToAddress(*pub)
+		if _, ok := signers[addr]; !ok {
+			return fmt.Errorf("signature from unexpected signer %s", addr)
+		}
+	}
+	return nil
+}
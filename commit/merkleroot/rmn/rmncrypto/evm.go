@@ -0,0 +1,97 @@
+package rmncrypto
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-ccip/chains/evm/gobindings/generated/latest/ccip_encoding_utils"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// evmReportArguments are the RMNRemote.verify arguments: abi.encode(reportVersionDigest, report).
+var evmReportArguments = func() abi.Arguments {
+	parsed, err := abi.JSON(strings.NewReader(ccip_encoding_utils.EncodingUtilsABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Methods["exposeRmnReport"].Inputs
+}()
+
+// EVM implements Backend for EVM destination chains, i.e. RMNRemote.verify.
+type EVM struct{}
+
+var _ Backend = EVM{}
+
+// ReportHash returns keccak256(abi.encode(reportVersionDigest, report)).
+func (EVM) ReportHash(report cciptypes.RMNReport) (cciptypes.Bytes32, error) {
+	encoded, err := abiEncodeEVMReport(report)
+	if err != nil {
+		return cciptypes.Bytes32{}, err
+	}
+	return cciptypes.Bytes32(crypto.Keccak256Hash(encoded)), nil
+}
+
+func abiEncodeEVMReport(report cciptypes.RMNReport) ([]byte, error) {
+	if len(report.RmnRemoteContractAddress) != common.AddressLength {
+		return nil, fmt.Errorf("invalid rmn remote address %s", report.RmnRemoteContractAddress)
+	}
+	if len(report.OfframpAddress) != common.AddressLength {
+		return nil, fmt.Errorf("invalid offramp address %s", report.OfframpAddress)
+	}
+	destChainID := big.NewInt(0)
+	if report.DestChainID.Int != nil {
+		destChainID = report.DestChainID.Int
+	}
+
+	merkleRoots := make([]ccip_encoding_utils.InternalMerkleRoot, 0, len(report.LaneUpdates))
+	for _, lu := range report.LaneUpdates {
+		merkleRoots = append(merkleRoots, ccip_encoding_utils.InternalMerkleRoot{
+			SourceChainSelector: uint64(lu.SourceChainSelector),
+			OnRampAddress:       lu.OnRampAddress,
+			MinSeqNr:            uint64(lu.MinSeqNr),
+			MaxSeqNr:            uint64(lu.MaxSeqNr),
+			MerkleRoot:          lu.MerkleRoot,
+		})
+	}
+
+	encoded, err := evmReportArguments.Pack(report.ReportVersionDigest, ccip_encoding_utils.RMNRemoteReport{
+		DestChainId:                 destChainID,
+		DestChainSelector:           uint64(report.DestChainSelector),
+		RmnRemoteContractAddress:    common.BytesToAddress(report.RmnRemoteContractAddress),
+		OfframpAddress:              common.BytesToAddress(report.OfframpAddress),
+		RmnHomeContractConfigDigest: report.RmnHomeContractConfigDigest,
+		MerkleRoots:                 merkleRoots,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("abi encode report: %w", err)
+	}
+	return encoded, nil
+}
+
+func (e EVM) SignReport(key *ecdsa.PrivateKey, report cciptypes.RMNReport) (cciptypes.RMNECDSASignature, error) {
+	hash, err := e.ReportHash(report)
+	if err != nil {
+		return cciptypes.RMNECDSASignature{}, err
+	}
+	return signReportHash(key, hash)
+}
+
+func (e EVM) VerifyReportSignatures(
+	_ context.Context,
+	sigs []cciptypes.RMNECDSASignature,
+	report cciptypes.RMNReport,
+	signerAddresses []cciptypes.UnknownAddress,
+) error {
+	hash, err := e.ReportHash(report)
+	if err != nil {
+		return err
+	}
+	return verifyReportHashSignatures(hash, sigs, signerAddresses)
+}
@@ -0,0 +1,88 @@
+// Package rmncrypto contains the cciptypes.RMNCrypto implementations of the destination chain families, so that
+// RMN report signatures can be verified, and produced in tests, without the chain specific relayers.
+//
+// Only EVM has a backend. Solana has none until the RMN remote program verifies report signatures, a backend
+// cannot be checked against the program before then, see ErrUnverifiedFamily. The other chain families can Register
+// their backend.
+package rmncrypto
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// Backend verifies and signs RMN reports the way the destination chains of a chain family do.
+type Backend interface {
+	cciptypes.RMNCrypto
+
+	// ReportHash returns the digest of the report that is signed by the RMN nodes.
+	ReportHash(report cciptypes.RMNReport) (cciptypes.Bytes32, error)
+
+	// SignReport signs the report so that it passes VerifyReportSignatures, it is used by tests and simulated RMN
+	// nodes, real RMN nodes sign the reports themselves.
+	SignReport(key *ecdsa.PrivateKey, report cciptypes.RMNReport) (cciptypes.RMNECDSASignature, error)
+}
+
+// ErrUnverifiedFamily is returned for the chain families whose onchain RMN contracts don't verify the report
+// signatures yet, so that there is no reference to build and test their backend against.
+var ErrUnverifiedFamily = errors.New("the RMN remote of the chain family doesn't verify report signatures")
+
+var (
+	mu       sync.RWMutex
+	backends = map[string]Backend{
+		chainsel.FamilyEVM: EVM{},
+	}
+	// unverifiedFamilies are the families without a backend because of ErrUnverifiedFamily.
+	unverifiedFamilies = map[string]struct{}{
+		chainsel.FamilySolana: {},
+	}
+)
+
+// Register registers the backend of a chain family, replacing the previously registered one if any.
+func Register(family string, backend Backend) {
+	mu.Lock()
+	defer mu.Unlock()
+	backends[family] = backend
+}
+
+// Get returns the backend of the chain family.
+func Get(family string) (Backend, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	backend, ok := backends[family]
+	if !ok {
+		if _, unverified := unverifiedFamilies[family]; unverified {
+			return nil, fmt.Errorf("no rmn crypto backend for chain family %q: %w", family, ErrUnverifiedFamily)
+		}
+		return nil, fmt.Errorf("no rmn crypto backend registered for chain family %q", family)
+	}
+	return backend, nil
+}
+
+// GetForChain returns the backend of the family of the destination chain.
+func GetForChain(chainSel cciptypes.ChainSelector) (Backend, error) {
+	family, err := chainsel.GetSelectorFamily(uint64(chainSel))
+	if err != nil {
+		return nil, fmt.Errorf("get chain family of %d: %w", chainSel, err)
+	}
+	return Get(family)
+}
+
+// Families returns the sorted chain families with a registered backend.
+func Families() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	families := make([]string, 0, len(backends))
+	for family := range backends {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	return families
+}
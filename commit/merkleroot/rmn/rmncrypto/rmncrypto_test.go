@@ -0,0 +1,248 @@
+package rmncrypto
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+var update = flag.Bool("update", false, "regenerate the test vectors")
+
+const vectorsFile = "testdata/vectors.json"
+
+// testVectors pin the report hashes and signatures of the backends, regenerate them with -update. The EVM
+// preimages are the RMNRemote.verify arguments encoded with the ABI of the contract bindings.
+type testVectors struct {
+	// SignerKeys are the hex encoded secp256k1 private keys of the signers.
+	SignerKeys []string     `json:"signerKeys"`
+	Vectors    []testVector `json:"vectors"`
+}
+
+type testVector struct {
+	Name   string              `json:"name"`
+	Family string              `json:"family"`
+	Report cciptypes.RMNReport `json:"report"`
+	// Preimage is the encoded report that is hashed.
+	Preimage   cciptypes.Bytes               `json:"preimage"`
+	ReportHash cciptypes.Bytes32             `json:"reportHash"`
+	Signatures []cciptypes.RMNECDSASignature `json:"signatures"`
+}
+
+func testReports() []testVector {
+	evmAddr := func(b byte) cciptypes.UnknownAddress {
+		return common.BytesToAddress([]byte{b}).Bytes()
+	}
+	laneUpdates := []cciptypes.RMNLaneUpdate{
+		{
+			SourceChainSelector: cciptypes.ChainSelector(chainsel.ETHEREUM_MAINNET.Selector),
+			OnRampAddress:       common.LeftPadBytes([]byte{0x1}, 32),
+			MinSeqNr:            1,
+			MaxSeqNr:            10,
+			MerkleRoot:          cciptypes.Bytes32{0x1, 0x2, 0x3},
+		},
+		{
+			SourceChainSelector: cciptypes.ChainSelector(chainsel.SOLANA_MAINNET.Selector),
+			OnRampAddress:       common.LeftPadBytes([]byte{0x2}, 32),
+			MinSeqNr:            100,
+			MaxSeqNr:            100,
+			MerkleRoot:          cciptypes.Bytes32{0x4, 0x5, 0x6},
+		},
+	}
+	digest := cciptypes.Bytes32{0xa, 0xb, 0xc}
+
+	return []testVector{
+		{
+			Name:   "evm no lane updates",
+			Family: chainsel.FamilyEVM,
+			Report: cciptypes.NewRMNReport(
+				cciptypes.Bytes32(crypto.Keccak256([]byte("RMN_V1_6_ANY2EVM_REPORT"))),
+				cciptypes.NewBigIntFromInt64(1),
+				cciptypes.ChainSelector(chainsel.ETHEREUM_MAINNET.Selector),
+				evmAddr(0xaa), evmAddr(0xff), digest, []cciptypes.RMNLaneUpdate{},
+			),
+		},
+		{
+			Name:   "evm lane updates",
+			Family: chainsel.FamilyEVM,
+			Report: cciptypes.NewRMNReport(
+				cciptypes.Bytes32(crypto.Keccak256([]byte("RMN_V1_6_ANY2EVM_REPORT"))),
+				cciptypes.NewBigIntFromInt64(1),
+				cciptypes.ChainSelector(chainsel.ETHEREUM_MAINNET.Selector),
+				evmAddr(0xaa), evmAddr(0xff), digest, laneUpdates,
+			),
+		},
+	}
+}
+
+// preimage returns the encoded report of the backends in this package.
+func preimage(t *testing.T, family string, report cciptypes.RMNReport) []byte {
+	switch family {
+	case chainsel.FamilyEVM:
+		encoded, err := abiEncodeEVMReport(report)
+		require.NoError(t, err)
+		return encoded
+	default:
+		t.Fatalf("unknown family %s", family)
+		return nil
+	}
+}
+
+func generateVectors(t *testing.T) testVectors {
+	vectors := testVectors{}
+	for i := 1; i <= 3; i++ {
+		seed := crypto.Keccak256([]byte{byte(i)})
+		vectors.SignerKeys = append(vectors.SignerKeys, common.Bytes2Hex(seed))
+	}
+
+	for _, v := range testReports() {
+		backend, err := Get(v.Family)
+		require.NoError(t, err)
+		v.Preimage = preimage(t, v.Family, v.Report)
+		v.ReportHash, err = backend.ReportHash(v.Report)
+		require.NoError(t, err)
+		for _, hexKey := range vectors.SignerKeys {
+			key, err := crypto.HexToECDSA(hexKey)
+			require.NoError(t, err)
+			sig, err := backend.SignReport(key, v.Report)
+			require.NoError(t, err)
+			v.Signatures = append(v.Signatures, sig)
+		}
+		vectors.Vectors = append(vectors.Vectors, v)
+	}
+	return vectors
+}
+
+func TestVectors(t *testing.T) {
+	if *update {
+		b, err := json.MarshalIndent(generateVectors(t), "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(vectorsFile), 0o755))
+		require.NoError(t, os.WriteFile(vectorsFile, append(b, '\n'), 0o600))
+	}
+
+	b, err := os.ReadFile(vectorsFile)
+	require.NoError(t, err)
+	var vectors testVectors
+	require.NoError(t, json.Unmarshal(b, &vectors))
+	require.Len(t, vectors.Vectors, len(testReports()))
+
+	var signers []cciptypes.UnknownAddress
+	for _, hexKey := range vectors.SignerKeys {
+		key, err := crypto.HexToECDSA(hexKey)
+		require.NoError(t, err)
+		signers = append(signers, crypto.PubkeyToAddress(key.PublicKey).Bytes())
+	}
+
+	for _, v := range vectors.Vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			backend, err := Get(v.Family)
+			require.NoError(t, err)
+
+			assert.Equal(t, []byte(v.Preimage), preimage(t, v.Family, v.Report))
+			assert.Equal(t, cciptypes.Bytes32(crypto.Keccak256(v.Preimage)), v.ReportHash)
+			hash, err := backend.ReportHash(v.Report)
+			require.NoError(t, err)
+			assert.Equal(t, v.ReportHash, hash)
+
+			for i, hexKey := range vectors.SignerKeys {
+				key, err := crypto.HexToECDSA(hexKey)
+				require.NoError(t, err)
+				sig, err := backend.SignReport(key, v.Report)
+				require.NoError(t, err)
+				assert.Equal(t, v.Signatures[i], sig)
+			}
+			require.NoError(t, backend.VerifyReportSignatures(tests.Context(t), v.Signatures, v.Report, signers))
+
+			// A signature of another signer is rejected.
+			require.Error(t, backend.VerifyReportSignatures(tests.Context(t), v.Signatures, v.Report, signers[1:]))
+
+			// Signatures of a different report are rejected.
+			tampered := v.Report
+			tampered.RmnHomeContractConfigDigest = cciptypes.Bytes32{0x1}
+			require.Error(t, backend.VerifyReportSignatures(tests.Context(t), v.Signatures, tampered, signers))
+		})
+	}
+}
+
+func TestBackends_SignAndVerify(t *testing.T) {
+	for _, v := range testReports() {
+		t.Run(v.Name, func(t *testing.T) {
+			backend, err := Get(v.Family)
+			require.NoError(t, err)
+
+			// Enough keys to cover both recovery ids.
+			for i := 0; i < 20; i++ {
+				key, err := crypto.GenerateKey()
+				require.NoError(t, err)
+				sig, err := backend.SignReport(key, v.Report)
+				require.NoError(t, err)
+
+				signer := cciptypes.UnknownAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes())
+				require.NoError(t, backend.VerifyReportSignatures(
+					tests.Context(t), []cciptypes.RMNECDSASignature{sig}, v.Report, []cciptypes.UnknownAddress{signer}))
+
+				other := cciptypes.UnknownAddress(common.HexToAddress("0x1234").Bytes())
+				require.Error(t, backend.VerifyReportSignatures(
+					tests.Context(t), []cciptypes.RMNECDSASignature{sig}, v.Report, []cciptypes.UnknownAddress{other}))
+			}
+		})
+	}
+}
+
+func TestBackends_InvalidAddresses(t *testing.T) {
+	for _, v := range testReports() {
+		t.Run(v.Name, func(t *testing.T) {
+			backend, err := Get(v.Family)
+			require.NoError(t, err)
+
+			report := v.Report
+			report.OfframpAddress = append(cciptypes.UnknownAddress{0x1}, report.OfframpAddress...)
+			_, err = backend.ReportHash(report)
+			require.Error(t, err)
+
+			report = v.Report
+			report.RmnRemoteContractAddress = report.RmnRemoteContractAddress[1:]
+			_, err = backend.ReportHash(report)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	assert.Equal(t, []string{chainsel.FamilyEVM}, Families())
+
+	backend, err := GetForChain(cciptypes.ChainSelector(chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector))
+	require.NoError(t, err)
+	assert.Equal(t, EVM{}, backend)
+
+	_, err = GetForChain(cciptypes.ChainSelector(chainsel.SOLANA_DEVNET.Selector))
+	require.ErrorIs(t, err, ErrUnverifiedFamily)
+	_, err = GetForChain(cciptypes.ChainSelector(chainsel.APTOS_MAINNET.Selector))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrUnverifiedFamily)
+	_, err = GetForChain(1)
+	require.Error(t, err)
+
+	Register(chainsel.FamilyAptos, EVM{})
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(backends, chainsel.FamilyAptos)
+	})
+	backend, err = GetForChain(cciptypes.ChainSelector(chainsel.APTOS_MAINNET.Selector))
+	require.NoError(t, err)
+	assert.Equal(t, EVM{}, backend)
+}
@@ -0,0 +1,80 @@
+// home.go contains an in-memory RMNHome reader serving the simulated network config.
+
+package rmnsim
+
+import (
+	"context"
+	"fmt"
+
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	readerpkg "github.com/smartcontractkit/chainlink-ccip/pkg/reader"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// Home is an RMNHome reader with the network as the only (active) config.
+type Home struct {
+	network *Network
+}
+
+var _ readerpkg.RMNHome = (*Home)(nil)
+
+func (h *Home) checkDigest(configDigest cciptypes.Bytes32) error {
+	if configDigest != h.network.cfg.ConfigDigest {
+		return fmt.Errorf("unknown config digest %s", configDigest)
+	}
+	return nil
+}
+
+func (h *Home) GetRMNNodesInfo(configDigest cciptypes.Bytes32) ([]rmntypes.HomeNodeInfo, error) {
+	if err := h.checkDigest(configDigest); err != nil {
+		return nil, err
+	}
+	return h.network.HomeNodes(), nil
+}
+
+func (h *Home) IsRMNHomeConfigDigestSet(configDigest cciptypes.Bytes32) bool {
+	return h.checkDigest(configDigest) == nil
+}
+
+func (h *Home) GetRMNEnabledSourceChains(configDigest cciptypes.Bytes32) (map[cciptypes.ChainSelector]bool, error) {
+	if err := h.checkDigest(configDigest); err != nil {
+		return nil, err
+	}
+	enabled := make(map[cciptypes.ChainSelector]bool, len(h.network.cfg.FObserve))
+	for chain := range h.network.cfg.FObserve {
+		enabled[chain] = true
+	}
+	return enabled, nil
+}
+
+func (h *Home) GetFObserve(configDigest cciptypes.Bytes32) (map[cciptypes.ChainSelector]int, error) {
+	if err := h.checkDigest(configDigest); err != nil {
+		return nil, err
+	}
+	fObserve := make(map[cciptypes.ChainSelector]int, len(h.network.cfg.FObserve))
+	for chain, f := range h.network.cfg.FObserve {
+		fObserve[chain] = f
+	}
+	return fObserve, nil
+}
+
+func (h *Home) GetOffChainConfig(configDigest cciptypes.Bytes32) (cciptypes.Bytes, error) {
+	if err := h.checkDigest(configDigest); err != nil {
+		return nil, err
+	}
+	return cciptypes.Bytes{}, nil
+}
+
+func (h *Home) GetAllConfigDigests() (activeConfigDigest cciptypes.Bytes32, candidateConfigDigest cciptypes.Bytes32) {
+	return h.network.cfg.ConfigDigest, cciptypes.Bytes32{}
+}
+
+func (h *Home) Start(context.Context) error { return nil }
+
+func (h *Home) Close() error { return nil }
+
+func (h *Home) Ready() error { return nil }
+
+func (h *Home) HealthReport() map[string]error { return map[string]error{h.Name(): nil} }
+
+func (h *Home) Name() string { return "rmnsim.Home" }
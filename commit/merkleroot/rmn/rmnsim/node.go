@@ -0,0 +1,317 @@
+// node.go contains the simulated RMN node, handling observation and report signature requests.
+
+package rmnsim
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	ragep2ptypes "github.com/smartcontractkit/libocr/ragep2p/types"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// Node is a simulated RMN node.
+type Node struct {
+	lggr    logger.Logger
+	network *Network
+	id      rmntypes.NodeID
+
+	supportedSourceChains mapset.Set[cciptypes.ChainSelector]
+
+	offchainKey ed25519.PrivateKey
+	onchainKey  *ecdsa.PrivateKey
+
+	mu       sync.RWMutex
+	behavior Behavior
+
+	observationRequests     atomic.Int64
+	reportSignatureRequests atomic.Int64
+}
+
+func newNode(network *Network, id rmntypes.NodeID, cfg NodeConfig) (*Node, error) {
+	_, offchainKey, err := ed25519.GenerateKey(crand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate offchain key: %w", err)
+	}
+	onchainKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate onchain key: %w", err)
+	}
+
+	return &Node{
+		lggr:                  logger.With(network.lggr, "rmnNode", id),
+		network:               network,
+		id:                    id,
+		supportedSourceChains: mapset.NewSet(cfg.SupportedSourceChains...),
+		offchainKey:           offchainKey,
+		onchainKey:            onchainKey,
+		behavior:              cfg.Behavior,
+	}, nil
+}
+
+// ID returns the node index in the RMN config.
+func (n *Node) ID() rmntypes.NodeID {
+	return n.id
+}
+
+// Behavior returns the current behavior of the node.
+func (n *Node) Behavior() Behavior {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.behavior
+}
+
+// SetBehavior changes the behavior of the node, it applies to requests received afterwards.
+func (n *Node) SetBehavior(b Behavior) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.behavior = b
+}
+
+// Requests returns the number of observation and report signature requests the node received.
+func (n *Node) Requests() (observations, reportSignatures int) {
+	return int(n.observationRequests.Load()), int(n.reportSignatureRequests.Load())
+}
+
+// HomeNodeInfo returns the RMNHome view of the node.
+func (n *Node) HomeNodeInfo() rmntypes.HomeNodeInfo {
+	pub, _ := n.offchainKey.Public().(ed25519.PublicKey)
+	return rmntypes.HomeNodeInfo{
+		ID:                    n.id,
+		PeerID:                ragep2ptypes.PeerID(pub),
+		SupportedSourceChains: n.supportedSourceChains.Clone(),
+		OffchainPublicKey:     &pub,
+		StreamNamePrefix:      streamNamePrefix,
+	}
+}
+
+// RemoteSignerInfo returns the RMNRemote view of the node.
+func (n *Node) RemoteSignerInfo() cciptypes.RemoteSignerInfo {
+	return cciptypes.RemoteSignerInfo{
+		OnchainPublicKey: crypto.PubkeyToAddress(n.onchainKey.PublicKey).Bytes(),
+		NodeIndex:        uint64(n.id),
+	}
+}
+
+// handle processes a request, false is returned if the node doesn't respond to it.
+func (n *Node) handle(req *rmnpb.Request) (*rmnpb.Response, bool) {
+	lggr := logger.With(n.lggr, "requestID", req.RequestId)
+	switch r := req.Request.(type) {
+	case *rmnpb.Request_ObservationRequest:
+		n.observationRequests.Add(1)
+		signedObs, err := n.observe(r.ObservationRequest)
+		if err != nil {
+			lggr.Errorw("failed to observe", "err", err)
+			return nil, false
+		}
+		return &rmnpb.Response{
+			RequestId: req.RequestId,
+			Response:  &rmnpb.Response_SignedObservation{SignedObservation: signedObs},
+		}, true
+	case *rmnpb.Request_ReportSignatureRequest:
+		n.reportSignatureRequests.Add(1)
+		sig, err := n.signReport(r.ReportSignatureRequest)
+		if err != nil {
+			lggr.Errorw("failed to sign report", "err", err)
+			return nil, false
+		}
+		return &rmnpb.Response{
+			RequestId: req.RequestId,
+			Response: &rmnpb.Response_ReportSignature{ReportSignature: &rmnpb.ReportSignature{
+				Signature: &rmnpb.EcdsaSignature{R: sig.R[:], S: sig.S[:]},
+			}},
+		}, true
+	default:
+		lggr.Errorw("unexpected request type", "type", fmt.Sprintf("%T", req.Request))
+		return nil, false
+	}
+}
+
+// root returns the merkle root the node observes for the interval.
+func (n *Node) root(source *rmnpb.LaneSource, interval *rmnpb.ClosedInterval) cciptypes.Bytes32 {
+	if n.Behavior().Equivocate {
+		return n.network.randomBytes32()
+	}
+	return n.network.cfg.Roots(source, interval)
+}
+
+// observe signs the roots of the requested intervals for the supported source chains.
+func (n *Node) observe(req *rmnpb.ObservationRequest) (*rmnpb.SignedObservation, error) {
+	digest := n.network.cfg.ConfigDigest
+	obs := &rmnpb.Observation{
+		RmnHomeContractConfigDigest: digest[:],
+		LaneDest:                    req.LaneDest,
+		Timestamp:                   uint64(time.Now().UnixMilli()),
+	}
+	for _, lur := range req.FixedDestLaneUpdateRequests {
+		if !n.supportedSourceChains.Contains(cciptypes.ChainSelector(lur.LaneSource.SourceChainSelector)) {
+			continue
+		}
+		root := n.root(lur.LaneSource, lur.ClosedInterval)
+		obs.FixedDestLaneUpdates = append(obs.FixedDestLaneUpdates, &rmnpb.FixedDestLaneUpdate{
+			LaneSource:     lur.LaneSource,
+			ClosedInterval: lur.ClosedInterval,
+			Root:           root[:],
+		})
+	}
+
+	key := n.offchainKey
+	if n.Behavior().WrongObservationSignature {
+		_, wrongKey, err := ed25519.GenerateKey(crand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		key = wrongKey
+	}
+
+	msg, err := rmn.ObservationSigningMessage(n.network.cfg.SignObservationPrefix, obs)
+	if err != nil {
+		return nil, err
+	}
+	return &rmnpb.SignedObservation{
+		Observation: obs,
+		Signature:   ed25519.Sign(key, msg),
+	}, nil
+}
+
+// signReport signs the report built from the roots that at least F+1 of the attributed
+// observations agree on, like RMNRemote expects.
+func (n *Node) signReport(req *rmnpb.ReportSignatureRequest) (cciptypes.RMNECDSASignature, error) {
+	cfg := n.network.cfg
+	if req.Context == nil || req.Context.LaneDest == nil {
+		return cciptypes.RMNECDSASignature{}, fmt.Errorf("missing report context")
+	}
+	if !bytes.Equal(req.Context.RmnHomeContractConfigDigest, cfg.ConfigDigest[:]) {
+		return cciptypes.RMNECDSASignature{}, fmt.Errorf("unexpected config digest %x",
+			req.Context.RmnHomeContractConfigDigest)
+	}
+
+	laneUpdates, err := n.agreedLaneUpdates(req.AttributedSignedObservations)
+	if err != nil {
+		return cciptypes.RMNECDSASignature{}, err
+	}
+	if len(laneUpdates) == 0 {
+		return cciptypes.RMNECDSASignature{}, fmt.Errorf("no lane update has enough matching observations")
+	}
+
+	report := cciptypes.NewRMNReport(
+		cfg.ReportVersionDigest,
+		cciptypes.NewBigIntFromInt64(int64(req.Context.EvmDestChainId)),
+		cciptypes.ChainSelector(req.Context.LaneDest.DestChainSelector),
+		req.Context.RmnRemoteContractAddress,
+		req.Context.LaneDest.OfframpAddress,
+		cfg.ConfigDigest,
+		laneUpdates,
+	)
+
+	key := n.onchainKey
+	if n.Behavior().WrongReportSignature {
+		if key, err = crypto.GenerateKey(); err != nil {
+			return cciptypes.RMNECDSASignature{}, err
+		}
+	}
+	return cfg.ReportSigner.SignReport(key, report)
+}
+
+// agreedLaneUpdates counts the votes of the validly signed observations for each source chain
+// root and returns a lane update for every chain with a root observed by at least F+1 nodes.
+func (n *Node) agreedLaneUpdates(
+	observations []*rmnpb.AttributedSignedObservation,
+) ([]cciptypes.RMNLaneUpdate, error) {
+	type vote struct {
+		update *rmnpb.FixedDestLaneUpdate
+		voters mapset.Set[uint32]
+	}
+	// voteKey is a lane update of a source chain, nodes only agree on a root for the same
+	// onramp and interval.
+	type voteKey struct {
+		onRamp   string
+		min, max uint64
+		root     cciptypes.Bytes32
+	}
+	votes := make(map[uint64]map[voteKey]*vote)
+
+	for _, aso := range observations {
+		signer := n.network.Node(rmntypes.NodeID(aso.SignerNodeIndex))
+		if signer == nil || aso.SignedObservation == nil || aso.SignedObservation.Observation == nil {
+			continue
+		}
+		msg, err := rmn.ObservationSigningMessage(n.network.cfg.SignObservationPrefix, aso.SignedObservation.Observation)
+		if err != nil {
+			return nil, err
+		}
+		pub, _ := signer.offchainKey.Public().(ed25519.PublicKey)
+		if !ed25519.Verify(pub, msg, aso.SignedObservation.Signature) {
+			n.lggr.Warnw("ignoring observation with an invalid signature", "signer", aso.SignerNodeIndex)
+			continue
+		}
+
+		for _, lu := range aso.SignedObservation.Observation.FixedDestLaneUpdates {
+			if lu.LaneSource == nil || lu.ClosedInterval == nil {
+				continue
+			}
+			chain := lu.LaneSource.SourceChainSelector
+			if votes[chain] == nil {
+				votes[chain] = make(map[voteKey]*vote)
+			}
+			key := voteKey{
+				onRamp: string(lu.LaneSource.OnrampAddress),
+				min:    lu.ClosedInterval.MinMsgNr,
+				max:    lu.ClosedInterval.MaxMsgNr,
+				root:   cciptypes.Bytes32(lu.Root),
+			}
+			if votes[chain][key] == nil {
+				votes[chain][key] = &vote{update: lu, voters: mapset.NewSet[uint32]()}
+			}
+			votes[chain][key].voters.Add(aso.SignerNodeIndex)
+		}
+	}
+
+	var laneUpdates []cciptypes.RMNLaneUpdate
+	for chain, roots := range votes {
+		f, ok := n.network.cfg.FObserve[cciptypes.ChainSelector(chain)]
+		if !ok {
+			continue
+		}
+		for key, v := range roots {
+			if v.voters.Cardinality() < f+1 {
+				continue
+			}
+			root := key.root
+			if n.Behavior().Equivocate {
+				root = n.root(v.update.LaneSource, v.update.ClosedInterval)
+			}
+			laneUpdates = append(laneUpdates, cciptypes.RMNLaneUpdate{
+				SourceChainSelector: cciptypes.ChainSelector(chain),
+				// Observations carry 20 byte EVM addresses, reports use the abi encoded address.
+				OnRampAddress: common.LeftPadBytes(v.update.LaneSource.OnrampAddress, 32),
+				MinSeqNr:      cciptypes.SeqNum(v.update.ClosedInterval.MinMsgNr),
+				MaxSeqNr:      cciptypes.SeqNum(v.update.ClosedInterval.MaxMsgNr),
+				MerkleRoot:    root,
+			})
+			break
+		}
+	}
+	sort.Slice(laneUpdates, func(i, j int) bool {
+		return laneUpdates[i].SourceChainSelector < laneUpdates[j].SourceChainSelector
+	})
+	return laneUpdates, nil
+}
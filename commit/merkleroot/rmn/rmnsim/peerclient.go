@@ -0,0 +1,114 @@
+// peerclient.go contains the rmn.PeerClient implementation that talks to the simulated nodes.
+
+package rmnsim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	ragep2ptypes "github.com/smartcontractkit/libocr/ragep2p/types"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// PeerClient routes the requests of a single oracle to the simulated nodes.
+type PeerClient struct {
+	network  *Network
+	respChan chan rmn.PeerResponse
+
+	mu sync.Mutex
+	// done is closed when the connection is closed, pending responses are discarded.
+	done chan struct{}
+}
+
+var _ rmn.PeerClient = (*PeerClient)(nil)
+
+func (c *PeerClient) InitConnection(
+	_ context.Context,
+	_ cciptypes.Bytes32,
+	_ cciptypes.Bytes32,
+	_ []ragep2ptypes.PeerID,
+	_ []rmntypes.HomeNodeInfo,
+) error {
+	if err := c.Close(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = make(chan struct{})
+	return nil
+}
+
+func (c *PeerClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done != nil {
+		close(c.done)
+		c.done = nil
+	}
+	return nil
+}
+
+// Send hands the request to the node, the response is delivered through Recv after the
+// node's latency unless the node drops the request.
+func (c *PeerClient) Send(rmnNode rmntypes.HomeNodeInfo, request []byte) error {
+	c.mu.Lock()
+	done := c.done
+	c.mu.Unlock()
+	if done == nil {
+		return rmn.ErrNoConn
+	}
+
+	node := c.network.Node(rmnNode.ID)
+	if node == nil {
+		return fmt.Errorf("unknown rmn node %d", rmnNode.ID)
+	}
+
+	req := &rmnpb.Request{}
+	if err := proto.Unmarshal(request, req); err != nil {
+		return fmt.Errorf("proto unmarshal request: %w", err)
+	}
+
+	go func() {
+		resp, ok := node.handle(req)
+		if !ok {
+			return
+		}
+		body, err := proto.Marshal(resp)
+		if err != nil {
+			node.lggr.Errorw("failed to marshal response", "err", err)
+			return
+		}
+		delay, ok := c.network.delay(node.Behavior())
+		if !ok {
+			node.lggr.Debugw("dropping request", "requestID", req.RequestId)
+			return
+		}
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-done:
+			return
+		}
+		select {
+		case c.respChan <- rmn.PeerResponse{RMNNodeID: node.id, Body: body}:
+		case <-done:
+		}
+	}()
+	return nil
+}
+
+func (c *PeerClient) Recv() <-chan rmn.PeerResponse {
+	return c.respChan
+}
@@ -0,0 +1,217 @@
+// Package rmnsim simulates a network of RMN nodes speaking the rmn_offchain.proto protocol.
+//
+// Nodes sign observations with ed25519 and reports with ECDSA like real RMN nodes do, so the
+// commit plugin can be run end-to-end against them. Each node can be configured to respond
+// slowly, drop requests, equivocate or produce invalid signatures.
+//
+// Reports are signed with the rmncrypto backend of the destination chain family, EVM by default.
+//
+//	network, err := rmnsim.NewNetwork(lggr, cfg, nodes)
+//	peerClient := network.NewPeerClient() // one per oracle
+//	controller := rmn.NewController(lggr, rmncrypto.EVM{}, cfg.SignObservationPrefix,
+//		peerClient, network.Home(), ...)
+package rmnsim
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmncrypto"
+	rmntypes "github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// DefaultSignObservationPrefix is the prefix RMN nodes use when signing observations.
+const DefaultSignObservationPrefix = "chainlink ccip 1.6 rmn observation"
+
+// streamNamePrefix is the RageP2P stream name prefix of RMN nodes.
+const streamNamePrefix = "ccip-rmn/v1_6/"
+
+// RootFunc computes the merkle root of the messages in the interval, it must be deterministic
+// so that honest nodes agree on the roots.
+type RootFunc func(source *rmnpb.LaneSource, interval *rmnpb.ClosedInterval) cciptypes.Bytes32
+
+// DefaultRoots derives a root from the lane and interval. Use a RootFunc that computes the
+// actual merkle root when the roots are compared with the ones computed by the plugin.
+func DefaultRoots(source *rmnpb.LaneSource, interval *rmnpb.ClosedInterval) cciptypes.Bytes32 {
+	h := sha256.New()
+	h.Write(binary.BigEndian.AppendUint64(nil, source.SourceChainSelector))
+	h.Write(source.OnrampAddress)
+	h.Write(binary.BigEndian.AppendUint64(nil, interval.MinMsgNr))
+	h.Write(binary.BigEndian.AppendUint64(nil, interval.MaxMsgNr))
+	return cciptypes.Bytes32(h.Sum(nil))
+}
+
+// ReportSigner signs RMN reports the way the destination chain family expects them, see rmncrypto.Backend.
+type ReportSigner interface {
+	SignReport(key *ecdsa.PrivateKey, report cciptypes.RMNReport) (cciptypes.RMNECDSASignature, error)
+}
+
+// Config is shared by all nodes of the network, it mirrors the RMNHome and RMNRemote config.
+type Config struct {
+	// ConfigDigest is used as both the RMNHome and the RMNRemote config digest.
+	ConfigDigest cciptypes.Bytes32
+	// ReportVersionDigest is the RMNRemote report version, e.g. keccak256("RMN_V1_6_ANY2EVM_REPORT").
+	ReportVersionDigest cciptypes.Bytes32
+	// SignObservationPrefix defaults to DefaultSignObservationPrefix.
+	SignObservationPrefix string
+	// FObserve is the RMNHome F of each RMN-enabled source chain.
+	FObserve map[cciptypes.ChainSelector]int
+	// Roots defaults to DefaultRoots.
+	Roots RootFunc
+	// ReportSigner defaults to rmncrypto.EVM.
+	ReportSigner ReportSigner
+	// Seed makes the latency jitter and drops reproducible, a time based seed is used when 0.
+	Seed int64
+}
+
+// NodeConfig configures a single simulated node.
+type NodeConfig struct {
+	SupportedSourceChains []cciptypes.ChainSelector
+	Behavior              Behavior
+}
+
+// Behavior controls how a node deviates from an honest and responsive RMN node.
+type Behavior struct {
+	// Latency is how long the node takes to respond, Jitter adds a random duration up to its value.
+	Latency time.Duration
+	Jitter  time.Duration
+	// DropRate is the probability of a request never getting a response, 1 drops every request.
+	DropRate float64
+	// Equivocate makes the node observe roots that don't match the other nodes and sign reports
+	// containing them.
+	Equivocate bool
+	// WrongObservationSignature signs observations with a key that isn't in the RMNHome config.
+	WrongObservationSignature bool
+	// WrongReportSignature signs reports with a key that isn't in the RMNRemote config.
+	WrongReportSignature bool
+}
+
+// Network is a set of simulated RMN nodes.
+type Network struct {
+	lggr  logger.Logger
+	cfg   Config
+	nodes []*Node
+
+	// rndMu guards rnd which is shared by all nodes.
+	rndMu sync.Mutex
+	rnd   *rand.Rand
+}
+
+// NewNetwork creates a node for each config with freshly generated keys, the node IDs are the
+// indexes of the configs.
+func NewNetwork(lggr logger.Logger, cfg Config, nodeConfigs []NodeConfig) (*Network, error) {
+	if cfg.SignObservationPrefix == "" {
+		cfg.SignObservationPrefix = DefaultSignObservationPrefix
+	}
+	if cfg.Roots == nil {
+		cfg.Roots = DefaultRoots
+	}
+	if cfg.ReportSigner == nil {
+		cfg.ReportSigner = rmncrypto.EVM{}
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	n := &Network{
+		lggr: lggr,
+		cfg:  cfg,
+		//nolint:gosec // used for simulated latency and drops only.
+		rnd: rand.New(rand.NewSource(cfg.Seed)),
+	}
+	for i, nodeCfg := range nodeConfigs {
+		node, err := newNode(n, rmntypes.NodeID(i), nodeCfg)
+		if err != nil {
+			return nil, fmt.Errorf("create node %d: %w", i, err)
+		}
+		n.nodes = append(n.nodes, node)
+	}
+	return n, nil
+}
+
+// Nodes returns the simulated nodes ordered by ID.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Node returns the node with the ID or nil.
+func (n *Network) Node(id rmntypes.NodeID) *Node {
+	if int(id) >= len(n.nodes) {
+		return nil
+	}
+	return n.nodes[id]
+}
+
+// HomeNodes returns the RMNHome view of the nodes.
+func (n *Network) HomeNodes() []rmntypes.HomeNodeInfo {
+	infos := make([]rmntypes.HomeNodeInfo, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		infos = append(infos, node.HomeNodeInfo())
+	}
+	return infos
+}
+
+// RemoteConfig returns the RMNRemote config with every node as a signer.
+func (n *Network) RemoteConfig(contractAddress cciptypes.UnknownAddress, fSign uint64) cciptypes.RemoteConfig {
+	signers := make([]cciptypes.RemoteSignerInfo, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		signers = append(signers, node.RemoteSignerInfo())
+	}
+	return cciptypes.RemoteConfig{
+		ContractAddress:  contractAddress,
+		ConfigDigest:     n.cfg.ConfigDigest,
+		Signers:          signers,
+		FSign:            fSign,
+		ConfigVersion:    1,
+		RmnReportVersion: n.cfg.ReportVersionDigest,
+	}
+}
+
+// Home returns an RMNHome reader serving the network config.
+func (n *Network) Home() *Home {
+	return &Home{network: n}
+}
+
+// NewPeerClient returns a client for a single oracle, every oracle needs its own client so that
+// responses are routed back to it.
+func (n *Network) NewPeerClient() *PeerClient {
+	return &PeerClient{
+		network:  n,
+		respChan: make(chan rmn.PeerResponse, 1024),
+	}
+}
+
+// delay returns the response delay of a request, or false if the request is dropped.
+func (n *Network) delay(b Behavior) (time.Duration, bool) {
+	n.rndMu.Lock()
+	defer n.rndMu.Unlock()
+
+	if b.DropRate > 0 && n.rnd.Float64() < b.DropRate {
+		return 0, false
+	}
+	d := b.Latency
+	if b.Jitter > 0 {
+		d += time.Duration(n.rnd.Int63n(int64(b.Jitter)))
+	}
+	return d, true
+}
+
+func (n *Network) randomBytes32() cciptypes.Bytes32 {
+	n.rndMu.Lock()
+	defer n.rndMu.Unlock()
+
+	var b cciptypes.Bytes32
+	_, _ = n.rnd.Read(b[:])
+	return b
+}
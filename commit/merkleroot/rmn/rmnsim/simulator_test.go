@@ -0,0 +1,374 @@
+package rmnsim
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
+	rmnpb "github.com/smartcontractkit/chainlink-protos/rmn/v1.6/go/serialization"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/rmncrypto"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+var (
+	chainS1 = cciptypes.ChainSelector(chainsel.TEST_90000002.Selector)
+	chainS2 = cciptypes.ChainSelector(chainsel.TEST_90000003.Selector)
+
+	destChain = &rmnpb.LaneDest{
+		DestChainSelector: chainsel.ETHEREUM_TESTNET_SEPOLIA.Selector,
+		OfframpAddress:    common.HexToAddress("0x0000000000000000000000000000000000000fff").Bytes(),
+	}
+	rmnRemoteAddress = common.HexToAddress("0x0000000000000000000000000000000000000aaa").Bytes()
+)
+
+func newTestNetwork(t *testing.T, behaviors ...Behavior) *Network {
+	return newTestNetworkWithSigner(t, rmncrypto.EVM{}, behaviors...)
+}
+
+func newTestNetworkWithSigner(t *testing.T, signer ReportSigner, behaviors ...Behavior) *Network {
+	nodeConfigs := make([]NodeConfig, 0, len(behaviors))
+	for _, b := range behaviors {
+		nodeConfigs = append(nodeConfigs, NodeConfig{
+			SupportedSourceChains: []cciptypes.ChainSelector{chainS1, chainS2},
+			Behavior:              b,
+		})
+	}
+
+	network, err := NewNetwork(logger.Test(t), Config{
+		ConfigDigest:        cciptypes.Bytes32{0x1, 0x2, 0x3},
+		ReportVersionDigest: cciptypes.Bytes32(crypto.Keccak256([]byte("RMN_V1_6_ANY2EVM_REPORT"))),
+		FObserve:            map[cciptypes.ChainSelector]int{chainS1: 1, chainS2: 1},
+		ReportSigner:        signer,
+		Seed:                1,
+	}, nodeConfigs)
+	require.NoError(t, err)
+	return network
+}
+
+func updateRequests() []*rmnpb.FixedDestLaneUpdateRequest {
+	return updateRequestsFrom(10)
+}
+
+// updateRequestsFrom returns lane update requests for both source chains starting at seqNr.
+func updateRequestsFrom(seqNr uint64) []*rmnpb.FixedDestLaneUpdateRequest {
+	return []*rmnpb.FixedDestLaneUpdateRequest{
+		{
+			LaneSource: &rmnpb.LaneSource{
+				SourceChainSelector: uint64(chainS1),
+				OnrampAddress:       common.LeftPadBytes(common.HexToAddress("0x01").Bytes(), 32),
+			},
+			ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: seqNr, MaxMsgNr: seqNr + 10},
+		},
+		{
+			LaneSource: &rmnpb.LaneSource{
+				SourceChainSelector: uint64(chainS2),
+				OnrampAddress:       common.LeftPadBytes(common.HexToAddress("0x02").Bytes(), 32),
+			},
+			ClosedInterval: &rmnpb.ClosedInterval{MinMsgNr: seqNr + 90, MaxMsgNr: seqNr + 100},
+		},
+	}
+}
+
+func computeReportSignatures(
+	t *testing.T,
+	network *Network,
+	timeout time.Duration,
+) (*rmn.ReportSignatures, cciptypes.RemoteConfig, error) {
+	return computeReportSignaturesFor(t, network, rmncrypto.EVM{}, destChain, rmnRemoteAddress, timeout)
+}
+
+// computeReportSignaturesFor runs a controller against the network for a destination chain of the backend family.
+func computeReportSignaturesFor(
+	t *testing.T,
+	network *Network,
+	backend rmncrypto.Backend,
+	dest *rmnpb.LaneDest,
+	rmnRemote cciptypes.UnknownAddress,
+	timeout time.Duration,
+) (*rmn.ReportSignatures, cciptypes.RemoteConfig, error) {
+	lggr := logger.Test(t)
+	ctx, cancel := context.WithTimeout(tests.Context(t), timeout)
+	defer cancel()
+
+	remoteCfg := network.RemoteConfig(rmnRemote, 1)
+	peerClient := network.NewPeerClient()
+	controller := rmn.NewController(
+		lggr,
+		backend,
+		DefaultSignObservationPrefix,
+		peerClient,
+		network.Home(),
+		50*time.Millisecond,
+		50*time.Millisecond,
+		rmn.NoopMetrics{},
+	)
+	require.NoError(t, controller.InitConnection(ctx, cciptypes.Bytes32{}, remoteCfg.ConfigDigest, nil, nil))
+	defer func() { require.NoError(t, controller.Close()) }()
+
+	sigs, err := controller.ComputeReportSignatures(ctx, dest, updateRequests(), remoteCfg)
+	return sigs, remoteCfg, err
+}
+
+// requireValidSignatures checks that the signatures are valid for the signed lane updates and
+// that the roots are the ones observed by the honest nodes.
+func requireValidSignatures(t *testing.T, sigs *rmn.ReportSignatures, remoteCfg cciptypes.RemoteConfig) {
+	requireValidSignaturesFor(t, rmncrypto.EVM{}, destChain, sigs, remoteCfg)
+}
+
+func requireValidSignaturesFor(
+	t *testing.T,
+	backend rmncrypto.Backend,
+	dest *rmnpb.LaneDest,
+	sigs *rmn.ReportSignatures,
+	remoteCfg cciptypes.RemoteConfig,
+) {
+	require.Len(t, sigs.Signatures, int(remoteCfg.FSign+1))
+	require.Len(t, sigs.LaneUpdates, 2)
+
+	for _, lu := range sigs.LaneUpdates {
+		// RMN nodes observe the 20 byte onramp address.
+		source := &rmnpb.LaneSource{
+			SourceChainSelector: lu.LaneSource.SourceChainSelector,
+			OnrampAddress:       common.BytesToAddress(lu.LaneSource.OnrampAddress).Bytes(),
+		}
+		assert.Equal(t, DefaultRoots(source, lu.ClosedInterval), cciptypes.Bytes32(lu.Root))
+	}
+
+	laneUpdates, err := rmn.NewLaneUpdatesFromPB(sigs.LaneUpdates)
+	require.NoError(t, err)
+	// Non-EVM destination chains have no chain id.
+	destChainInfo, _ := chainsel.ChainBySelector(dest.DestChainSelector)
+	report := cciptypes.NewRMNReport(
+		remoteCfg.RmnReportVersion,
+		cciptypes.NewBigIntFromInt64(int64(destChainInfo.EvmChainID)),
+		cciptypes.ChainSelector(dest.DestChainSelector),
+		remoteCfg.ContractAddress,
+		dest.OfframpAddress,
+		remoteCfg.ConfigDigest,
+		laneUpdates,
+	)
+
+	signerAddresses := make([]cciptypes.UnknownAddress, 0, len(remoteCfg.Signers))
+	for _, signer := range remoteCfg.Signers {
+		signerAddresses = append(signerAddresses, signer.OnchainPublicKey)
+	}
+	for _, pbSig := range sigs.Signatures {
+		sig, err := rmn.NewECDSASigFromPB(pbSig)
+		require.NoError(t, err)
+		require.NoError(t, backend.VerifyReportSignatures(
+			tests.Context(t), []cciptypes.RMNECDSASignature{*sig}, report, signerAddresses))
+	}
+}
+
+func TestNetwork_ComputeReportSignatures(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		network := newTestNetwork(t, Behavior{}, Behavior{}, Behavior{}, Behavior{})
+		sigs, remoteCfg, err := computeReportSignatures(t, network, 5*time.Second)
+		require.NoError(t, err)
+		requireValidSignatures(t, sigs, remoteCfg)
+	})
+
+	t.Run("slow and dropping nodes", func(t *testing.T) {
+		network := newTestNetwork(t,
+			Behavior{DropRate: 1},
+			Behavior{Latency: 200 * time.Millisecond, Jitter: 50 * time.Millisecond},
+			Behavior{DropRate: 1},
+			Behavior{},
+			Behavior{Latency: 10 * time.Millisecond},
+		)
+		sigs, remoteCfg, err := computeReportSignatures(t, network, 5*time.Second)
+		require.NoError(t, err)
+		requireValidSignatures(t, sigs, remoteCfg)
+	})
+
+	t.Run("equivocating node", func(t *testing.T) {
+		network := newTestNetwork(t, Behavior{Equivocate: true}, Behavior{}, Behavior{}, Behavior{})
+		sigs, remoteCfg, err := computeReportSignatures(t, network, 5*time.Second)
+		require.NoError(t, err)
+		requireValidSignatures(t, sigs, remoteCfg)
+	})
+
+	t.Run("wrong signatures", func(t *testing.T) {
+		network := newTestNetwork(t,
+			Behavior{WrongObservationSignature: true},
+			Behavior{WrongReportSignature: true},
+			Behavior{},
+			Behavior{},
+		)
+		sigs, remoteCfg, err := computeReportSignatures(t, network, 5*time.Second)
+		require.NoError(t, err)
+		requireValidSignatures(t, sigs, remoteCfg)
+	})
+
+	t.Run("not enough honest nodes", func(t *testing.T) {
+		network := newTestNetwork(t,
+			Behavior{Equivocate: true},
+			Behavior{WrongObservationSignature: true},
+			Behavior{DropRate: 1},
+			Behavior{},
+		)
+		_, _, err := computeReportSignatures(t, network, time.Second)
+		require.Error(t, err)
+	})
+}
+
+func TestNode_SetBehavior(t *testing.T) {
+	network := newTestNetwork(t, Behavior{DropRate: 1}, Behavior{DropRate: 1}, Behavior{DropRate: 1}, Behavior{})
+	_, _, err := computeReportSignatures(t, network, 500*time.Millisecond)
+	require.Error(t, err)
+
+	for _, node := range network.Nodes() {
+		node.SetBehavior(Behavior{})
+	}
+	sigs, remoteCfg, err := computeReportSignatures(t, network, 5*time.Second)
+	require.NoError(t, err)
+	requireValidSignatures(t, sigs, remoteCfg)
+
+	observations, _ := network.Node(0).Requests()
+	assert.Positive(t, observations)
+}
+
+func TestController_AvoidsSlowNodes(t *testing.T) {
+	slow := Behavior{Latency: time.Second}
+	network := newTestNetwork(t, slow, Behavior{}, Behavior{}, Behavior{}, slow, Behavior{}, Behavior{}, Behavior{})
+
+	remoteCfg := network.RemoteConfig(rmnRemoteAddress, 1)
+	controller := rmn.NewController(
+		logger.Test(t),
+		rmncrypto.EVM{},
+		DefaultSignObservationPrefix,
+		network.NewPeerClient(),
+		network.Home(),
+		50*time.Millisecond,
+		50*time.Millisecond,
+		rmn.NoopMetrics{},
+	)
+	require.NoError(t, controller.InitConnection(tests.Context(t), cciptypes.Bytes32{}, remoteCfg.ConfigDigest, nil, nil))
+	t.Cleanup(func() { require.NoError(t, controller.Close()) })
+
+	seqNr := uint64(1)
+	computeRounds := func(rounds int) {
+		for i := 0; i < rounds; i++ {
+			ctx, cancel := context.WithTimeout(tests.Context(t), 5*time.Second)
+			sigs, err := controller.ComputeReportSignatures(ctx, destChain, updateRequestsFrom(seqNr), remoteCfg)
+			seqNr += 11
+			cancel()
+			require.NoError(t, err)
+			requireValidSignatures(t, sigs, remoteCfg)
+		}
+	}
+
+	// The slow nodes are demoted after missing the initial request timers.
+	computeRounds(8)
+	var before []int
+	for _, id := range []int{0, 4} {
+		obs, sigs := network.Nodes()[id].Requests()
+		before = append(before, obs+sigs)
+	}
+
+	computeRounds(5)
+	for i, id := range []int{0, 4} {
+		obs, sigs := network.Nodes()[id].Requests()
+		assert.Equal(t, before[i], obs+sigs, "slow node %d was requested again", id)
+	}
+}
+
+func TestController_ReusesCachedResponses(t *testing.T) {
+	network := newTestNetwork(t, Behavior{}, Behavior{}, Behavior{}, Behavior{})
+
+	remoteCfg := network.RemoteConfig(rmnRemoteAddress, 1)
+	controller := rmn.NewController(
+		logger.Test(t),
+		rmncrypto.EVM{},
+		DefaultSignObservationPrefix,
+		network.NewPeerClient(),
+		network.Home(),
+		50*time.Millisecond,
+		50*time.Millisecond,
+		rmn.NoopMetrics{},
+	)
+	require.NoError(t, controller.InitConnection(tests.Context(t), cciptypes.Bytes32{}, remoteCfg.ConfigDigest, nil, nil))
+	t.Cleanup(func() { require.NoError(t, controller.Close()) })
+
+	totalRequests := func() int {
+		total := 0
+		for _, node := range network.Nodes() {
+			obs, sigs := node.Requests()
+			total += obs + sigs
+		}
+		return total
+	}
+
+	first, err := controller.ComputeReportSignatures(tests.Context(t), destChain, updateRequestsFrom(1), remoteCfg)
+	require.NoError(t, err)
+	requireValidSignatures(t, first, remoteCfg)
+	requests := totalRequests()
+	require.Positive(t, requests)
+
+	// Retrying the same lane updates doesn't query the nodes again.
+	retry, err := controller.ComputeReportSignatures(tests.Context(t), destChain, updateRequestsFrom(1), remoteCfg)
+	require.NoError(t, err)
+	requireValidSignatures(t, retry, remoteCfg)
+	assert.Equal(t, first.Signatures, retry.Signatures)
+	assert.Equal(t, requests, totalRequests())
+
+	// Different lane updates are observed and signed again.
+	next, err := controller.ComputeReportSignatures(tests.Context(t), destChain, updateRequestsFrom(12), remoteCfg)
+	require.NoError(t, err)
+	requireValidSignatures(t, next, remoteCfg)
+	assert.Greater(t, totalRequests(), requests)
+}
+
+func TestNode_agreedLaneUpdates(t *testing.T) {
+	network := newTestNetwork(t, Behavior{}, Behavior{}, Behavior{})
+	root := cciptypes.Bytes32{0xaa}
+
+	observe := func(nodeIndex uint32, interval *rmnpb.ClosedInterval) *rmnpb.AttributedSignedObservation {
+		obs := &rmnpb.Observation{
+			FixedDestLaneUpdates: []*rmnpb.FixedDestLaneUpdate{{
+				LaneSource:     updateRequests()[0].LaneSource,
+				ClosedInterval: interval,
+				Root:           root[:],
+			}},
+		}
+		msg, err := rmn.ObservationSigningMessage(DefaultSignObservationPrefix, obs)
+		require.NoError(t, err)
+		return &rmnpb.AttributedSignedObservation{
+			SignerNodeIndex: nodeIndex,
+			SignedObservation: &rmnpb.SignedObservation{
+				Observation: obs,
+				Signature:   ed25519.Sign(network.Nodes()[nodeIndex].offchainKey, msg),
+			},
+		}
+	}
+
+	// the same root for different intervals is not an agreement
+	updates, err := network.Nodes()[0].agreedLaneUpdates([]*rmnpb.AttributedSignedObservation{
+		observe(0, &rmnpb.ClosedInterval{MinMsgNr: 1, MaxMsgNr: 10}),
+		observe(1, &rmnpb.ClosedInterval{MinMsgNr: 1, MaxMsgNr: 11}),
+	})
+	require.NoError(t, err)
+	require.Empty(t, updates)
+
+	updates, err = network.Nodes()[0].agreedLaneUpdates([]*rmnpb.AttributedSignedObservation{
+		observe(0, &rmnpb.ClosedInterval{MinMsgNr: 1, MaxMsgNr: 10}),
+		observe(1, &rmnpb.ClosedInterval{MinMsgNr: 1, MaxMsgNr: 10}),
+	})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	require.Equal(t, root, updates[0].MerkleRoot)
+	require.Equal(t, cciptypes.SeqNum(10), updates[0].MaxSeqNr)
+}
@@ -34,6 +34,13 @@ type Observation struct {
 	OffRampNextSeqNums []plugintypes.SeqNumChain        `json:"offRampNextSeqNums"`
 	RMNRemoteConfig    cciptypes.RemoteConfig           `json:"rmnRemoteConfig"`
 	FChain             map[cciptypes.ChainSelector]int  `json:"fChain"`
+	// MessageHashes are the hashes of the messages of each observed merkle root, they are used to agree on the
+	// largest common prefix of a range when the observed roots of a chain don't reach consensus.
+	MessageHashes plugintypes.MessageHashes `json:"messageHashes"`
+	// CommittedRoots are the merkle roots of the recent CommitReportAccepted events of the destination chain for
+	// the source chains of the report that is waiting for transmission, at most one per source chain of the report
+	// (see selectCommittedRoots).
+	CommittedRoots []cciptypes.MerkleRootChain `json:"committedRoots"`
 }
 
 func (o Observation) Stats() map[string]int {
@@ -49,6 +56,8 @@ func (o Observation) Stats() map[string]int {
 
 func (o Observation) IsEmpty() bool {
 	return len(o.MerkleRoots) == 0 &&
+		len(o.MessageHashes) == 0 &&
+		len(o.CommittedRoots) == 0 &&
 		len(o.OnRampMaxSeqNums) == 0 &&
 		len(o.OffRampNextSeqNums) == 0 &&
 		o.RMNRemoteConfig.IsEmpty() &&
@@ -74,6 +83,18 @@ type aggregatedObservation struct {
 
 	// A map from chain selectors to the list of f (failure tolerance) observed for each chain
 	FChain map[cciptypes.ChainSelector][]int
+
+	// A map from chain selectors to the message hashes observed for each chain along with the observed root
+	MessageHashes map[cciptypes.ChainSelector][]observedMessageHashes
+
+	// A map from chain selectors to the committed merkle roots observed for each chain
+	CommittedRoots map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain
+}
+
+// observedMessageHashes are the message hashes of a merkle root observed by an oracle.
+type observedMessageHashes struct {
+	Root   cciptypes.MerkleRootChain
+	Hashes map[cciptypes.SeqNum]cciptypes.Bytes32
 }
 
 // aggregateObservations takes a list of observations and produces an MerkleAggregatedObservation
@@ -85,6 +106,8 @@ func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]
 		OffRampNextSeqNums: make(map[cciptypes.ChainSelector][]cciptypes.SeqNum),
 		RMNRemoteConfigs:   make([]cciptypes.RemoteConfig, 0),
 		FChain:             make(map[cciptypes.ChainSelector][]int),
+		MessageHashes:      make(map[cciptypes.ChainSelector][]observedMessageHashes),
+		CommittedRoots:     make(map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain),
 	}
 
 	for _, ao := range aos {
@@ -93,6 +116,12 @@ func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]
 		for _, merkleRoot := range obs.MerkleRoots {
 			aggObs.MerkleRoots[merkleRoot.ChainSel] =
 				append(aggObs.MerkleRoots[merkleRoot.ChainSel], merkleRoot)
+
+			// MessageHashes
+			if hashes, ok := obs.MessageHashes[merkleRoot.ChainSel]; ok {
+				aggObs.MessageHashes[merkleRoot.ChainSel] = append(aggObs.MessageHashes[merkleRoot.ChainSel],
+					observedMessageHashes{Root: merkleRoot, Hashes: hashes})
+			}
 		}
 
 		// RMNEnabledChains
@@ -122,6 +151,10 @@ func aggregateObservations(aos []plugincommon.AttributedObservation[Observation]
 			aggObs.FChain[chainSel] = append(aggObs.FChain[chainSel], f)
 		}
 
+		// CommittedRoots
+		for _, root := range obs.CommittedRoots {
+			aggObs.CommittedRoots[root.ChainSel] = append(aggObs.CommittedRoots[root.ChainSel], root)
+		}
 	}
 
 	return aggObs
@@ -146,6 +179,9 @@ type consensusObservation struct {
 
 	// A map from chain selectors to each chain's consensus f (failure tolerance)
 	FChain map[cciptypes.ChainSelector]int
+
+	// A map from chain selectors to the merkle roots committed on the destination chain for each chain
+	CommittedRoots map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain
 }
 
 type OutcomeType int
@@ -168,6 +204,10 @@ type Outcome struct {
 	ReportTransmissionCheckAttempts uint                             `json:"reportTransmissionCheckAttempts"`
 	RMNReportSignatures             []cciptypes.RMNECDSASignature    `json:"rmnReportSignatures"`
 	RMNRemoteCfg                    cciptypes.RemoteConfig           `json:"rmnRemoteCfg"`
+	// LaneWaitingRounds is the number of consecutive range selections in which a source chain had pending
+	// messages but was not scheduled in the first report, see scheduleLanes. It is carried over until the next
+	// range selection and orders the roots of the reports, see LanePriorityLess.
+	LaneWaitingRounds map[cciptypes.ChainSelector]uint64 `json:"laneWaitingRounds"`
 }
 
 func (o Outcome) Stats() map[string]int {
@@ -122,6 +122,13 @@ func TestObservation_IsEmpty(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "Non-empty MessageHashes",
+			observation: Observation{
+				MessageHashes: plugintypes.MessageHashes{1: {1: {1}}},
+			},
+			expected: false,
+		},
 		{
 			name: "Non-empty FChain",
 			observation: Observation{
@@ -158,6 +165,8 @@ func TestAggregateObservations(t *testing.T) {
 				RMNRemoteConfigs:   make([]cciptypes.RemoteConfig, 0),
 				FChain:             make(map[cciptypes.ChainSelector][]int),
 				RMNEnabledChains:   map[cciptypes.ChainSelector][]bool{},
+				MessageHashes:      make(map[cciptypes.ChainSelector][]observedMessageHashes),
+				CommittedRoots:     make(map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain),
 			},
 		},
 		{
@@ -170,6 +179,8 @@ func TestAggregateObservations(t *testing.T) {
 						OffRampNextSeqNums: []plugintypes.SeqNumChain{{ChainSel: 1, SeqNum: 1}},
 						RMNRemoteConfig:    cciptypes.RemoteConfig{RmnReportVersion: cciptypes.Bytes32{1}},
 						FChain:             map[cciptypes.ChainSelector]int{1: 1},
+						MessageHashes:      plugintypes.MessageHashes{1: {1: {1}}},
+						CommittedRoots:     []cciptypes.MerkleRootChain{{ChainSel: 1, MerkleRoot: cciptypes.Bytes32{2}}},
 					},
 				},
 			},
@@ -180,6 +191,12 @@ func TestAggregateObservations(t *testing.T) {
 				RMNRemoteConfigs:   []cciptypes.RemoteConfig{{RmnReportVersion: cciptypes.Bytes32{1}}},
 				FChain:             map[cciptypes.ChainSelector][]int{1: {1}},
 				RMNEnabledChains:   map[cciptypes.ChainSelector][]bool{},
+				MessageHashes: map[cciptypes.ChainSelector][]observedMessageHashes{
+					1: {{Root: cciptypes.MerkleRootChain{ChainSel: 1}, Hashes: map[cciptypes.SeqNum]cciptypes.Bytes32{1: {1}}}},
+				},
+				CommittedRoots: map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{
+					1: {{ChainSel: 1, MerkleRoot: cciptypes.Bytes32{2}}},
+				},
 			},
 		},
 		{
@@ -214,6 +231,8 @@ func TestAggregateObservations(t *testing.T) {
 				},
 				RMNEnabledChains: map[cciptypes.ChainSelector][]bool{},
 				FChain:           map[cciptypes.ChainSelector][]int{1: {1}, 2: {2}},
+				MessageHashes:    map[cciptypes.ChainSelector][]observedMessageHashes{},
+				CommittedRoots:   map[cciptypes.ChainSelector][]cciptypes.MerkleRootChain{},
 			},
 		},
 	}
@@ -13,7 +13,7 @@ import (
 )
 
 func (p *Processor) ValidateObservation(
-	_ Outcome,
+	prevOutcome Outcome,
 	q Query,
 	ao plugincommon.AttributedObservation[Observation]) error {
 
@@ -39,6 +39,10 @@ func (p *Processor) ValidateObservation(
 		return fmt.Errorf("validate MerkleRoots: %w", err)
 	}
 
+	if err := validateObservedMessageHashes(obs.MessageHashes, obs.MerkleRoots); err != nil {
+		return fmt.Errorf("validate MessageHashes: %w", err)
+	}
+
 	if err := validateObservedOnRampMaxSeqNums(obs.OnRampMaxSeqNums, ao.OracleID, observerSupportedChains); err != nil {
 		return fmt.Errorf("validate OnRampMaxSeqNums: %w", err)
 	}
@@ -47,6 +51,11 @@ func (p *Processor) ValidateObservation(
 		return fmt.Errorf("validate OffRampNextSeqNums: %w", err)
 	}
 
+	if err := validateObservedCommittedRoots(
+		obs.CommittedRoots, ao.OracleID, supportsDestChain, prevOutcome.RootsToReport); err != nil {
+		return fmt.Errorf("validate CommittedRoots: %w", err)
+	}
+
 	// Don't need to validate RMNRemoteConfig if RMN is disabled.
 	if p.offchainCfg.RMNEnabled {
 		if err := validateRMNRemoteConfig(ao.OracleID, supportsDestChain, obs.RMNRemoteConfig); err != nil {
@@ -104,6 +113,60 @@ func validateObservedMerkleRoots(
 	return nil
 }
 
+// validateObservedMessageHashes validates that the message hashes of each chain are the hashes of the messages of
+// the observed root of the chain. Roots observed without message hashes are valid.
+func validateObservedMessageHashes(
+	msgHashes plugintypes.MessageHashes,
+	merkleRoots []cciptypes.MerkleRootChain,
+) error {
+	if len(msgHashes) == 0 {
+		return nil
+	}
+
+	numHashes := 0
+	for _, hashes := range msgHashes {
+		numHashes += len(hashes)
+	}
+	if numHashes > MaxObservedMessageHashes {
+		return fmt.Errorf("too many message hashes observed: %d > %d", numHashes, MaxObservedMessageHashes)
+	}
+
+	rootsByChain := make(map[cciptypes.ChainSelector]cciptypes.MerkleRootChain, len(merkleRoots))
+	for _, root := range merkleRoots {
+		rootsByChain[root.ChainSel] = root
+	}
+
+	for chain, hashes := range msgHashes {
+		root, ok := rootsByChain[chain]
+		if !ok {
+			return fmt.Errorf("found message hashes for chain %d without an observed merkle root", chain)
+		}
+
+		if len(hashes) != root.SeqNumsRange.Length() {
+			return fmt.Errorf("%s invalid: %d message hashes observed", root, len(hashes))
+		}
+
+		orderedHashes := make([]cciptypes.Bytes32, 0, len(hashes))
+		for _, seqNum := range root.SeqNumsRange.ToSlice() {
+			h, ok := hashes[seqNum]
+			if !ok {
+				return fmt.Errorf("%s invalid: message hash of seq num %d not observed", root, seqNum)
+			}
+			orderedHashes = append(orderedHashes, h)
+		}
+
+		merkleRoot, err := merkleRootFromHashes(orderedHashes)
+		if err != nil {
+			return fmt.Errorf("%s invalid: %w", root, err)
+		}
+		if merkleRoot != root.MerkleRoot {
+			return fmt.Errorf("%s invalid: merkle root of the message hashes is %s", root, merkleRoot)
+		}
+	}
+
+	return nil
+}
+
 func validateObservedOnRampMaxSeqNums(
 	onRampMaxSeqNums []plugintypes.SeqNumChain,
 	observer commontypes.OracleID,
@@ -131,6 +194,58 @@ func validateObservedOnRampMaxSeqNums(
 	return nil
 }
 
+// validateObservedCommittedRoots validates that the committed roots are observed by an oracle that supports the
+// destination chain, only for the source chains of the roots that are waiting for transmission and at most one per
+// chain with a range overlapping the root of the chain, see selectCommittedRoots.
+func validateObservedCommittedRoots(
+	committedRoots []cciptypes.MerkleRootChain,
+	observer commontypes.OracleID,
+	supportsDestChain bool,
+	rootsToReport []cciptypes.MerkleRootChain,
+) error {
+	if len(committedRoots) == 0 {
+		return nil
+	}
+
+	if !supportsDestChain {
+		return fmt.Errorf("observer %d does not support dest chain, but has observed %d committed roots",
+			observer, len(committedRoots))
+	}
+
+	reportedRanges := make(map[cciptypes.ChainSelector]cciptypes.SeqNumRange, len(rootsToReport))
+	for _, root := range rootsToReport {
+		reportedRanges[root.ChainSel] = root.SeqNumsRange
+	}
+
+	seenChains := mapset.NewSet[cciptypes.ChainSelector]()
+	for _, root := range committedRoots {
+		reportedRange, ok := reportedRanges[root.ChainSel]
+		if !ok {
+			return fmt.Errorf("%s invalid: chain is not waiting for report transmission", root)
+		}
+
+		if seenChains.Contains(root.ChainSel) {
+			return fmt.Errorf("%s invalid: more than one committed root for the chain", root)
+		}
+
+		if root.MerkleRoot.IsEmpty() {
+			return fmt.Errorf("%s invalid: empty MerkleRoot", root)
+		}
+
+		if root.SeqNumsRange.Start() == cciptypes.SeqNum(0) || root.SeqNumsRange.End() < root.SeqNumsRange.Start() {
+			return fmt.Errorf("%s invalid: invalid seq nums range", root)
+		}
+
+		if !root.SeqNumsRange.Overlaps(reportedRange) {
+			return fmt.Errorf("%s invalid: range does not overlap the reported range %s", root, reportedRange)
+		}
+
+		seenChains.Add(root.ChainSel)
+	}
+
+	return nil
+}
+
 func validateObservedOffRampMaxSeqNums(
 	offRampMaxSeqNums []plugintypes.SeqNumChain,
 	observer commontypes.OracleID,
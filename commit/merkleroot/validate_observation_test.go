@@ -86,6 +86,82 @@ func Test_validateObservedMerkleRoots(t *testing.T) {
 	}
 }
 
+func Test_validateObservedMessageHashes(t *testing.T) {
+	hashes := map[cciptypes.SeqNum]cciptypes.Bytes32{10: {1}, 11: {2}}
+	root, err := merkleRootFromHashes([]cciptypes.Bytes32{{1}, {2}})
+	assert.NoError(t, err)
+
+	tooManyHashes := make(map[cciptypes.SeqNum]cciptypes.Bytes32, MaxObservedMessageHashes+1)
+	for i := range MaxObservedMessageHashes + 1 {
+		tooManyHashes[cciptypes.SeqNum(i+1)] = cciptypes.Bytes32{1}
+	}
+
+	testCases := []struct {
+		name        string
+		msgHashes   plugintypes.MessageHashes
+		merkleRoots []cciptypes.MerkleRootChain
+		expErr      bool
+	}{
+		{
+			name:        "No message hashes",
+			merkleRoots: []cciptypes.MerkleRootChain{{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{10, 11}}},
+			expErr:      false,
+		},
+		{
+			name:        "Valid message hashes",
+			msgHashes:   plugintypes.MessageHashes{1: hashes},
+			merkleRoots: []cciptypes.MerkleRootChain{{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{10, 11}, MerkleRoot: root}},
+			expErr:      false,
+		},
+		{
+			name:        "Message hashes without a merkle root",
+			msgHashes:   plugintypes.MessageHashes{2: hashes},
+			merkleRoots: []cciptypes.MerkleRootChain{{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{10, 11}, MerkleRoot: root}},
+			expErr:      true,
+		},
+		{
+			name:        "Message hashes do not cover the range",
+			msgHashes:   plugintypes.MessageHashes{1: hashes},
+			merkleRoots: []cciptypes.MerkleRootChain{{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{10, 12}, MerkleRoot: root}},
+			expErr:      true,
+		},
+		{
+			name:        "Message hashes of other seq nums",
+			msgHashes:   plugintypes.MessageHashes{1: hashes},
+			merkleRoots: []cciptypes.MerkleRootChain{{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{11, 12}, MerkleRoot: root}},
+			expErr:      true,
+		},
+		{
+			name:      "Too many message hashes",
+			msgHashes: plugintypes.MessageHashes{1: tooManyHashes},
+			merkleRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{1, MaxObservedMessageHashes + 1}},
+			},
+			expErr: true,
+		},
+		{
+			name:      "Merkle root does not match the message hashes",
+			msgHashes: plugintypes.MessageHashes{1: hashes},
+			merkleRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: [2]cciptypes.SeqNum{10, 11}, MerkleRoot: [32]byte{1}},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateObservedMessageHashes(tc.msgHashes, tc.merkleRoots)
+
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func Test_validateObservedOnRampMaxSeqNums(t *testing.T) {
 	testCases := []struct {
 		name                    string
@@ -234,6 +310,105 @@ func Test_validateObservedOffRampMaxSeqNums(t *testing.T) {
 	}
 }
 
+func Test_validateObservedCommittedRoots(t *testing.T) {
+	rootsToReport := []cciptypes.MerkleRootChain{
+		{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+		{ChainSel: 2, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{2}},
+	}
+
+	testCases := []struct {
+		name              string
+		committedRoots    []cciptypes.MerkleRootChain
+		supportsDestChain bool
+		expErr            bool
+	}{
+		{
+			name:              "No committed roots",
+			supportsDestChain: false,
+			expErr:            false,
+		},
+		{
+			name: "Valid committed roots",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+				{ChainSel: 2, SeqNumsRange: cciptypes.NewSeqNumRange(10, 15), MerkleRoot: cciptypes.Bytes32{3}},
+			},
+			supportsDestChain: true,
+			expErr:            false,
+		},
+		{
+			name: "Dest chain not supported",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+			},
+			supportsDestChain: false,
+			expErr:            true,
+		},
+		{
+			name: "Chain not waiting for report transmission",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 3, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+			},
+			supportsDestChain: true,
+			expErr:            true,
+		},
+		{
+			name: "Empty merkle root",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20)},
+			},
+			supportsDestChain: true,
+			expErr:            true,
+		},
+		{
+			name: "Duplicate merkle root",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+			},
+			supportsDestChain: true,
+			expErr:            true,
+		},
+		{
+			name: "More than one committed root for a chain",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 20), MerkleRoot: cciptypes.Bytes32{1}},
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(10, 15), MerkleRoot: cciptypes.Bytes32{3}},
+			},
+			supportsDestChain: true,
+			expErr:            true,
+		},
+		{
+			name: "Range not overlapping the reported range",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(21, 30), MerkleRoot: cciptypes.Bytes32{3}},
+			},
+			supportsDestChain: true,
+			expErr:            true,
+		},
+		{
+			name: "Invalid seq nums range",
+			committedRoots: []cciptypes.MerkleRootChain{
+				{ChainSel: 1, SeqNumsRange: cciptypes.NewSeqNumRange(20, 10), MerkleRoot: cciptypes.Bytes32{1}},
+			},
+			supportsDestChain: true,
+			expErr:            true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateObservedCommittedRoots(tc.committedRoots, 10, tc.supportsDestChain, rootsToReport)
+
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func Test_validateRMNRemoteConfig(t *testing.T) {
 	testCases := []struct {
 		name              string
@@ -77,6 +77,27 @@ var (
 		},
 		[]string{"method", "nodeID", "error"},
 	)
+	promChainFeeRejections = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_chain_fee_rejections",
+			Help: "This metric tracks the observed chain fee values rejected as outliers or capped by the max change",
+		},
+		[]string{"chainFamily", "chainID", "sourceChainFamily", "sourceChain", "component", "reason"},
+	)
+	promInvalidFeedAnswers = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ccip_commit_invalid_feed_answers",
+			Help: "This metric tracks the token price feed answers ignored as stale, non-positive or out of bounds",
+		},
+		[]string{"chainFamily", "chainID", "token", "reason"},
+	)
+	promRmnControllerRmnNodeScore = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ccip_commit_rmn_controller_rmn_node_score",
+			Help: "This metric tracks the health score (0 to 1) the RMN controller uses to pick which RMN nodes to request",
+		},
+		[]string{"nodeID"},
+	)
 )
 
 type PromReporter struct {
@@ -86,10 +107,13 @@ type PromReporter struct {
 	// Prometheus components
 	merkleProcessorRmnReportHistogram *prometheus.HistogramVec
 	rmnControllerRmnRequestHistogram  *prometheus.HistogramVec
+	rmnControllerRmnNodeScore         *prometheus.GaugeVec
 	processorLatencyHistogram         *prometheus.HistogramVec
 	processorOutputCounter            *prometheus.CounterVec
 	processorErrors                   *prometheus.CounterVec
 	sequenceNumbers                   *prometheus.GaugeVec
+	chainFeeRejections                *prometheus.CounterVec
+	invalidFeedAnswers                *prometheus.CounterVec
 }
 
 func NewPromReporter(lggr logger.Logger, selector cciptypes.ChainSelector) (*PromReporter, error) {
@@ -105,9 +129,13 @@ func NewPromReporter(lggr logger.Logger, selector cciptypes.ChainSelector) (*Pro
 
 		merkleProcessorRmnReportHistogram: promMerkleProcessorRmnReportLatency,
 		rmnControllerRmnRequestHistogram:  promRmnControllerRmnRequestLatency,
+		rmnControllerRmnNodeScore:         promRmnControllerRmnNodeScore,
 
 		sequenceNumbers: promSequenceNumbers,
 
+		chainFeeRejections: promChainFeeRejections,
+		invalidFeedAnswers: promInvalidFeedAnswers,
+
 		processorLatencyHistogram: promProcessorLatencyHistogram,
 		processorOutputCounter:    promProcessorOutputCounter,
 		processorErrors:           promProcessorErrors,
@@ -172,6 +200,10 @@ func (p *PromReporter) TrackRmnRequest(method string, latency float64, nodeID ui
 	p.rmnControllerRmnRequestHistogram.WithLabelValues(method, nodeIDStr, err).Observe(latency)
 }
 
+func (p *PromReporter) TrackRmnNodeScore(nodeID uint64, score float64) {
+	p.rmnControllerRmnNodeScore.WithLabelValues(strconv.FormatUint(nodeID, 10)).Set(score)
+}
+
 func (p *PromReporter) TrackProcessorLatency(
 	processor string,
 	method plugincommon.MethodType,
@@ -201,3 +233,25 @@ func (p *PromReporter) TrackProcessorOutput(
 			Add(float64(val))
 	}
 }
+
+func (p *PromReporter) TrackChainFeeRejection(
+	chain cciptypes.ChainSelector,
+	component, reason string,
+	count int,
+) {
+	sourceFamily, sourceChainID, ok := libs.GetChainInfoFromSelector(chain)
+	if !ok {
+		p.lggr.Errorw("failed to get chain ID from selector", "selector", chain)
+		return
+	}
+
+	p.chainFeeRejections.
+		WithLabelValues(p.chainFamily, p.chainID, sourceFamily, sourceChainID, component, reason).
+		Add(float64(count))
+}
+
+func (p *PromReporter) TrackInvalidFeedAnswer(token cciptypes.UnknownEncodedAddress, reason string) {
+	p.invalidFeedAnswers.
+		WithLabelValues(p.chainFamily, p.chainID, string(token), reason).
+		Inc()
+}
@@ -562,3 +562,50 @@ func cleanupMetrics(reporter *PromReporter) func() {
 		reporter.processorLatencyHistogram.Reset()
 	}
 }
+
+func Test_RmnNodeScore(t *testing.T) {
+	reporter, err := NewPromReporter(logger.Test(t), selector)
+	require.NoError(t, err)
+
+	reporter.TrackRmnNodeScore(1, 0.9)
+	reporter.TrackRmnNodeScore(2, 0.3)
+	reporter.TrackRmnNodeScore(1, 0.7)
+
+	require.Equal(t, 0.7, testutil.ToFloat64(reporter.rmnControllerRmnNodeScore.WithLabelValues("1")))
+	require.Equal(t, 0.3, testutil.ToFloat64(reporter.rmnControllerRmnNodeScore.WithLabelValues("2")))
+}
+
+func Test_ChainFeeRejections(t *testing.T) {
+	reporter, err := NewPromReporter(logger.Test(t), selector)
+	require.NoError(t, err)
+	t.Cleanup(reporter.chainFeeRejections.Reset)
+
+	sourceChain := cciptypes.ChainSelector(5009297550715157269)
+	reporter.TrackChainFeeRejection(sourceChain, "executionFee", "outlier", 2)
+	reporter.TrackChainFeeRejection(sourceChain, "executionFee", "outlier", 1)
+	reporter.TrackChainFeeRejection(sourceChain, "executionFeeUSD", "maxChange", 1)
+	// unknown chains are not tracked
+	reporter.TrackChainFeeRejection(cciptypes.ChainSelector(1), "executionFee", "outlier", 1)
+
+	require.Equal(t, 3.0, testutil.ToFloat64(
+		reporter.chainFeeRejections.WithLabelValues("evm", chainID, "evm", "1", "executionFee", "outlier")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		reporter.chainFeeRejections.WithLabelValues("evm", chainID, "evm", "1", "executionFeeUSD", "maxChange")))
+	require.Equal(t, 2, testutil.CollectAndCount(reporter.chainFeeRejections))
+}
+
+func Test_InvalidFeedAnswers(t *testing.T) {
+	reporter, err := NewPromReporter(logger.Test(t), selector)
+	require.NoError(t, err)
+	t.Cleanup(reporter.invalidFeedAnswers.Reset)
+
+	reporter.TrackInvalidFeedAnswer("0x1", "stale")
+	reporter.TrackInvalidFeedAnswer("0x1", "stale")
+	reporter.TrackInvalidFeedAnswer("0x2", "outOfBounds")
+
+	require.Equal(t, 2.0, testutil.ToFloat64(
+		reporter.invalidFeedAnswers.WithLabelValues("evm", chainID, "0x1", "stale")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		reporter.invalidFeedAnswers.WithLabelValues("evm", chainID, "0x2", "outOfBounds")))
+	require.Equal(t, 2, testutil.CollectAndCount(reporter.invalidFeedAnswers))
+}
@@ -751,6 +751,8 @@ func prepareCcipReaderMock(
 		Return(reader2.CurseInfo{}, nil).Maybe()
 	ccipReader.EXPECT().GetOffRampSourceChainsConfig(mock.Anything, mock.Anything).
 		Return(sourceChainConfigs, nil).Maybe()
+	ccipReader.EXPECT().CommitReportsGTETimestamp(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).Maybe()
 
 	if mockEmptySeqNrs {
 		ccipReader.EXPECT().NextSeqNum(mock.Anything, mock.Anything).Unset()
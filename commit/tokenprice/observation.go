@@ -2,10 +2,12 @@ package tokenprice
 
 import (
 	"context"
+	"errors"
 	"sort"
 	"sync"
 	"time"
 
+	mapset "github.com/deckarep/golang-set/v2"
 	"golang.org/x/exp/maps"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
@@ -92,6 +94,7 @@ type baseObserver struct {
 	chainSupport     plugincommon.ChainSupport
 	offChainCfg      pluginconfig.CommitOffchainConfig
 	destChain        cciptypes.ChainSelector
+	metricsReporter  MetricsReporter
 }
 
 func newBaseObserver(
@@ -100,6 +103,7 @@ func newBaseObserver(
 	oracleID commontypes.OracleID,
 	chainSupport plugincommon.ChainSupport,
 	offchainCfg pluginconfig.CommitOffchainConfig,
+	metricsReporter MetricsReporter,
 ) *baseObserver {
 	return &baseObserver{
 		oracleID:         oracleID,
@@ -107,6 +111,7 @@ func newBaseObserver(
 		chainSupport:     chainSupport,
 		destChain:        destChain,
 		offChainCfg:      offchainCfg,
+		metricsReporter:  metricsReporter,
 	}
 }
 
@@ -122,23 +127,86 @@ func (b *baseObserver) observeFeedTokenPrices(ctx context.Context, lggr logger.L
 		return cciptypes.TokenPriceMap{}
 	}
 
-	if !supportedChains.Contains(b.offChainCfg.PriceFeedChainSelector) {
-		lggr.Debugf("oracle does not support feed chain %d", b.offChainCfg.PriceFeedChainSelector)
+	// Only query the tokens with a price available from the chains supported by the oracle, the prices of the feeds
+	// of a token are aggregated and derived prices are computed by the price reader.
+	tokensToQuery := make([]cciptypes.UnknownEncodedAddress, 0, len(b.offChainCfg.TokenInfo))
+	for token, info := range b.offChainCfg.TokenInfo {
+		if hasSupportedPriceSource(b.offChainCfg, info, supportedChains) {
+			tokensToQuery = append(tokensToQuery, token)
+		}
+	}
+	if len(tokensToQuery) == 0 {
+		lggr.Debugw("oracle does not support the price sources of any token")
 		return cciptypes.TokenPriceMap{}
 	}
-
-	tokensToQuery := maps.Keys(b.offChainCfg.TokenInfo)
 	lggr.Infow("observing feed token prices", "tokens", tokensToQuery)
 	tokenPrices, err := b.tokenPriceReader.GetFeedPricesUSD(ctx, tokensToQuery)
-	if err != nil {
+	var invalidAnswersErr *pkgreader.InvalidFeedAnswersError
+	if errors.As(err, &invalidAnswersErr) {
+		// The invalid answers are not used for the prices, only report them.
+		for _, answer := range invalidAnswersErr.Answers {
+			b.metricsReporter.TrackInvalidFeedAnswer(answer.Token, answer.Reason)
+		}
+		lggr.Warnw("ignored invalid feed answers", "answers", invalidAnswersErr.Answers)
+	} else if err != nil {
 		lggr.Errorw("call to GetFeedPricesUSD failed",
 			"err", err)
 		return cciptypes.TokenPriceMap{}
 	}
 
+	var missingTokens []cciptypes.UnknownEncodedAddress
+	for _, token := range tokensToQuery {
+		if _, ok := tokenPrices[token]; !ok {
+			missingTokens = append(missingTokens, token)
+		}
+	}
+	if len(missingTokens) > 0 {
+		lggr.Warnw("no feed price for tokens, none of their feeds returned a valid price", "tokens", missingTokens)
+	}
+
 	return tokenPrices
 }
 
+// hasSupportedPriceSource checks if the price of the token can be read: it has a price report, at least one of its
+// feeds is on a supported chain, or for a derived price every one of its factors is available.
+func hasSupportedPriceSource(
+	cfg pluginconfig.CommitOffchainConfig,
+	info pluginconfig.TokenInfo,
+	supportedChains mapset.Set[cciptypes.ChainSelector],
+) bool {
+	feedChain := cfg.PriceFeedChainSelector
+	if info.Derived == nil {
+		if !info.ReportFeedID.IsEmpty() && cfg.PriceReportSource != nil {
+			return true
+		}
+		for _, feed := range info.PriceFeeds(feedChain) {
+			if supportedChains.Contains(feed.ChainSelector) {
+				return true
+			}
+		}
+		return false
+	}
+
+	derived := info.Derived.WithChain(feedChain)
+	if derived.BaseToken != "" {
+		baseInfo, ok := cfg.TokenInfo[derived.BaseToken]
+		if !ok || baseInfo.Derived != nil || !hasSupportedPriceSource(cfg, baseInfo, supportedChains) {
+			return false
+		}
+	}
+	for _, feed := range derived.Feeds {
+		if !supportedChains.Contains(feed.ChainSelector) {
+			return false
+		}
+	}
+	for _, rate := range derived.Rates {
+		if !supportedChains.Contains(rate.ChainSelector) {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *baseObserver) observeFeeQuoterTokenUpdates(
 	ctx context.Context,
 	lggr logger.Logger) map[cciptypes.UnknownEncodedAddress]cciptypes.TimestampedBig {
@@ -19,6 +19,7 @@ import (
 	common_mock "github.com/smartcontractkit/chainlink-ccip/mocks/internal_/plugincommon"
 	readermock "github.com/smartcontractkit/chainlink-ccip/mocks/internal_/reader"
 	readerpkg_mock "github.com/smartcontractkit/chainlink-ccip/mocks/pkg/reader"
+	pkgreader "github.com/smartcontractkit/chainlink-ccip/pkg/reader"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
 )
@@ -89,7 +90,7 @@ func Test_Observation(t *testing.T) {
 					tokenPriceReader,
 					homeChain,
 					f,
-					plugincommon.NoopReporter{},
+					NoopMetrics{},
 				)
 			},
 			expObs: Observation{
@@ -141,7 +142,7 @@ func Test_Observation(t *testing.T) {
 					tokenPriceReader,
 					homeChain,
 					f,
-					plugincommon.NoopReporter{},
+					NoopMetrics{},
 				)
 			},
 			expObs: Observation{
@@ -189,3 +190,127 @@ var defaultCfg = pluginconfig.CommitOffchainConfig{
 	// Have this disabled for testing purposes
 	TokenPriceAsyncObserverDisabled: true,
 }
+
+func Test_baseObserver_observeFeedTokenPrices_feedChains(t *testing.T) {
+	otherFeedChain := otherFeedChainSel
+	derivedToken := cciptypes.UnknownEncodedAddress("0xDDDDDDDDDDDDDDDd75C1216873Ec4F88C11E57E3")
+	reportToken := cciptypes.UnknownEncodedAddress("0xEEEEEEEEEEEEEEEd75C1216873Ec4F88C11E57E3")
+	cfg := pluginconfig.CommitOffchainConfig{
+		TokenInfo: map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+			tokenA: defaultCfg.TokenInfo[tokenA],
+			// only has a feed on a chain that the oracle does not support
+			tokenB: {
+				Decimals:     18,
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Feeds: []pluginconfig.PriceFeed{
+					{ChainSelector: otherFeedChain, AggregatorAddress: "0x2222222222222222222222Ff18C45Df59775Fbb2"},
+				},
+			},
+			// derived from tokenA with a rate on the feed chain
+			tokenC: {
+				Decimals:     18,
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Derived: &pluginconfig.DerivedPrice{
+					BaseToken: tokenA,
+					Rates:     []pluginconfig.RateProvider{{Address: "0x3333333333333333333333Ff18C45Df59775Fbb2"}},
+				},
+			},
+			// derived with a rate on a chain that the oracle does not support
+			derivedToken: {
+				Decimals:     18,
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Derived: &pluginconfig.DerivedPrice{
+					BaseToken: tokenA,
+					Rates: []pluginconfig.RateProvider{
+						{ChainSelector: otherFeedChain, Address: "0x4444444444444444444444Ff18C45Df59775Fbb2"},
+					},
+				},
+			},
+			// priced from its report, its feed is on a chain that the oracle does not support
+			reportToken: {
+				Decimals:     18,
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Feeds: []pluginconfig.PriceFeed{
+					{ChainSelector: otherFeedChain, AggregatorAddress: "0x5555555555555555555555Ff18C45Df59775Fbb2"},
+				},
+				ReportFeedID: cciptypes.Bytes32{0x00, 0x03, 0x01},
+			},
+		},
+		PriceFeedChainSelector: feedChainSel,
+		PriceReportSource: &pluginconfig.PriceReportSource{
+			URL:        "https://reports.example.com",
+			Signers:    []cciptypes.UnknownEncodedAddress{"0x6666666666666666666666Ff18C45Df59775Fbb2"},
+			MinSigners: 1,
+		},
+	}
+
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.EXPECT().SupportedChains(mock.Anything).Return(mapset.NewSet(feedChainSel), nil)
+
+	tokenPriceReader := readerpkg_mock.NewMockPriceReader(t)
+	tokenPriceReader.EXPECT().GetFeedPricesUSD(mock.Anything,
+		mock.MatchedBy(func(tokens []cciptypes.UnknownEncodedAddress) bool {
+			return mapset.NewSet(tokens...).Equal(mapset.NewSet(tokenA, tokenC, reportToken)) && len(tokens) == 3
+		})).
+		Return(cciptypes.TokenPriceMap{}, nil)
+
+	obs := newBaseObserver(tokenPriceReader, destChainSel, commontypes.OracleID(1), chainSupport, cfg, NoopMetrics{})
+	prices := obs.observeFeedTokenPrices(context.Background(), logger.Test(t))
+	assert.Empty(t, prices)
+}
+
+func Test_baseObserver_observeFeedTokenPrices_feedChainNotSupported(t *testing.T) {
+	cfg := pluginconfig.CommitOffchainConfig{
+		TokenInfo: map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+			tokenA: defaultCfg.TokenInfo[tokenA],
+			// only has a feed on another chain than the feed chain
+			tokenB: {
+				Decimals:     18,
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Feeds: []pluginconfig.PriceFeed{
+					{ChainSelector: otherFeedChainSel, AggregatorAddress: "0x2222222222222222222222Ff18C45Df59775Fbb2"},
+				},
+			},
+		},
+		PriceFeedChainSelector: feedChainSel,
+	}
+
+	// the oracle does not support the feed chain, only the chain of the feed of tokenB
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.EXPECT().SupportedChains(mock.Anything).Return(mapset.NewSet(otherFeedChainSel), nil)
+
+	tokenPriceReader := readerpkg_mock.NewMockPriceReader(t)
+	tokenPriceReader.EXPECT().GetFeedPricesUSD(mock.Anything, []cciptypes.UnknownEncodedAddress{tokenB}).
+		Return(cciptypes.TokenPriceMap{tokenB: cciptypes.NewBigIntFromInt64(10)}, nil)
+
+	obs := newBaseObserver(tokenPriceReader, destChainSel, commontypes.OracleID(1), chainSupport, cfg, NoopMetrics{})
+	prices := obs.observeFeedTokenPrices(context.Background(), logger.Test(t))
+	assert.Equal(t, cciptypes.TokenPriceMap{tokenB: cciptypes.NewBigIntFromInt64(10)}, prices)
+}
+
+type invalidAnswersReporter struct {
+	NoopMetrics
+	reasons map[cciptypes.UnknownEncodedAddress]string
+}
+
+func (r *invalidAnswersReporter) TrackInvalidFeedAnswer(token cciptypes.UnknownEncodedAddress, reason string) {
+	r.reasons[token] = reason
+}
+
+func Test_baseObserver_observeFeedTokenPrices_invalidAnswers(t *testing.T) {
+	chainSupport := common_mock.NewMockChainSupport(t)
+	chainSupport.EXPECT().SupportedChains(mock.Anything).Return(mapset.NewSet(feedChainSel), nil)
+
+	tokenPriceReader := readerpkg_mock.NewMockPriceReader(t)
+	tokenPriceReader.EXPECT().GetFeedPricesUSD(mock.Anything, mock.Anything).
+		Return(cciptypes.TokenPriceMap{tokenA: cciptypes.NewBigIntFromInt64(10)}, &pkgreader.InvalidFeedAnswersError{
+			Answers: []pkgreader.InvalidFeedAnswer{{Token: tokenB, Reason: pkgreader.FeedAnswerStale}},
+		})
+
+	reporter := &invalidAnswersReporter{reasons: map[cciptypes.UnknownEncodedAddress]string{}}
+	obs := newBaseObserver(tokenPriceReader, destChainSel, commontypes.OracleID(1), chainSupport, defaultCfg, reporter)
+	prices := obs.observeFeedTokenPrices(context.Background(), logger.Test(t))
+
+	assert.Equal(t, cciptypes.TokenPriceMap{tokenA: cciptypes.NewBigIntFromInt64(10)}, prices)
+	assert.Equal(t, map[cciptypes.UnknownEncodedAddress]string{tokenB: pkgreader.FeedAnswerStale}, reporter.reasons)
+}
@@ -6,7 +6,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
-	"github.com/smartcontractkit/chainlink-ccip/internal/libs/mathslib"
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon/consensus"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
@@ -37,17 +37,11 @@ func (p *processor) getConsensusObservation(
 	}
 	timestamp := consensus.Median(aggObs.Timestamps, consensus.TimestampComparator)
 
-	fFeedChain, exists := fChains[p.offChainCfg.PriceFeedChainSelector]
-	if !exists {
-		return ConsensusObservation{},
-			fmt.Errorf("no consensus value for f for FeedChain: %d", p.offChainCfg.PriceFeedChainSelector)
-	}
-
 	feedPricesConsensus := consensus.GetConsensusMapAggregator(
 		lggr,
 		"FeedTokenPrices",
 		aggObs.FeedTokenPrices,
-		consensus.MakeConstantThreshold[cciptypes.UnknownEncodedAddress](consensus.TwoFPlus1(fFeedChain)),
+		consensus.MakeMultiThreshold(p.tokenPriceFs(lggr, fChains), consensus.TwoFPlus1),
 		func(vals []cciptypes.TokenPrice) cciptypes.TokenPrice {
 			return consensus.Median(vals, consensus.TokenPriceComparator)
 		},
@@ -73,59 +67,109 @@ func (p *processor) getConsensusObservation(
 	return consensusObs, nil
 }
 
+// tokenPriceFs returns the f used for the consensus on the feed price of each token: the largest f of the chains its
+// price is read from, see pluginconfig.CommitOffchainConfig.TokenPriceChains, so that 2f+1 observations are required
+// whichever of its feeds the oracles read. A token priced from its report only is not read from a chain, every oracle
+// can fetch the report and the role DON f is used. A token with a chain without a consensus f is left out, and so
+// not priced in this round.
+func (p *processor) tokenPriceFs(
+	lggr logger.Logger,
+	fChains map[cciptypes.ChainSelector]int,
+) map[cciptypes.UnknownEncodedAddress]int {
+	fTokens := make(map[cciptypes.UnknownEncodedAddress]int, len(p.offChainCfg.TokenInfo))
+	for token := range p.offChainCfg.TokenInfo {
+		chains := p.offChainCfg.TokenPriceChains(token)
+		if len(chains) == 0 {
+			fTokens[token] = p.fRoleDON
+			continue
+		}
+
+		fToken := 0
+		hasF := true
+		for _, chain := range chains {
+			fChain, ok := fChains[chain]
+			if !ok {
+				lggr.Warnw("no consensus value for f of a feed chain of the token", "token", token, "chain", chain)
+				hasF = false
+				break
+			}
+			fToken = max(fToken, fChain)
+		}
+		if hasF {
+			fTokens[token] = fToken
+		}
+	}
+	return fTokens
+}
+
 // selectTokensForUpdate checks which tokens need to be updated based on the observed token prices and
-// the fee quoter updates
+// the fee quoter updates. The decision is delegated to the configured update policy, by default
 // a token is selected for update if it meets one of 2 conditions:
-// 1. if time passed since the last update is greater than the stale threshold
+// 1. if time passed since the last update is greater than the token's heartbeat
 // 2. if deviation between the fee quoter and feed exceeds token's configured threshold
+// The selected tokens are always updated with the consensus feed price.
 func (p *processor) selectTokensForUpdate(
 	lggr logger.Logger,
 	obs ConsensusObservation,
-) cciptypes.TokenPriceMap {
-	tokenPrices := make(cciptypes.TokenPriceMap)
+	policyState updatepolicy.State,
+) (cciptypes.TokenPriceMap, updatepolicy.State) {
 	cfg := p.offChainCfg
 	tokenInfo := cfg.TokenInfo
 
+	candidates := make([]updatepolicy.Candidate, 0, len(obs.FeedTokenPrices))
 	for token, feedPrice := range obs.FeedTokenPrices {
-		lastUpdate, exists := obs.FeeQuoterTokenUpdates[token]
-		lggr := logger.With(lggr,
+		candidate := updatepolicy.Candidate{
+			Key:        string(token),
+			Components: []updatepolicy.Component{{Current: feedPrice.Price.Int}},
+			Heartbeat:  cfg.TokenPriceBatchWriteFrequency.Duration(),
+		}
+
+		if lastUpdate, exists := obs.FeeQuoterTokenUpdates[token]; exists {
+			ti, ok := tokenInfo[token]
+			if !ok {
+				lggr.Warnf("could not find token info for token %s", token)
+				continue
+			}
+			candidate.LastUpdate = lastUpdate.Timestamp
+			candidate.Components[0].Last = lastUpdate.Value.Int
+			candidate.Components[0].DeviationPPB = ti.DeviationPPB.Int64()
+			if heartbeat := ti.Heartbeat.Duration(); heartbeat > 0 {
+				candidate.Heartbeat = heartbeat
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	decisions, nextPolicyState := p.updatePolicy.Select(obs.Timestamp, candidates, policyState)
+
+	tokenPrices := make(cciptypes.TokenPriceMap, len(decisions))
+	for _, decision := range decisions {
+		token := cciptypes.UnknownEncodedAddress(decision.Key)
+		feedPrice := obs.FeedTokenPrices[token]
+		lggr.Infow("token price update needed",
 			"token", token,
+			"reason", decision.Reason,
 			"feedPrice", feedPrice,
-			"lastUpdate", lastUpdate,
+			"lastUpdate", obs.FeeQuoterTokenUpdates[token],
 			"consensusTimestamp", obs.Timestamp,
 		)
-		if !exists {
-			lggr.Infow("token price update needed: no previous update exists")
-			tokenPrices[token] = cciptypes.NewBigInt(feedPrice.Price.Int)
-			continue
-		}
-
-		ti, ok := tokenInfo[token]
-		if !ok {
-			lggr.Warnf("could not find token info for token %s", token)
-			continue
-		}
+		tokenPrices[token] = cciptypes.NewBigInt(feedPrice.Price.Int)
+	}
 
-		nextUpdateTime := lastUpdate.Timestamp.Add(cfg.TokenPriceBatchWriteFrequency.Duration())
-		priceDeviates := mathslib.Deviates(feedPrice.Price.Int, lastUpdate.Value.Int, ti.DeviationPPB.Int64())
-		heartbeatPassed := obs.Timestamp.After(nextUpdateTime)
-
-		if heartbeatPassed {
-			lggr.Infow("token price update needed: heartbeat time passed",
-				"nextUpdateTime", nextUpdateTime,
-				"heartbeatInterval", cfg.TokenPriceBatchWriteFrequency)
-			tokenPrices[token] = cciptypes.NewBigInt(feedPrice.Price.Int)
-		} else if priceDeviates {
-			lggr.Infow("token price update needed: deviation threshold exceeded",
-				"deviationPPB", ti.DeviationPPB)
-			tokenPrices[token] = cciptypes.NewBigInt(feedPrice.Price.Int)
-		} else {
-			lggr.Debugw("token price update not needed: within deviation threshold",
-				"deviationPPB", ti.DeviationPPB)
+	for _, candidate := range candidates {
+		token := cciptypes.UnknownEncodedAddress(candidate.Key)
+		if _, selected := tokenPrices[token]; !selected {
+			lggr.Debugw("token price update not needed",
+				"token", token,
+				"feedPrice", obs.FeedTokenPrices[token],
+				"lastUpdate", obs.FeeQuoterTokenUpdates[token],
+				"heartbeat", candidate.Heartbeat,
+				"deviationPPB", candidate.Components[0].DeviationPPB,
+			)
 		}
 	}
 
-	return tokenPrices
+	return tokenPrices, nextPolicyState
 }
 
 // aggregateObservations takes a list of observations and produces an AggregateObservation
@@ -11,6 +11,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/libocr/commontypes"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
@@ -51,10 +52,10 @@ var obs = Observation{
 var offChainCfg = pluginconfig.CommitOffchainConfig{
 	TokenPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Minute),
 	TokenInfo: map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
-		tokenA: {DeviationPPB: cbi(1)},
-		tokenB: {DeviationPPB: cbi(2)},
-		tokenC: {DeviationPPB: cbi(3)},
-		tokenD: {DeviationPPB: cbi(4)},
+		tokenA: {DeviationPPB: cbi(1), AggregatorAddress: "0x1"},
+		tokenB: {DeviationPPB: cbi(2), AggregatorAddress: "0x2"},
+		tokenC: {DeviationPPB: cbi(3), AggregatorAddress: "0x3"},
+		tokenD: {DeviationPPB: cbi(4), AggregatorAddress: "0x4"},
 	},
 	PriceFeedChainSelector: feedChainSel,
 }
@@ -106,15 +107,56 @@ func TestGetConsensusObservation(t *testing.T) {
 	assert.Len(t, consensusObs.FeedTokenPrices, 4)
 }
 
-func TestSelectTokensForUpdate(t *testing.T) {
+func TestGetConsensusObservation_tokenFeedChains(t *testing.T) {
 	lggr := logger.Test(t)
+	cfg := pluginconfig.CommitOffchainConfig{
+		TokenInfo: map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+			// on the feed chain, f = 2
+			tokenA: {DeviationPPB: cbi(1), AggregatorAddress: "0x1"},
+			// on another chain, f = 1
+			tokenB: {DeviationPPB: cbi(1), Feeds: []pluginconfig.PriceFeed{
+				{ChainSelector: otherFeedChainSel, AggregatorAddress: "0x2"}}},
+			// on a chain without a consensus f
+			tokenC: {DeviationPPB: cbi(1), Feeds: []pluginconfig.PriceFeed{
+				{ChainSelector: cciptypes.ChainSelector(1000), AggregatorAddress: "0x3"}}},
+			// priced from its report only, uses the role DON f = 1
+			tokenD: {DeviationPPB: cbi(1), ReportFeedID: cciptypes.Bytes32{0x1}},
+		},
+		PriceFeedChainSelector: feedChainSel,
+	}
 	p := &processor{
 		lggr:        lggr,
 		destChain:   destChainSel,
-		offChainCfg: offChainCfg,
+		offChainCfg: cfg,
 		fRoleDON:    1,
 	}
 
+	tokenObs := obs
+	tokenObs.FChain = map[cciptypes.ChainSelector]int{destChainSel: 1, feedChainSel: 2, otherFeedChainSel: 1}
+	aos := []plugincommon.AttributedObservation[Observation]{
+		{OracleID: 1, Observation: tokenObs},
+		{OracleID: 2, Observation: tokenObs},
+		{OracleID: 3, Observation: tokenObs},
+	}
+
+	consensusObs, err := p.getConsensusObservation(lggr, aos)
+	assert.NoError(t, err)
+	assert.Equal(t, map[cciptypes.UnknownEncodedAddress]cciptypes.TokenPrice{
+		tokenB: feedTokenPricesMap[tokenB],
+		tokenD: feedTokenPricesMap[tokenD],
+	}, consensusObs.FeedTokenPrices)
+}
+
+func TestSelectTokensForUpdate(t *testing.T) {
+	lggr := logger.Test(t)
+	p := &processor{
+		lggr:         lggr,
+		destChain:    destChainSel,
+		offChainCfg:  offChainCfg,
+		fRoleDON:     1,
+		updatePolicy: updatepolicy.Default(),
+	}
+
 	conObs := ConsensusObservation{
 		FeedTokenPrices:       feedTokenPricesMap,
 		FeeQuoterTokenUpdates: feeQuoterUpdates,
@@ -125,13 +167,49 @@ func TestSelectTokensForUpdate(t *testing.T) {
 	// tokenB will be updated because of deviation
 	// tokenC will be updated because it's not available on feeQuoter
 	// tokenD will not be updated because it's same price and time is not passed
-	tokenPrices := p.selectTokensForUpdate(lggr, conObs)
+	tokenPrices, _ := p.selectTokensForUpdate(lggr, conObs, updatepolicy.State{})
 	assert.Len(t, tokenPrices, 3)
 	assert.Equal(t, conObs.FeedTokenPrices[tokenA].Price, tokenPrices[tokenA])
 	assert.Equal(t, conObs.FeedTokenPrices[tokenB].Price, tokenPrices[tokenB])
 	assert.Equal(t, conObs.FeedTokenPrices[tokenC].Price, tokenPrices[tokenC])
 }
 
+func TestSelectTokensForUpdate_tokenHeartbeat(t *testing.T) {
+	lggr := logger.Test(t)
+	cfg := offChainCfg
+	cfg.TokenInfo = map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+		tokenA: {DeviationPPB: cbi(1), Heartbeat: *commonconfig.MustNewDuration(5 * time.Minute)},
+		tokenD: {DeviationPPB: cbi(4), Heartbeat: *commonconfig.MustNewDuration(30 * time.Second)},
+	}
+	policy, err := updatepolicy.New(pluginconfig.UpdatePolicyConfig{MaxUpdatesPerHour: 10})
+	assert.NoError(t, err)
+	p := &processor{
+		lggr:         lggr,
+		destChain:    destChainSel,
+		offChainCfg:  cfg,
+		fRoleDON:     1,
+		updatePolicy: policy,
+	}
+
+	conObs := ConsensusObservation{
+		FeedTokenPrices: map[cciptypes.UnknownEncodedAddress]cciptypes.TokenPrice{
+			tokenA: feedTokenPricesMap[tokenA],
+			tokenD: feedTokenPricesMap[tokenD],
+		},
+		FeeQuoterTokenUpdates: map[cciptypes.UnknownEncodedAddress]cciptypes.TimestampedBig{
+			// the global heartbeat passed but not the token's one
+			tokenA: {Timestamp: ts.Add(-2 * time.Minute), Value: cbi100},
+			// the global heartbeat did not pass but the token's one did
+			tokenD: {Timestamp: ts.Add(-45 * time.Second), Value: feedTokenPricesMap[tokenD].Price},
+		},
+		Timestamp: ts,
+	}
+
+	tokenPrices, state := p.selectTokensForUpdate(lggr, conObs, updatepolicy.State{})
+	assert.Equal(t, cciptypes.TokenPriceMap{tokenD: feedTokenPricesMap[tokenD].Price}, tokenPrices)
+	assert.Equal(t, []time.Time{ts}, state.Updates)
+}
+
 // Test Plugin Outcome method returns the correct token prices
 func TestOutcome(t *testing.T) {
 	ctx := tests.Context(t)
@@ -141,7 +219,8 @@ func TestOutcome(t *testing.T) {
 		destChain:       destChainSel,
 		offChainCfg:     offChainCfg,
 		fRoleDON:        1,
-		metricsReporter: plugincommon.NoopReporter{},
+		metricsReporter: NoopMetrics{},
+		updatePolicy:    updatepolicy.Default(),
 	}
 
 	outcome, err := p.Outcome(ctx, Outcome{}, Query{}, []plugincommon.AttributedObservation[Observation]{
@@ -175,7 +254,8 @@ func TestOutcome_EmptyObservations(t *testing.T) {
 		destChain:       destChainSel,
 		offChainCfg:     offChainCfg,
 		fRoleDON:        fChains[destChainSel], // Use f from fChains for the destination chain
-		metricsReporter: plugincommon.NoopReporter{},
+		metricsReporter: NoopMetrics{},
+		updatePolicy:    updatepolicy.Default(),
 	}
 
 	// Prepare attributed observations with only minimal data
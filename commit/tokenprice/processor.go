@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/libocr/commontypes"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
 	"github.com/smartcontractkit/chainlink-ccip/internal/reader"
 	"github.com/smartcontractkit/chainlink-ccip/pkg/consts"
@@ -25,9 +26,10 @@ type processor struct {
 	chainSupport     plugincommon.ChainSupport
 	tokenPriceReader pkgreader.PriceReader
 	homeChain        reader.HomeChain
-	metricsReporter  plugincommon.MetricsReporter
+	metricsReporter  MetricsReporter
 	fRoleDON         int
 	obs              observer
+	updatePolicy     updatepolicy.Policy
 }
 
 func NewProcessor(
@@ -39,7 +41,7 @@ func NewProcessor(
 	tokenPriceReader pkgreader.PriceReader,
 	homeChain reader.HomeChain,
 	fRoleDON int,
-	metricsReporter plugincommon.MetricsReporter,
+	metricsReporter MetricsReporter,
 ) plugincommon.PluginProcessor[Query, Observation, Outcome] {
 	var obs observer
 	baseObs := newBaseObserver(
@@ -48,6 +50,7 @@ func NewProcessor(
 		oracleID,
 		chainSupport,
 		offChainCfg,
+		metricsReporter,
 	)
 	if !offChainCfg.TokenPriceAsyncObserverDisabled {
 		obs = newAsyncObserver(
@@ -59,6 +62,11 @@ func NewProcessor(
 	} else {
 		obs = baseObs
 	}
+	updatePolicy, err := updatepolicy.New(offChainCfg.TokenPriceUpdatePolicy)
+	if err != nil {
+		lggr.Errorw("invalid token price update policy, falling back to the default policy", "err", err)
+		updatePolicy = updatepolicy.Default()
+	}
 	p := &processor{
 		oracleID:         oracleID,
 		lggr:             lggr,
@@ -70,6 +78,7 @@ func NewProcessor(
 		fRoleDON:         fRoleDON,
 		metricsReporter:  metricsReporter,
 		obs:              obs,
+		updatePolicy:     updatePolicy,
 	}
 	return plugincommon.NewTrackedProcessor(lggr, p, processorsLabel, metricsReporter)
 }
@@ -80,7 +89,7 @@ func (p *processor) Query(ctx context.Context, prevOutcome Outcome) (Query, erro
 
 func (p *processor) Outcome(
 	ctx context.Context,
-	_ Outcome,
+	prevOutcome Outcome,
 	_ Query,
 	aos []plugincommon.AttributedObservation[Observation],
 ) (Outcome, error) {
@@ -99,10 +108,12 @@ func (p *processor) Outcome(
 
 	consensusObservation, err := p.getConsensusObservation(lggr, aos)
 	if err != nil {
-		return Outcome{}, fmt.Errorf("get consensus observation: %w", err)
+		// Keep the policy state, prices are not observed while previous prices are inflight.
+		return Outcome{UpdatePolicyState: prevOutcome.UpdatePolicyState},
+			fmt.Errorf("get consensus observation: %w", err)
 	}
 
-	tokenPriceOutcome := p.selectTokensForUpdate(lggr, consensusObservation)
+	tokenPriceOutcome, policyState := p.selectTokensForUpdate(lggr, consensusObservation, prevOutcome.UpdatePolicyState)
 	lggr.Infow(
 		"outcome token prices",
 		"tokenPrices", tokenPriceOutcome,
@@ -110,10 +121,10 @@ func (p *processor) Outcome(
 
 	if len(tokenPriceOutcome) == 0 {
 		lggr.Debugw("No token prices to report")
-		return Outcome{}, nil
+		return Outcome{UpdatePolicyState: policyState}, nil
 	}
 
-	out := Outcome{TokenPrices: tokenPriceOutcome}
+	out := Outcome{TokenPrices: tokenPriceOutcome, UpdatePolicyState: policyState}
 	return out, nil
 }
 
@@ -18,6 +18,8 @@ var (
 	feedChainSel = cciptypes.ChainSelector(1)
 	destChainSel = cciptypes.ChainSelector(2)
 	f            = 1
+
+	otherFeedChainSel = cciptypes.ChainSelector(999)
 )
 
 func bi(i int) *big.Int {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon"
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 )
 
@@ -14,11 +17,30 @@ const (
 	feeQuoterTokenUpdatesLabel = "feeQuoterTokenUpdates"
 )
 
+// MetricsReporter exposes only relevant methods for reporting token prices from metrics.Reporter
+type MetricsReporter interface {
+	TrackProcessorLatency(processor string, method string, latency time.Duration, err error)
+	TrackProcessorOutput(processor string, method plugincommon.MethodType, obs plugintypes.Trackable)
+	// TrackInvalidFeedAnswer tracks a feed answer of a token ignored by the price reader, see pkgreader.FeedAnswerStale
+	// and the other reasons.
+	TrackInvalidFeedAnswer(token cciptypes.UnknownEncodedAddress, reason string)
+}
+
+type NoopMetrics struct{}
+
+func (n NoopMetrics) TrackProcessorLatency(string, string, time.Duration, error) {}
+
+func (n NoopMetrics) TrackProcessorOutput(string, plugincommon.MethodType, plugintypes.Trackable) {}
+
+func (n NoopMetrics) TrackInvalidFeedAnswer(cciptypes.UnknownEncodedAddress, string) {}
+
 type Query struct {
 }
 
 type Outcome struct {
 	TokenPrices cciptypes.TokenPriceMap `json:"tokenPrices"`
+	// UpdatePolicyState is the state of the token price update policy, carried to the next round.
+	UpdatePolicyState updatepolicy.State `json:"updatePolicyState"`
 }
 
 func (out Outcome) Stats() map[string]int {
@@ -29,16 +29,18 @@ func (p *processor) ValidateObservation(
 		return fmt.Errorf("failed to get supported chains: %w", err)
 	}
 
-	if len(obs.FeedTokenPrices) > 0 && !supportedChains.Contains(p.offChainCfg.PriceFeedChainSelector) {
-		return fmt.Errorf("feed chain must be supported to read feed token prices, oreacleID: %d feedChain: %d",
-			ao.OracleID, p.offChainCfg.PriceFeedChainSelector)
-
-	}
-
 	if err = validateObservedTokenPrices(obs.FeedTokenPrices, p.offChainCfg.TokenInfo); err != nil {
 		return fmt.Errorf("failed to validate observed token prices: %w", err)
 	}
 
+	// The price of a token is read from the chains of its own feeds, which can differ from the feed chain.
+	for token := range obs.FeedTokenPrices {
+		if !hasSupportedPriceSource(p.offChainCfg, p.offChainCfg.TokenInfo[token], supportedChains) {
+			return fmt.Errorf("the chains of the price feeds of token %s must be supported to read its price, "+
+				"oracleID: %d feedChains: %v", token, ao.OracleID, p.offChainCfg.TokenPriceChains(token))
+		}
+	}
+
 	if len(obs.FeeQuoterTokenUpdates) > 0 && !supportedChains.Contains(p.destChain) {
 		return fmt.Errorf("dest chain must be supported to read fee quoter token updates "+
 			"oracleID: %d destChain: %d", ao.OracleID, p.destChain)
@@ -25,10 +25,12 @@ var (
 	defaultOffChainConfig = pluginconfig.CommitOffchainConfig{
 		PriceFeedChainSelector: feedChainSel,
 		TokenInfo: map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
-			"0x1": {},
-			"0x2": {},
-			"0x3": {},
-			"0xa": {},
+			"0x1": {AggregatorAddress: "0x11"},
+			"0x2": {AggregatorAddress: "0x12"},
+			"0x3": {AggregatorAddress: "0x13"},
+			"0xa": {AggregatorAddress: "0x1a"},
+			// priced from a feed on another chain than the feed chain
+			"0xb": {Feeds: []pluginconfig.PriceFeed{{ChainSelector: otherFeedChainSel, AggregatorAddress: "0x1b"}}},
 		},
 	}
 	defaultTokensToQuery = map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
@@ -190,6 +192,33 @@ func TestValidateObservation(t *testing.T) {
 			},
 			expErr: true,
 		},
+		{
+			name: "token priced from another chain than the feed chain",
+			obs: func() Observation {
+				obs := defaultObs
+				obs.FeedTokenPrices = cciptypes.TokenPriceMap{"0xb": oneBig}
+				return obs
+			},
+			chainSupportMock: func() *commonmock.MockChainSupport {
+				mock := commonmock.NewMockChainSupport(t)
+				sc := mapset.NewSet[cciptypes.ChainSelector](otherFeedChainSel, destChainSel)
+				mock.On("SupportedChains", oracleID).Return(sc, nil)
+				return mock
+			},
+			expErr: false,
+		},
+		{
+			name: "unsupported chain of the token feed",
+			obs: func() Observation {
+				obs := defaultObs
+				obs.FeedTokenPrices = cciptypes.TokenPriceMap{"0xb": oneBig}
+				return obs
+			},
+			chainSupportMock: func() *commonmock.MockChainSupport {
+				return chainSupport
+			},
+			expErr: true,
+		},
 		{
 			name: "invalid token price",
 			obs: func() Observation {
@@ -0,0 +1,254 @@
+// Package updatepolicy decides which token prices and chain fees the commit plugin writes to the destination chain.
+//
+// Policies run in the Outcome phase on consensus values, so they must be deterministic. Anything a policy needs to
+// remember across rounds is kept in State, which is carried in the processor outcomes.
+//
+// Policies only know about the outcomes, not about the reports that land onchain: the update budget counts the
+// candidates selected in the outcomes, even if their report is not transmitted, and the moving average of the ewma
+// policy is updated once per Select call, i.e. once per OCR round in which the processor selects updates.
+package updatepolicy
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-ccip/internal/libs/mathslib"
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+)
+
+// budgetWindow is the rolling window of pluginconfig.UpdatePolicyConfig.MaxUpdatesPerHour.
+const budgetWindow = time.Hour
+
+// Reason is why a candidate was selected for update.
+type Reason string
+
+const (
+	ReasonNoPreviousUpdate Reason = "noPreviousUpdate"
+	ReasonHeartbeat        Reason = "heartbeat"
+	ReasonDeviation        Reason = "deviation"
+)
+
+// priority orders the reasons when the update budget does not allow every selected candidate to be written.
+func (r Reason) priority() int {
+	switch r {
+	case ReasonNoPreviousUpdate:
+		return 0
+	case ReasonHeartbeat:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Component is a single value of a candidate, e.g. the execution fee of a chain fee.
+type Component struct {
+	// Current is the value observed in this round.
+	Current *big.Int
+	// Last is the value stored onchain, ignored when the candidate has no previous update.
+	Last *big.Int
+	// DeviationPPB is the deviation, in parts per billion, above which the component has to be written.
+	DeviationPPB int64
+}
+
+// Candidate is a token price or a chain fee that may be written onchain.
+type Candidate struct {
+	// Key identifies the candidate across rounds, e.g. the token address or the chain selector.
+	Key string
+	// Components are compared independently, the candidate deviates if any of them deviates.
+	Components []Component
+	// LastUpdate is the time of the last onchain update, zero if the value was never written.
+	LastUpdate time.Time
+	// Heartbeat is the maximum time between two updates.
+	Heartbeat time.Duration
+	// DeviationDisabled skips the deviation checks, the candidate is only written when its heartbeat passes.
+	DeviationDisabled bool
+}
+
+// Decision is a candidate selected for update.
+type Decision struct {
+	Key    string
+	Reason Reason
+}
+
+// State is what a policy remembers across rounds.
+type State struct {
+	// Smoothed holds the moving average of the components of every candidate, used by the ewma policy.
+	Smoothed map[string][]cciptypes.BigInt `json:"smoothed"`
+	// Updates holds the time of every update selected within the budget window, used by the budget cap. There are
+	// at most pluginconfig.MaxMaxUpdatesPerHour of them.
+	Updates []time.Time `json:"updates"`
+}
+
+// Policy selects the candidates that have to be written onchain.
+type Policy interface {
+	// Select returns the decisions for the candidates to update at now, sorted by key, and the state to provide to
+	// the next call.
+	Select(now time.Time, candidates []Candidate, state State) ([]Decision, State)
+}
+
+// New returns the policy described by the provided config.
+func New(cfg pluginconfig.UpdatePolicyConfig) (Policy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid update policy config: %w", err)
+	}
+
+	p := &policy{maxUpdatesPerHour: cfg.MaxUpdatesPerHour}
+	switch cfg.Type {
+	case "", pluginconfig.UpdatePolicyHeartbeatDeviation:
+		p.deviates = heartbeatDeviation
+	case pluginconfig.UpdatePolicyTimeWeighted:
+		p.deviates = timeWeighted
+	case pluginconfig.UpdatePolicyEWMA:
+		p.ewmaAlphaPPB = cfg.EWMAAlphaPPB
+		p.deviates = heartbeatDeviation
+	}
+	return p, nil
+}
+
+// Default returns the pluginconfig.UpdatePolicyHeartbeatDeviation policy without an update budget.
+func Default() Policy {
+	return &policy{deviates: heartbeatDeviation}
+}
+
+// deviatesFunc reports whether the value of a component deviates from its onchain value, given the time passed
+// since the last update.
+type deviatesFunc func(value *big.Int, c Component, elapsed, heartbeat time.Duration) bool
+
+type policy struct {
+	deviates          deviatesFunc
+	ewmaAlphaPPB      int64
+	maxUpdatesPerHour uint64
+}
+
+func (p *policy) Select(now time.Time, candidates []Candidate, state State) ([]Decision, State) {
+	var nextState State
+	if p.maxUpdatesPerHour > 0 {
+		nextState.Updates = budgetUpdates(now, state.Updates)
+	}
+	if p.ewmaAlphaPPB > 0 {
+		nextState.Smoothed = make(map[string][]cciptypes.BigInt, len(candidates))
+	}
+
+	var decisions []Decision
+	for _, c := range candidates {
+		values := make([]*big.Int, len(c.Components))
+		for i, comp := range c.Components {
+			values[i] = comp.Current
+		}
+		if p.ewmaAlphaPPB > 0 {
+			values = ewma(p.ewmaAlphaPPB, values, state.Smoothed[c.Key])
+			nextState.Smoothed[c.Key] = make([]cciptypes.BigInt, len(values))
+			for i, v := range values {
+				nextState.Smoothed[c.Key][i] = cciptypes.NewBigInt(v)
+			}
+		}
+
+		if reason, ok := p.reason(now, c, values); ok {
+			decisions = append(decisions, Decision{Key: c.Key, Reason: reason})
+		}
+	}
+
+	if p.maxUpdatesPerHour > 0 {
+		decisions = p.applyBudget(decisions, candidates, uint64(len(nextState.Updates)))
+		for range decisions {
+			nextState.Updates = append(nextState.Updates, now)
+		}
+	}
+
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].Key < decisions[j].Key })
+	return decisions, nextState
+}
+
+func (p *policy) reason(now time.Time, c Candidate, values []*big.Int) (Reason, bool) {
+	if c.LastUpdate.IsZero() {
+		return ReasonNoPreviousUpdate, true
+	}
+
+	elapsed := now.Sub(c.LastUpdate)
+	if now.After(c.LastUpdate.Add(c.Heartbeat)) {
+		return ReasonHeartbeat, true
+	}
+
+	if c.DeviationDisabled {
+		return "", false
+	}
+	for i, comp := range c.Components {
+		if p.deviates(values[i], comp, elapsed, c.Heartbeat) {
+			return ReasonDeviation, true
+		}
+	}
+	return "", false
+}
+
+// applyBudget keeps the decisions with the highest priority that fit in the remaining budget.
+func (p *policy) applyBudget(decisions []Decision, candidates []Candidate, spent uint64) []Decision {
+	remaining := p.maxUpdatesPerHour - min(spent, p.maxUpdatesPerHour)
+	if uint64(len(decisions)) <= remaining {
+		return decisions
+	}
+	if remaining == 0 {
+		return nil
+	}
+
+	lastUpdates := make(map[string]time.Time, len(candidates))
+	for _, c := range candidates {
+		lastUpdates[c.Key] = c.LastUpdate
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		a, b := decisions[i], decisions[j]
+		if a.Reason.priority() != b.Reason.priority() {
+			return a.Reason.priority() < b.Reason.priority()
+		}
+		if !lastUpdates[a.Key].Equal(lastUpdates[b.Key]) {
+			return lastUpdates[a.Key].Before(lastUpdates[b.Key])
+		}
+		return strings.Compare(a.Key, b.Key) < 0
+	})
+	return decisions[:remaining]
+}
+
+// budgetUpdates returns the updates that are still within the budget window at now.
+func budgetUpdates(now time.Time, updates []time.Time) []time.Time {
+	var res []time.Time
+	for _, u := range updates {
+		if now.Sub(u) < budgetWindow {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+func heartbeatDeviation(value *big.Int, c Component, _, _ time.Duration) bool {
+	return mathslib.Deviates(value, c.Last, c.DeviationPPB)
+}
+
+// timeWeighted scales the deviation threshold down linearly with the time passed since the last update.
+func timeWeighted(value *big.Int, c Component, elapsed, heartbeat time.Duration) bool {
+	if heartbeat <= 0 || elapsed <= 0 {
+		return mathslib.Deviates(value, c.Last, c.DeviationPPB)
+	}
+	remaining := max(heartbeat-elapsed, 0)
+	threshold := new(big.Int).Mul(big.NewInt(c.DeviationPPB), big.NewInt(int64(remaining)))
+	threshold.Div(threshold, big.NewInt(int64(heartbeat)))
+	return mathslib.Deviates(value, c.Last, threshold.Int64())
+}
+
+// ewma returns alpha*values + (1-alpha)*smoothed for every component, alpha being in parts per billion.
+// The moving average starts from the observed values if there is no previous average for all the components.
+func ewma(alphaPPB int64, values []*big.Int, smoothed []cciptypes.BigInt) []*big.Int {
+	res := make([]*big.Int, len(values))
+	for i, v := range values {
+		if len(smoothed) != len(values) || smoothed[i].Int == nil {
+			res[i] = new(big.Int).Set(v)
+			continue
+		}
+		weighted := new(big.Int).Mul(v, big.NewInt(alphaPPB))
+		prev := new(big.Int).Mul(smoothed[i].Int, big.NewInt(1e9-alphaPPB))
+		res[i] = weighted.Add(weighted, prev).Div(weighted, big.NewInt(1e9))
+	}
+	return res
+}
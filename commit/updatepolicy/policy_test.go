@@ -0,0 +1,197 @@
+package updatepolicy
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+)
+
+var now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// candidate returns a candidate with a single component, last updated `ago` before now.
+func candidate(key string, current, last int64, ago time.Duration) Candidate {
+	c := Candidate{
+		Key: key,
+		Components: []Component{{
+			Current:      big.NewInt(current),
+			Last:         big.NewInt(last),
+			DeviationPPB: 1e8, // 10%
+		}},
+		Heartbeat: time.Hour,
+	}
+	if ago >= 0 {
+		c.LastUpdate = now.Add(-ago)
+	}
+	return c
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(pluginconfig.UpdatePolicyConfig{})
+	require.NoError(t, err)
+	_, err = New(pluginconfig.UpdatePolicyConfig{Type: pluginconfig.UpdatePolicyEWMA, EWMAAlphaPPB: 5e8})
+	require.NoError(t, err)
+	_, err = New(pluginconfig.UpdatePolicyConfig{Type: pluginconfig.UpdatePolicyEWMA})
+	require.Error(t, err)
+	_, err = New(pluginconfig.UpdatePolicyConfig{Type: "unknown"})
+	require.Error(t, err)
+}
+
+func TestPolicy_Select(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cfg          pluginconfig.UpdatePolicyConfig
+		candidates   []Candidate
+		state        State
+		expDecisions []Decision
+	}{
+		{
+			name: "heartbeat and deviation",
+			candidates: []Candidate{
+				candidate("a", 100, 100, -1),             // never written
+				candidate("b", 100, 100, 2*time.Hour),    // heartbeat passed
+				candidate("c", 120, 100, time.Minute),    // deviates
+				candidate("d", 105, 100, time.Minute),    // within deviation
+				candidate("e", 105, 100, 59*time.Minute), // within deviation, heartbeat not passed
+			},
+			expDecisions: []Decision{
+				{Key: "a", Reason: ReasonNoPreviousUpdate},
+				{Key: "b", Reason: ReasonHeartbeat},
+				{Key: "c", Reason: ReasonDeviation},
+			},
+		},
+		{
+			name: "deviation disabled",
+			candidates: func() []Candidate {
+				c1 := candidate("a", 200, 100, time.Minute)
+				c1.DeviationDisabled = true
+				c2 := candidate("b", 200, 100, 2*time.Hour)
+				c2.DeviationDisabled = true
+				return []Candidate{c1, c2}
+			}(),
+			expDecisions: []Decision{{Key: "b", Reason: ReasonHeartbeat}},
+		},
+		{
+			name: "any component deviates",
+			candidates: []Candidate{{
+				Key: "a",
+				Components: []Component{
+					{Current: big.NewInt(100), Last: big.NewInt(100), DeviationPPB: 1e8},
+					{Current: big.NewInt(200), Last: big.NewInt(100), DeviationPPB: 1e8},
+				},
+				LastUpdate: now.Add(-time.Minute),
+				Heartbeat:  time.Hour,
+			}},
+			expDecisions: []Decision{{Key: "a", Reason: ReasonDeviation}},
+		},
+		{
+			name: "time weighted threshold decreases with time",
+			cfg:  pluginconfig.UpdatePolicyConfig{Type: pluginconfig.UpdatePolicyTimeWeighted},
+			candidates: []Candidate{
+				candidate("a", 105, 100, 6*time.Minute),  // threshold 9% > 5%
+				candidate("b", 105, 100, 45*time.Minute), // threshold 2.5% < 5%
+			},
+			expDecisions: []Decision{{Key: "b", Reason: ReasonDeviation}},
+		},
+		{
+			name: "ewma smooths a spike",
+			cfg:  pluginconfig.UpdatePolicyConfig{Type: pluginconfig.UpdatePolicyEWMA, EWMAAlphaPPB: 2e8},
+			candidates: []Candidate{
+				candidate("a", 150, 100, time.Minute), // average 110, deviation exactly 10%
+				candidate("b", 200, 100, time.Minute), // average 120
+			},
+			state: State{Smoothed: map[string][]cciptypes.BigInt{
+				"a": {cciptypes.NewBigIntFromInt64(100)},
+				"b": {cciptypes.NewBigIntFromInt64(100)},
+			}},
+			expDecisions: []Decision{{Key: "b", Reason: ReasonDeviation}},
+		},
+		{
+			name: "ewma starts from the observed value",
+			cfg:  pluginconfig.UpdatePolicyConfig{Type: pluginconfig.UpdatePolicyEWMA, EWMAAlphaPPB: 2e8},
+			candidates: []Candidate{
+				candidate("a", 150, 100, time.Minute),
+			},
+			expDecisions: []Decision{{Key: "a", Reason: ReasonDeviation}},
+		},
+		{
+			name: "budget keeps the highest priority updates",
+			cfg:  pluginconfig.UpdatePolicyConfig{MaxUpdatesPerHour: 4},
+			candidates: []Candidate{
+				candidate("a", 200, 100, time.Minute),
+				candidate("b", 200, 100, 2*time.Minute),
+				candidate("c", 100, 100, 2*time.Hour),
+				candidate("d", 100, 100, -1),
+			},
+			state: State{Updates: []time.Time{
+				now.Add(-10 * time.Minute),
+				now.Add(-2 * time.Hour), // outside the window
+			}},
+			expDecisions: []Decision{
+				{Key: "b", Reason: ReasonDeviation},
+				{Key: "c", Reason: ReasonHeartbeat},
+				{Key: "d", Reason: ReasonNoPreviousUpdate},
+			},
+		},
+		{
+			name: "budget exhausted",
+			cfg:  pluginconfig.UpdatePolicyConfig{MaxUpdatesPerHour: 1},
+			candidates: []Candidate{
+				candidate("a", 100, 100, -1),
+			},
+			state: State{Updates: []time.Time{now.Add(-59 * time.Minute)}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.cfg)
+			require.NoError(t, err)
+
+			decisions, _ := p.Select(now, tc.candidates, tc.state)
+			assert.Equal(t, tc.expDecisions, decisions)
+		})
+	}
+}
+
+func TestPolicy_Select_state(t *testing.T) {
+	p, err := New(pluginconfig.UpdatePolicyConfig{
+		Type:              pluginconfig.UpdatePolicyEWMA,
+		EWMAAlphaPPB:      5e8,
+		MaxUpdatesPerHour: 2,
+	})
+	require.NoError(t, err)
+
+	var state State
+	var written []string
+	for round := 0; round < 4; round++ {
+		ts := now.Add(time.Duration(round) * 20 * time.Minute)
+		candidates := []Candidate{
+			{Key: "a", Components: []Component{{Current: big.NewInt(100)}}, Heartbeat: time.Hour},
+			{Key: "b", Components: []Component{{Current: big.NewInt(200)}}, Heartbeat: time.Hour},
+		}
+
+		var decisions []Decision
+		decisions, state = p.Select(ts, candidates, state)
+		for _, d := range decisions {
+			written = append(written, d.Key)
+		}
+		assert.LessOrEqual(t, len(state.Updates), 2)
+		assert.Equal(t, []cciptypes.BigInt{cciptypes.NewBigIntFromInt64(100)}, state.Smoothed["a"])
+		assert.Equal(t, []cciptypes.BigInt{cciptypes.NewBigIntFromInt64(200)}, state.Smoothed["b"])
+	}
+
+	// Both updates fit in the first round, the budget is free again one hour later.
+	assert.Equal(t, []string{"a", "b", "a", "b"}, written)
+}
+
+func TestDefault(t *testing.T) {
+	decisions, state := Default().Select(now, []Candidate{candidate("a", 100, 100, -1)}, State{})
+	assert.Equal(t, []Decision{{Key: "a", Reason: ReasonNoPreviousUpdate}}, decisions)
+	assert.Equal(t, State{}, state)
+}
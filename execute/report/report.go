@@ -107,7 +107,7 @@ func buildSingleChainReportHelper(
 		Messages:            msgInRoot,
 		OffchainTokenData:   offchainTokenData,
 		Proofs:              proofsCast,
-		ProofFlagBits:       ccipocr3.BigInt{Int: slicelib.BoolsToBitFlags(proof.SourceFlags)},
+		ProofFlagBits:       ccipocr3.BigInt{Int: proof.FlagBits()},
 	}
 
 	lggr.Debugw("in-progress report built",
@@ -149,6 +149,7 @@ func assertMerkleRoot(
 	for i, p := range execReport.Proofs {
 		copy(proofCast[i][:], p[:32])
 	}
+	// verified with chainlink-common's merklemulti, independently of the pkg/merkle code building the proofs
 	var proof merklemulti.Proof[[32]byte]
 	proof.Hashes = proofCast
 	proof.SourceFlags = slicelib.BitFlagsToBools(execReport.ProofFlagBits.Int, len(leaves)+len(proofCast)-1)
@@ -5,16 +5,16 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
-	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
 
 	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/merkle"
 )
 
 // ConstructMerkleTree creates the merkle tree object from the messages in the report.
 func ConstructMerkleTree(
 	report exectypes.CommitData,
 	lggr logger.Logger,
-) (*merklemulti.Tree[[32]byte], error) {
+) (*merkle.Tree, error) {
 	// Ensure we have the expected number of messages
 	numMsgs := int(report.SequenceNumberRange.End() - report.SequenceNumberRange.Start() + 1)
 	if numMsgs != len(report.Messages) {
@@ -54,5 +54,5 @@ func ConstructMerkleTree(
 	}
 
 	// TODO: Do not hard code the hash function, it should be derived from the message hasher.
-	return merklemulti.NewTree(hashutil.NewKeccak(), treeLeaves)
+	return merkle.NewTree(hashutil.NewKeccak(), treeLeaves)
 }
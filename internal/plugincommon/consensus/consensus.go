@@ -50,12 +50,27 @@ func GetConsensusMap[K comparable, T any](
 // Aggregator is a function type that aggregates a slice of values into a single value.
 type Aggregator[T any] func(vals []T) T
 
+// KeyAggregator is like Aggregator but also receives the key of the aggregated values.
+type KeyAggregator[K comparable, T any] func(key K, vals []T) T
+
 func GetConsensusMapAggregator[K comparable, T any](
 	lggr logger.Logger,
 	objectName string,
 	items map[K][]T,
 	f MultiThreshold[K],
 	agg Aggregator[T],
+) map[K]T {
+	return GetConsensusMapKeyAggregator(lggr, objectName, items, f, func(_ K, vals []T) T { return agg(vals) })
+}
+
+// GetConsensusMapKeyAggregator is like GetConsensusMapAggregator but the aggregator also receives the key,
+// e.g. to log or report the values it discards.
+func GetConsensusMapKeyAggregator[K comparable, T any](
+	lggr logger.Logger,
+	objectName string,
+	items map[K][]T,
+	f MultiThreshold[K],
+	agg KeyAggregator[K, T],
 ) map[K]T {
 	consensus := make(map[K]T)
 
@@ -66,7 +81,7 @@ func GetConsensusMapAggregator[K comparable, T any](
 				"key", key)
 			continue
 		}
-		consensus[key] = agg(values)
+		consensus[key] = agg(key, values)
 	}
 	return consensus
 }
@@ -0,0 +1,40 @@
+package consensus
+
+import (
+	"math/big"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// minOutlierObservations is the minimum number of values for the median absolute deviation to be meaningful.
+const minOutlierObservations = 3
+
+// RejectOutliers splits vals into the values within k median absolute deviations (MAD) of their median and the
+// outliers, both in their original order.
+// Nothing is rejected when k is zero, when there are less than 3 values, or when the MAD is zero, i.e. most values
+// are equal to the median which is then not affected by the others.
+func RejectOutliers(vals []cciptypes.BigInt, k uint64) (inliers, outliers []cciptypes.BigInt) {
+	if k == 0 || len(vals) < minOutlierObservations {
+		return vals, nil
+	}
+
+	median := Median(vals, BigIntComparator)
+	deviations := make([]cciptypes.BigInt, len(vals))
+	for i, v := range vals {
+		deviations[i] = cciptypes.NewBigInt(new(big.Int).Abs(new(big.Int).Sub(v.Int, median.Int)))
+	}
+	mad := Median(deviations, BigIntComparator)
+	if mad.Sign() == 0 {
+		return vals, nil
+	}
+	limit := new(big.Int).Mul(mad.Int, new(big.Int).SetUint64(k))
+
+	for i, v := range vals {
+		if deviations[i].Cmp(limit) > 0 {
+			outliers = append(outliers, v)
+			continue
+		}
+		inliers = append(inliers, v)
+	}
+	return inliers, outliers
+}
@@ -0,0 +1,77 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+func TestRejectOutliers(t *testing.T) {
+	bigInts := func(vals ...int64) []cciptypes.BigInt {
+		if len(vals) == 0 {
+			return nil
+		}
+		res := make([]cciptypes.BigInt, len(vals))
+		for i, v := range vals {
+			res[i] = cciptypes.NewBigIntFromInt64(v)
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name        string
+		vals        []cciptypes.BigInt
+		k           uint64
+		expInliers  []cciptypes.BigInt
+		expOutliers []cciptypes.BigInt
+	}{
+		{
+			name:       "disabled",
+			vals:       bigInts(10, 11, 1000),
+			k:          0,
+			expInliers: bigInts(10, 11, 1000),
+		},
+		{
+			name:       "not enough values",
+			vals:       bigInts(10, 1000),
+			k:          3,
+			expInliers: bigInts(10, 1000),
+		},
+		{
+			name:        "spike is rejected",
+			vals:        bigInts(100, 1000000, 102, 98, 101),
+			k:           3,
+			expInliers:  bigInts(100, 102, 98, 101),
+			expOutliers: bigInts(1000000),
+		},
+		{
+			name:        "values on both sides are rejected",
+			vals:        bigInts(1, 100, 102, 98, 101, 99, 10000),
+			k:           5,
+			expInliers:  bigInts(100, 102, 98, 101, 99),
+			expOutliers: bigInts(1, 10000),
+		},
+		{
+			name:       "values within k mad are kept",
+			vals:       bigInts(100, 110, 120, 130, 140),
+			k:          2,
+			expInliers: bigInts(100, 110, 120, 130, 140),
+		},
+		{
+			name:       "zero mad",
+			vals:       bigInts(100, 100, 100, 101, 1000000),
+			k:          10,
+			expInliers: bigInts(100, 100, 100, 101, 1000000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inliers, outliers := RejectOutliers(tc.vals, tc.k)
+			assert.Equal(t, tc.expInliers, inliers)
+			assert.Equal(t, tc.expOutliers, outliers)
+		})
+	}
+}
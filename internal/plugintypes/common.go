@@ -23,6 +23,9 @@ type ChainRange struct {
 	SeqNumRange cciptypes.SeqNumRange   `json:"seqNumRange"`
 }
 
+// MessageHashes are the hashes of the messages of each source chain by sequence number.
+type MessageHashes map[cciptypes.ChainSelector]map[cciptypes.SeqNum]cciptypes.Bytes32
+
 type DonID = uint32
 
 // USD18 is a small unit of USD, where 1 USD18 is 1e-18 USD, meaning it is 18 decimal places smaller than 1 USD.
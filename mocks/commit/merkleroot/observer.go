@@ -10,6 +10,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	plugintypes "github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
+
+	time "time"
 )
 
 // MockObserver is an autogenerated mock type for the Observer type
@@ -70,6 +72,56 @@ func (_c *MockObserver_Close_Call) RunAndReturn(run func() error) *MockObserver_
 	return _c
 }
 
+// ObserveCommittedMerkleRoots provides a mock function with given fields: ctx, since, sourceChains
+func (_m *MockObserver) ObserveCommittedMerkleRoots(ctx context.Context, since time.Time, sourceChains []ccipocr3.ChainSelector) []ccipocr3.MerkleRootChain {
+	ret := _m.Called(ctx, since, sourceChains)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ObserveCommittedMerkleRoots")
+	}
+
+	var r0 []ccipocr3.MerkleRootChain
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []ccipocr3.ChainSelector) []ccipocr3.MerkleRootChain); ok {
+		r0 = rf(ctx, since, sourceChains)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ccipocr3.MerkleRootChain)
+		}
+	}
+
+	return r0
+}
+
+// MockObserver_ObserveCommittedMerkleRoots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveCommittedMerkleRoots'
+type MockObserver_ObserveCommittedMerkleRoots_Call struct {
+	*mock.Call
+}
+
+// ObserveCommittedMerkleRoots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - sourceChains []ccipocr3.ChainSelector
+func (_e *MockObserver_Expecter) ObserveCommittedMerkleRoots(ctx interface{}, since interface{}, sourceChains interface{}) *MockObserver_ObserveCommittedMerkleRoots_Call {
+	return &MockObserver_ObserveCommittedMerkleRoots_Call{Call: _e.mock.On("ObserveCommittedMerkleRoots", ctx, since, sourceChains)}
+}
+
+func (_c *MockObserver_ObserveCommittedMerkleRoots_Call) Run(run func(ctx context.Context, since time.Time, sourceChains []ccipocr3.ChainSelector)) *MockObserver_ObserveCommittedMerkleRoots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].([]ccipocr3.ChainSelector))
+	})
+	return _c
+}
+
+func (_c *MockObserver_ObserveCommittedMerkleRoots_Call) Return(_a0 []ccipocr3.MerkleRootChain) *MockObserver_ObserveCommittedMerkleRoots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockObserver_ObserveCommittedMerkleRoots_Call) RunAndReturn(run func(context.Context, time.Time, []ccipocr3.ChainSelector) []ccipocr3.MerkleRootChain) *MockObserver_ObserveCommittedMerkleRoots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ObserveFChain provides a mock function with given fields: ctx
 func (_m *MockObserver) ObserveFChain(ctx context.Context) map[ccipocr3.ChainSelector]int {
 	ret := _m.Called(ctx)
@@ -167,7 +219,7 @@ func (_c *MockObserver_ObserveLatestOnRampSeqNums_Call) RunAndReturn(run func(co
 }
 
 // ObserveMerkleRoots provides a mock function with given fields: ctx, ranges
-func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugintypes.ChainRange) []ccipocr3.MerkleRootChain {
+func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugintypes.ChainRange) ([]ccipocr3.MerkleRootChain, plugintypes.MessageHashes) {
 	ret := _m.Called(ctx, ranges)
 
 	if len(ret) == 0 {
@@ -175,6 +227,10 @@ func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugint
 	}
 
 	var r0 []ccipocr3.MerkleRootChain
+	var r1 plugintypes.MessageHashes
+	if rf, ok := ret.Get(0).(func(context.Context, []plugintypes.ChainRange) ([]ccipocr3.MerkleRootChain, plugintypes.MessageHashes)); ok {
+		return rf(ctx, ranges)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, []plugintypes.ChainRange) []ccipocr3.MerkleRootChain); ok {
 		r0 = rf(ctx, ranges)
 	} else {
@@ -183,7 +239,15 @@ func (_m *MockObserver) ObserveMerkleRoots(ctx context.Context, ranges []plugint
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, []plugintypes.ChainRange) plugintypes.MessageHashes); ok {
+		r1 = rf(ctx, ranges)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(plugintypes.MessageHashes)
+		}
+	}
+
+	return r0, r1
 }
 
 // MockObserver_ObserveMerkleRoots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveMerkleRoots'
@@ -205,12 +269,12 @@ func (_c *MockObserver_ObserveMerkleRoots_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockObserver_ObserveMerkleRoots_Call) Return(_a0 []ccipocr3.MerkleRootChain) *MockObserver_ObserveMerkleRoots_Call {
-	_c.Call.Return(_a0)
+func (_c *MockObserver_ObserveMerkleRoots_Call) Return(_a0 []ccipocr3.MerkleRootChain, _a1 plugintypes.MessageHashes) *MockObserver_ObserveMerkleRoots_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockObserver_ObserveMerkleRoots_Call) RunAndReturn(run func(context.Context, []plugintypes.ChainRange) []ccipocr3.MerkleRootChain) *MockObserver_ObserveMerkleRoots_Call {
+func (_c *MockObserver_ObserveMerkleRoots_Call) RunAndReturn(run func(context.Context, []plugintypes.ChainRange) ([]ccipocr3.MerkleRootChain, plugintypes.MessageHashes)) *MockObserver_ObserveMerkleRoots_Call {
 	_c.Call.Return(run)
 	return _c
 }
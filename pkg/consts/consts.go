@@ -16,6 +16,7 @@ const (
 	ContractNameRMNProxy               = "RMNProxy"
 	ContractNameRouter                 = "Router"
 	ContractNameCCTPMessageTransmitter = "MessageTransmitter"
+	ContractNameRateProvider           = "RateProvider"
 )
 
 func AllContractNames() []string {
@@ -73,6 +74,9 @@ const (
 	MethodNameGetLatestRoundData = "latestRoundData"
 	MethodNameGetDecimals        = "decimals"
 
+	// RateProvider methods
+	MethodNameGetRate = "getRate"
+
 	// NonceManager methods
 	MethodNameGetInboundNonce  = "GetInboundNonce"
 	MethodNameGetOutboundNonce = "GetOutboundNonce"
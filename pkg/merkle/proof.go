@@ -0,0 +1,137 @@
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
+)
+
+const (
+	// SourceFromHashes flags a hash operation whose first operand is a leaf or a previously computed hash.
+	SourceFromHashes = true
+	// SourceFromProof flags a hash operation whose first operand is the next proof hash.
+	SourceFromProof = false
+)
+
+// Proof is a multi-proof for an ordered subset of the leaves of a tree.
+type Proof struct {
+	// Hashes are the sibling hashes that can not be computed from the proven leaves.
+	Hashes [][32]byte `json:"hashes"`
+	// SourceFlags holds one flag per hash operation, see SourceFromHashes and SourceFromProof.
+	SourceFlags []bool `json:"sourceFlags"`
+}
+
+// NewProofFromFlagBits decodes a proof as it is submitted onchain, bit i of flagBits being the i-th source flag.
+// Like the onchain verifier, bits beyond the number of hash operations are ignored.
+func NewProofFromFlagBits(hashes [][32]byte, flagBits *big.Int, numLeaves int) (Proof, error) {
+	if numLeaves <= 0 {
+		return Proof{}, ErrLeavesCannotBeEmpty
+	}
+	if flagBits == nil {
+		flagBits = big.NewInt(0)
+	}
+	if flagBits.Sign() < 0 {
+		return Proof{}, fmt.Errorf("negative proof flag bits %s", flagBits)
+	}
+
+	totalHashes := numLeaves + len(hashes) - 1
+	flags := make([]bool, totalHashes)
+	for i := range flags {
+		flags[i] = flagBits.Bit(i) == 1
+	}
+	return Proof{Hashes: hashes, SourceFlags: flags}, nil
+}
+
+// FlagBits encodes the source flags as the onchain uint256 bitmap, the i-th flag being bit i.
+func (p Proof) FlagBits() *big.Int {
+	flagBits := big.NewInt(0)
+	for i, flag := range p.SourceFlags {
+		if flag == SourceFromHashes {
+			flagBits.SetBit(flagBits, i, 1)
+		}
+	}
+	return flagBits
+}
+
+// VerifyComputeRoot computes the root that the proven leaves and the proof hash to.
+// It mirrors MerkleMultiProof._merkleRoot and returns an error for every input the contract reverts on,
+// including proofs that reference hashes which have not been computed yet (CVE-2023-34459).
+func VerifyComputeRoot(hasher hashutil.Hasher[[32]byte], leaves [][32]byte, proof Proof) ([32]byte, error) {
+	leavesLen, proofsLen := len(leaves), len(proof.Hashes)
+	if leavesLen == 0 {
+		return [32]byte{}, ErrLeavesCannotBeEmpty
+	}
+	if leavesLen > MaxNumberOfHashes+1 || proofsLen > MaxNumberOfHashes+1 {
+		return [32]byte{}, fmt.Errorf("%w: %d leaves and %d proof hashes exceed the limit %d",
+			ErrInvalidProof, leavesLen, proofsLen, MaxNumberOfHashes+1)
+	}
+	totalHashes := leavesLen + proofsLen - 1
+	if totalHashes > MaxNumberOfHashes {
+		return [32]byte{}, fmt.Errorf("%w: %d hash operations exceed the limit %d",
+			ErrInvalidProof, totalHashes, MaxNumberOfHashes)
+	}
+	if totalHashes == 0 {
+		return leaves[0], nil
+	}
+	if len(proof.SourceFlags) < totalHashes {
+		return [32]byte{}, fmt.Errorf("%w: %d source flags for %d hash operations",
+			ErrInvalidProof, len(proof.SourceFlags), totalHashes)
+	}
+
+	hashes := make([][32]byte, totalHashes)
+	var leafPos, hashPos, proofPos int
+	for i := 0; i < totalHashes; i++ {
+		var a, b [32]byte
+		if proof.SourceFlags[i] == SourceFromHashes {
+			if leafPos < leavesLen {
+				a = leaves[leafPos]
+				leafPos++
+			} else {
+				a = hashes[hashPos]
+				hashPos++
+			}
+		} else {
+			if proofPos >= proofsLen {
+				return [32]byte{}, fmt.Errorf("%w: hash operation %d requires more than %d proof hashes",
+					ErrInvalidProof, i, proofsLen)
+			}
+			a = proof.Hashes[proofPos]
+			proofPos++
+		}
+
+		// The second operand is never a proof hash, hashing two proof hashes yields a hash that can be
+		// computed offchain.
+		if leafPos < leavesLen {
+			b = leaves[leafPos]
+			leafPos++
+		} else {
+			b = hashes[hashPos]
+			hashPos++
+		}
+
+		if hashPos > i {
+			return [32]byte{}, fmt.Errorf("%w: hash operation %d uses a hash that is not computed yet",
+				ErrInvalidProof, i)
+		}
+		hashes[i] = hasher.HashInternal(a, b)
+	}
+
+	if hashPos != totalHashes-1 || leafPos != leavesLen || proofPos != proofsLen {
+		return [32]byte{}, fmt.Errorf("%w: not all leaves, hashes and proof hashes were used", ErrInvalidProof)
+	}
+	return hashes[totalHashes-1], nil
+}
+
+// VerifyRoot checks that the proven leaves and the proof hash to the expected root.
+func VerifyRoot(hasher hashutil.Hasher[[32]byte], root [32]byte, leaves [][32]byte, proof Proof) error {
+	computed, err := VerifyComputeRoot(hasher, leaves, proof)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(computed[:], root[:]) {
+		return fmt.Errorf("%w: computed root %x does not match %x", ErrInvalidProof, computed, root)
+	}
+	return nil
+}
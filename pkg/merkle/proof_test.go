@@ -0,0 +1,322 @@
+package merkle
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
+)
+
+func TestVerifyComputeRoot_SpecSync(t *testing.T) {
+	// Vector from MerkleMultiProof.t.sol test_SpecSync_gas.
+	leaves := hexHashes(
+		"0xa20c0244af79697a4ef4e2378c9d5d14cbd49ddab3427b12594c7cfa67a7f240",
+		"0x3de96afb24ce2ac45a5595aa13d1a5163ae0b3c94cef6b2dc306b5966f32dfa5",
+		"0xacadf7b4d13cd57c5d25f1d27be39b656347fe8f8e0de8db9c76d979dff57736",
+		"0xc21c26a709802fe1ae52a9cd8ad94d15bf142ded26314339cd87a13e5b468165",
+		"0x55f6df03562738c9a6437cd9ad221c52b76906a175ae96188cff60e0a2a59933",
+		"0x2dbbe66452e43fec839dc65d5945aad6433d410c65863eaf1d876e1e0b06343c",
+		"0x8beab00297b94bf079fcd5893b0a33ebf6b0ce862cd06be07c87d3c63e1c4acf",
+		"0xcabdd3ad25daeb1e0541042f2ea4cd177f54e67aa4a2c697acd4bb682e94de59",
+		"0x7e01d497203685e99e34df33d55465c66b2253fa1630ee2fe5c4997968e4a6fa",
+		"0x1a03d013f1e2fa9cc04f89c7528ac3216e3e096a1185d7247304e97c59f9661f",
+	)
+	proofs := hexHashes(
+		"0xde96f24fcf9ddd20c803dc9c5fba7c478a5598a08a0faa5f032c65823b8e26a3",
+		"0xe1303cffc3958a6b93e2dc04caf21f200ff5aa5be090c5013f37804b91488bc2",
+		"0x90d80c76bccb44a91f4e16604976163aaa39e9a1588b0b24b33a61f1d4ba7bb5",
+		"0x012a299b25539d513c8677ecf37968774e9e4b045e79737f48defd350224cdfd",
+		"0x420a36c5a73f87d8fb98e70c48d0d6f9dd83f50b7b91416a6f5f91fac4db800f",
+		"0x5857d8d1b56abcd7f863cedd3c3f8677256f54d675be61f05efa45d6495fc30a",
+		"0xbf176d20166fdeb72593ff97efec1ce6244af41ca46cf0bc902d19d50c446f7b",
+		"0xa9221608e4380250a1815fb308632bce99f611a673d2e17fc617123fdc6afcd2",
+		"0xbd14f3366c73186314f182027217d0f70eba55817561de9e9a1f2c78bf5cbead",
+		"0x2f9aa48c0c9f82aaac65d7a9374a52d9dc138ed100a5809ede57e70697f48b56",
+		"0x2ae60afa54271cb421c12e4441c2dac0a25f25c9433a6d07cb32419e993fe344",
+		"0xc765c091680f0434b74c44507b932e5c80f6e995a975a275e5b130af1de1064c",
+		"0x59d2d6e0c4a5d07b169dbcdfa39dad7aea7b7783a814399f4f44c4a36b6336d3",
+		"0xdd14d1387d10740187d71ad9500475399559c0922dbe2576882e61f1edd84692",
+		"0x5412b8395509935406811ab3da43ab80be7acd8ffb5f398ab70f056ff3740f46",
+		"0xeadab258ae7d779ce5f10fbb1bb0273116b8eccbf738ed878db570de78bed1e4",
+		"0x6133aa40e6db75373b7cfc79e6f8b8ce80e441e6c1f98b85a593464dda3cf9c0",
+		"0x5418948467112660639b932af9b1b212e40d71b24326b4606679d168a765af4f",
+		"0x44f618505355c7e4e7c0f81d6bb15d2ec9cf9b366f9e1dc37db52745486e6b0f",
+		"0xa410ee174a66a4d64f3c000b93efe15b5b1f3e39e962af2580fcd30bce07d039",
+		"0x09c3eb05ac9552022a45c00d01a47cd56f95f94afdd4402299dba1291a17f976",
+		"0x0e780f6acd081b07320a55208fa3e1d884e2e95cb13d1c98c74b7e853372c813",
+		"0x2b60e8c21f78ef22fa4297f28f1d8c747181edfc465121b39c16be97d4fb8a04",
+		"0xf24da95060a8598c06e9dfb3926e1a8c8bd8ec2c65be10e69323442840724888",
+		"0x7e220fc095bcd2b0f5ef134d9620d89f6d7a1e8719ce8893bb9aff15e847578f",
+		"0xcfe9e475c4bd32f1e36b2cc65a959c403c59979ff914fb629a64385b0c680a71",
+		"0x25237fb8d1bfdc01ca5363ec3166a2b40789e38d5adcc8627801da683d2e1d76",
+		"0x42647949fed0250139c01212d739d8c83d2852589ebc892d3490ae52e411432c",
+		"0x34397a30930e6dd4fb5af48084afc5cfbe02c18dd9544b3faff4e2e90bf00cb9",
+		"0xa028f33226adc3d1cb72b19eb6808dab9190b25066a45cacb5dfe5d640e57cf2",
+		"0x7cff66ba47a05f932d06d168c294266dcb0d3943a4f2a4a75c860b9fd6e53092",
+		"0x5ca1b32f1dbfadd83205882be5eb76f34c49e834726f5239905a0e70d0a5e0eb",
+		"0x1b4b087a89e4eca6cdd237210932559dc8fd167d5f4f2d9acb13264e1e305479",
+	)
+	expRoot := common.HexToHash("0xd4f0f3c40a4d583d98c17d89e550b1143fe4d3d759f25ccc63131c90b183928e")
+
+	proof, err := NewProofFromFlagBits(proofs, big.NewInt(0x2f3c0000000), len(leaves))
+	require.NoError(t, err)
+	require.NoError(t, VerifyRoot(hashutil.NewKeccak(), expRoot, leaves, proof))
+}
+
+func TestVerifyComputeRoot_SolanaVectors(t *testing.T) {
+	// Vectors from the tests of the ccip-offramp program merkle module (calculate_merkle_root), the program only
+	// verifies single leaf proofs, i.e. every hash operation takes its first operand from the proof.
+	testCases := []struct {
+		name    string
+		leaf    string
+		proofs  []string
+		expRoot string
+	}{
+		{
+			name:    "valid",
+			leaf:    "0x7e1ff3c10bacb7a70bd9dbaa1b2ddeb4c860c6db3c3557d31baff96222505e2a",
+			proofs:  []string{"0x22ae9b57dfb3f830622fb5ee07a795961532dc9ab7f641271ac7cf1b89cb39f6"},
+			expRoot: "0x4ba232dc2d71873bc9fe7d7c8d8075a9b02eb5a402b38500ff41486a0edfa587",
+		},
+		{
+			name:    "from size 1",
+			leaf:    "0x7e1ff3c10bacb7a70bd9dbaa1b2ddeb4c860c6db3c3557d31baff96222505e2a",
+			expRoot: "0x7e1ff3c10bacb7a70bd9dbaa1b2ddeb4c860c6db3c3557d31baff96222505e2a",
+		},
+		{
+			name: "create merkle validate proof",
+			leaf: "0x1ac2f192702849e03dfe5c31ec66a4f6408b5eb16cc02f1583ce713b22be92ed",
+			proofs: []string{
+				"0x81caa6284d8f53a5cd06190bd30c33c4622e6dde8b1204e953f98d768eeab615",
+				"0x16e4eb7487ed6b9476a0aca9294a0d5e6c7fe7bf7d5ca71908d2e46802843135",
+			},
+			expRoot: "0x7a380f4183f5b64263e6c9a6a359adc4edac13b6898c927a2d4689a1502e21cc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			leaves := hexHashes(tc.leaf)
+			proof, err := NewProofFromFlagBits(hexHashes(tc.proofs...), big.NewInt(0), len(leaves))
+			require.NoError(t, err)
+			require.NoError(t, VerifyRoot(hashutil.NewKeccak(), common.HexToHash(tc.expRoot), leaves, proof))
+		})
+	}
+}
+
+// TestVerifyComputeRoot covers the cases of MerkleMultiProof.t.sol.
+func TestVerifyComputeRoot(t *testing.T) {
+	hasher := hashutil.NewKeccak()
+	l1, l2, l3, l4 := [32]byte{0x1}, [32]byte{0x2}, [32]byte{0x3}, [32]byte{0x4}
+	p1, p2 := [32]byte{0xa1}, [32]byte{0xa2}
+	maxFlags := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	same := make([][32]byte, 256)
+	for i := range same {
+		same[i] = crypto.Keccak256Hash([]byte("a"))
+	}
+	sameRoot := same[0]
+	for n := len(same); n > 1; n /= 2 {
+		sameRoot = hasher.HashInternal(sameRoot, sameRoot)
+	}
+
+	testCases := []struct {
+		name     string
+		leaves   [][32]byte
+		proofs   [][32]byte
+		flagBits *big.Int
+		expRoot  [32]byte
+		expError error
+	}{
+		{
+			name:     "single leaf",
+			leaves:   [][32]byte{l1},
+			flagBits: big.NewInt(0),
+			expRoot:  l1,
+		},
+		{
+			name:     "1 of 4",
+			leaves:   [][32]byte{l1},
+			proofs:   [][32]byte{p1, p2},
+			flagBits: big.NewInt(0),
+			expRoot:  hasher.HashInternal(hasher.HashInternal(l1, p1), p2),
+		},
+		{
+			name:     "2 of 4",
+			leaves:   [][32]byte{l1, l2},
+			proofs:   [][32]byte{p1, p2},
+			flagBits: big.NewInt(4),
+			expRoot:  hasher.HashInternal(hasher.HashInternal(l1, p1), hasher.HashInternal(l2, p2)),
+		},
+		{
+			name:     "3 of 4",
+			leaves:   [][32]byte{l1, l2, l3},
+			proofs:   [][32]byte{p1},
+			flagBits: big.NewInt(5),
+			expRoot:  hasher.HashInternal(hasher.HashInternal(l1, l2), hasher.HashInternal(l3, p1)),
+		},
+		{
+			name:     "4 of 4",
+			leaves:   [][32]byte{l1, l2, l3, l4},
+			flagBits: big.NewInt(7),
+			expRoot:  hasher.HashInternal(hasher.HashInternal(l1, l2), hasher.HashInternal(l3, l4)),
+		},
+		{
+			name:     "256 leaves",
+			leaves:   same,
+			flagBits: maxFlags,
+			expRoot:  sameRoot,
+		},
+		{
+			name:     "empty leaves",
+			flagBits: big.NewInt(0),
+			expError: ErrLeavesCannotBeEmpty,
+		},
+		{
+			name:     "too many hash operations",
+			leaves:   append(same, l1),
+			proofs:   [][32]byte{p1},
+			flagBits: maxFlags,
+			expError: ErrInvalidProof,
+		},
+		{
+			name:     "unused proof hash",
+			leaves:   [][32]byte{l1, l2},
+			proofs:   [][32]byte{p1},
+			flagBits: big.NewInt(3),
+			expError: ErrInvalidProof,
+		},
+		{
+			// Without the check on the position of computed hashes the malicious leaves would hash to the root of
+			// the honest tree {0x0, "leaf"}.
+			name:     "CVE-2023-34459",
+			leaves:   [][32]byte{toHash("malicious leaf"), toHash("another malicious leaf")},
+			proofs:   [][32]byte{toHash("leaf"), toHash("will never be used")},
+			flagBits: big.NewInt(3),
+			expError: ErrInvalidProof,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proof, err := NewProofFromFlagBits(tc.proofs, tc.flagBits, len(tc.leaves))
+			if err == nil {
+				var root [32]byte
+				root, err = VerifyComputeRoot(hasher, tc.leaves, proof)
+				assert.Equal(t, tc.expRoot, root)
+			}
+			if tc.expError != nil {
+				require.ErrorIs(t, err, tc.expError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProof_FlagBits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sourceFlags []bool
+		expBits     int64
+	}{
+		{name: "no flags", sourceFlags: nil, expBits: 0},
+		{name: "all proofs", sourceFlags: []bool{false, false}, expBits: 0},
+		{name: "all hashes", sourceFlags: []bool{true, true, true}, expBits: 7},
+		{name: "mixed", sourceFlags: []bool{true, false, true}, expBits: 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proof := Proof{Hashes: randomHashes(len(tc.sourceFlags)), SourceFlags: tc.sourceFlags}
+			bits := proof.FlagBits()
+			require.Equal(t, tc.expBits, bits.Int64())
+
+			// numLeaves such that the number of hash operations matches the flags.
+			decoded, err := NewProofFromFlagBits(proof.Hashes, bits, 1)
+			require.NoError(t, err)
+			if len(tc.sourceFlags) == 0 {
+				require.Empty(t, decoded.SourceFlags)
+				return
+			}
+			require.Equal(t, tc.sourceFlags, decoded.SourceFlags)
+		})
+	}
+
+	_, err := NewProofFromFlagBits(nil, big.NewInt(-1), 1)
+	require.Error(t, err)
+	_, err = NewProofFromFlagBits(nil, big.NewInt(0), 0)
+	require.ErrorIs(t, err, ErrLeavesCannotBeEmpty)
+}
+
+// TestProve checks that every proof generated for random trees and subsets of leaves is accepted by VerifyRoot.
+func TestProve(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	hasher := hashutil.NewKeccak()
+
+	for i := 0; i < 300; i++ {
+		numLeaves := 1 + rng.Intn(MaxNumberTreeLeaves)
+		leaves := randomHashesFrom(rng, numLeaves)
+		tree, err := NewTree(hasher, leaves)
+		require.NoError(t, err)
+
+		var indices []int
+		for len(indices) == 0 {
+			p := rng.Float64()
+			for idx := 0; idx < numLeaves; idx++ {
+				if rng.Float64() < p {
+					indices = append(indices, idx)
+				}
+			}
+		}
+		proven := make([][32]byte, len(indices))
+		for j, idx := range indices {
+			proven[j] = leaves[idx]
+		}
+
+		proof, err := tree.Prove(indices)
+		require.NoError(t, err)
+
+		decoded, err := NewProofFromFlagBits(proof.Hashes, proof.FlagBits(), len(proven))
+		require.NoError(t, err)
+		require.NoError(t, VerifyRoot(hasher, tree.Root(), proven, decoded), "leaves %d, indices %v", numLeaves, indices)
+
+		// Tampering with any proven leaf must change the root.
+		tampered := append([][32]byte{}, proven...)
+		tampered[rng.Intn(len(tampered))][0] ^= 0xff
+		require.Error(t, VerifyRoot(hasher, tree.Root(), tampered, decoded))
+	}
+}
+
+func hexHashes(hexes ...string) [][32]byte {
+	hashes := make([][32]byte, len(hexes))
+	for i, h := range hexes {
+		hashes[i] = common.HexToHash(h)
+	}
+	return hashes
+}
+
+// toHash mimics a bytes32 string literal in solidity.
+func toHash(s string) [32]byte {
+	var h [32]byte
+	copy(h[:], s)
+	return h
+}
+
+func randomHashes(n int) [][32]byte {
+	return randomHashesFrom(rand.New(rand.NewSource(int64(n))), n)
+}
+
+func randomHashesFrom(rng *rand.Rand, n int) [][32]byte {
+	hashes := make([][32]byte, n)
+	for i := range hashes {
+		_, _ = rng.Read(hashes[i][:])
+	}
+	return hashes
+}
@@ -0,0 +1,80 @@
+// Package merkle contains the chain-agnostic merkle tree used to commit CCIP messages, together with the multi-proofs
+// required to execute a subset of the committed messages. Verification follows the onchain MerkleMultiProof library
+// so that proofs accepted here are accepted by the offramp and vice versa.
+package merkle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
+)
+
+const (
+	// MaxNumberTreeLeaves is the maximum number of leaves in a tree, a limitation of the onchain verifier.
+	MaxNumberTreeLeaves = merklemulti.MaxNumberTreeLeaves
+	// MaxNumberOfHashes is the maximum number of hash operations the onchain verifier performs for a single proof.
+	MaxNumberOfHashes = 256
+)
+
+var (
+	// ErrLeavesCannotBeEmpty is returned when a tree is built or a proof is verified without any leaves.
+	ErrLeavesCannotBeEmpty = errors.New("leaves cannot be empty")
+	// ErrInvalidProof is returned for any proof that the onchain verifier would reject.
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// Tree is a merkle tree over pre-hashed leaves. Odd layers are padded with the hasher's zero hash.
+type Tree struct {
+	tree      *merklemulti.Tree[[32]byte]
+	numLeaves int
+}
+
+// NewTree builds a tree from the provided leaf hashes, in order.
+func NewTree(hasher hashutil.Hasher[[32]byte], leaves [][32]byte) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrLeavesCannotBeEmpty
+	}
+	if len(leaves) > MaxNumberTreeLeaves {
+		return nil, fmt.Errorf("too many leaves: %d > %d", len(leaves), MaxNumberTreeLeaves)
+	}
+
+	tree, err := merklemulti.NewTree(hasher, leaves)
+	if err != nil {
+		return nil, fmt.Errorf("construct tree: %w", err)
+	}
+	return &Tree{tree: tree, numLeaves: len(leaves)}, nil
+}
+
+// Root returns the merkle root of the tree.
+func (t *Tree) Root() [32]byte {
+	return t.tree.Root()
+}
+
+// NumLeaves returns the number of leaves the tree was built from.
+func (t *Tree) NumLeaves() int {
+	return t.numLeaves
+}
+
+// Prove generates a multi-proof for the leaves at the provided indices.
+// Indices must be strictly increasing and within the tree, the verifier expects the proven leaves in the same order.
+func (t *Tree) Prove(indices []int) (Proof, error) {
+	if len(indices) == 0 {
+		return Proof{}, ErrLeavesCannotBeEmpty
+	}
+	for i, idx := range indices {
+		if idx < 0 || idx >= t.numLeaves {
+			return Proof{}, fmt.Errorf("leaf index %d out of range [0, %d)", idx, t.numLeaves)
+		}
+		if i > 0 && idx <= indices[i-1] {
+			return Proof{}, fmt.Errorf("leaf indices must be strictly increasing, got %d after %d", idx, indices[i-1])
+		}
+	}
+
+	proof, err := t.tree.Prove(indices)
+	if err != nil {
+		return Proof{}, fmt.Errorf("prove leaves %v: %w", indices, err)
+	}
+	return Proof{Hashes: proof.Hashes, SourceFlags: proof.SourceFlags}, nil
+}
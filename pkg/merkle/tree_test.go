@@ -0,0 +1,100 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
+)
+
+func TestNewTree(t *testing.T) {
+	hasher := hashutil.NewKeccak()
+	a, b, c := [32]byte{0xa}, [32]byte{0xb}, [32]byte{0xc}
+
+	testCases := []struct {
+		name     string
+		leaves   [][32]byte
+		expRoot  [32]byte
+		expError bool
+	}{
+		{
+			name:    "single leaf is the root",
+			leaves:  [][32]byte{a},
+			expRoot: a,
+		},
+		{
+			name:    "two leaves",
+			leaves:  [][32]byte{a, b},
+			expRoot: hasher.HashInternal(a, b),
+		},
+		{
+			name:    "odd layer is padded with the zero hash",
+			leaves:  [][32]byte{a, b, c},
+			expRoot: hasher.HashInternal(hasher.HashInternal(a, b), hasher.HashInternal(c, hasher.ZeroHash())),
+		},
+		{
+			name:     "no leaves",
+			leaves:   nil,
+			expError: true,
+		},
+		{
+			name:     "too many leaves",
+			leaves:   make([][32]byte, MaxNumberTreeLeaves+1),
+			expError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree, err := NewTree(hasher, tc.leaves)
+			if tc.expError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expRoot, tree.Root())
+			assert.Equal(t, len(tc.leaves), tree.NumLeaves())
+		})
+	}
+}
+
+func TestTree_Prove(t *testing.T) {
+	hasher := hashutil.NewKeccak()
+	leaves := randomHashes(10)
+	tree, err := NewTree(hasher, leaves)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		indices  []int
+		expError bool
+	}{
+		{name: "single leaf", indices: []int{3}},
+		{name: "all leaves", indices: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "sparse leaves", indices: []int{0, 4, 9}},
+		{name: "no indices", indices: nil, expError: true},
+		{name: "negative index", indices: []int{-1}, expError: true},
+		{name: "index out of range", indices: []int{10}, expError: true},
+		{name: "unsorted indices", indices: []int{4, 2}, expError: true},
+		{name: "duplicate indices", indices: []int{2, 2}, expError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proof, err := tree.Prove(tc.indices)
+			if tc.expError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			proven := make([][32]byte, len(tc.indices))
+			for i, idx := range tc.indices {
+				proven[i] = leaves[idx]
+			}
+			require.NoError(t, VerifyRoot(hasher, tree.Root(), proven, proof))
+		})
+	}
+}
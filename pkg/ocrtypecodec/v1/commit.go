@@ -12,7 +12,9 @@ import (
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot"
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
 	"github.com/smartcontractkit/chainlink-ccip/commit/tokenprice"
+	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugincommon/discovery/discoverytypes"
+	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
 	"github.com/smartcontractkit/chainlink-ccip/pkg/ocrtypecodec/v1/ocrtypecodecpb"
 	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 )
@@ -93,6 +95,8 @@ func (c *CommitCodecProto) EncodeObservation(observation committypes.Observation
 			OffRampNextSeqNums: c.tr.seqNumChainToProto(observation.MerkleRootObs.OffRampNextSeqNums),
 			RmnRemoteConfig:    c.tr.rmnRemoteConfigToProto(observation.MerkleRootObs.RMNRemoteConfig),
 			FChain:             c.tr.fChainToProto(observation.MerkleRootObs.FChain),
+			MsgHashes:          c.tr.messageHashesToProto(exectypes.MessageHashes(observation.MerkleRootObs.MessageHashes)),
+			CommittedRoots:     c.tr.merkleRootsToProto(observation.MerkleRootObs.CommittedRoots),
 		},
 		TokenPriceObs: &ocrtypecodecpb.TokenPriceObservation{
 			FeedTokenPrices:       c.tr.feedTokenPricesToProto(observation.TokenPriceObs.FeedTokenPrices),
@@ -137,6 +141,8 @@ func (c *CommitCodecProto) DecodeObservation(data []byte) (committypes.Observati
 			OffRampNextSeqNums: c.tr.seqNumChainFromProto(pbObs.MerkleRootObs.OffRampNextSeqNums),
 			RMNRemoteConfig:    c.tr.rmnRemoteConfigFromProto(pbObs.MerkleRootObs.RmnRemoteConfig),
 			FChain:             c.tr.fChainFromProto(pbObs.MerkleRootObs.FChain),
+			MessageHashes:      plugintypes.MessageHashes(c.tr.messageHashesFromProto(pbObs.MerkleRootObs.MsgHashes)),
+			CommittedRoots:     c.tr.merkleRootsFromProto(pbObs.MerkleRootObs.CommittedRoots),
 		},
 		TokenPriceObs: tokenprice.Observation{
 			FeedTokenPrices:       c.tr.feedTokenPricesFromProto(pbObs.TokenPriceObs.FeedTokenPrices),
@@ -171,12 +177,15 @@ func (c *CommitCodecProto) EncodeOutcome(outcome committypes.Outcome) ([]byte, e
 			ReportTransmissionCheckAttempts: uint32(outcome.MerkleRootOutcome.ReportTransmissionCheckAttempts),
 			RmnReportSignatures:             c.tr.ccipRmnSignaturesToProto(outcome.MerkleRootOutcome.RMNReportSignatures),
 			RmnRemoteCfg:                    c.tr.rmnRemoteConfigToProto(outcome.MerkleRootOutcome.RMNRemoteCfg),
+			LaneWaitingRounds:               c.tr.laneWaitingRoundsToProto(outcome.MerkleRootOutcome.LaneWaitingRounds),
 		},
 		TokenPriceOutcome: &ocrtypecodecpb.TokenPriceOutcome{
-			TokenPrices: c.tr.feedTokenPricesToProto(outcome.TokenPriceOutcome.TokenPrices),
+			TokenPrices:       c.tr.feedTokenPricesToProto(outcome.TokenPriceOutcome.TokenPrices),
+			UpdatePolicyState: c.tr.updatePolicyStateToProto(outcome.TokenPriceOutcome.UpdatePolicyState),
 		},
 		ChainFeeOutcome: &ocrtypecodecpb.ChainFeeOutcome{
-			GasPrices: c.tr.gasPriceChainToProto(outcome.ChainFeeOutcome.GasPrices),
+			GasPrices:         c.tr.gasPriceChainToProto(outcome.ChainFeeOutcome.GasPrices),
+			UpdatePolicyState: c.tr.updatePolicyStateToProto(outcome.ChainFeeOutcome.UpdatePolicyState),
 		},
 		MainOutcome: &ocrtypecodecpb.MainOutcome{
 			InflightPriceOcrSequenceNumber: uint64(outcome.MainOutcome.InflightPriceOcrSequenceNumber),
@@ -207,12 +216,15 @@ func (c *CommitCodecProto) DecodeOutcome(data []byte) (committypes.Outcome, erro
 			ReportTransmissionCheckAttempts: uint(pbOutcome.MerkleRootOutcome.ReportTransmissionCheckAttempts),
 			RMNReportSignatures:             c.tr.ccipRmnSignaturesFromProto(pbOutcome.MerkleRootOutcome.RmnReportSignatures),
 			RMNRemoteCfg:                    c.tr.rmnRemoteConfigFromProto(pbOutcome.MerkleRootOutcome.RmnRemoteCfg),
+			LaneWaitingRounds:               c.tr.laneWaitingRoundsFromProto(pbOutcome.MerkleRootOutcome.LaneWaitingRounds),
 		},
 		TokenPriceOutcome: tokenprice.Outcome{
-			TokenPrices: c.tr.feedTokenPricesFromProto(pbOutcome.TokenPriceOutcome.TokenPrices),
+			TokenPrices:       c.tr.feedTokenPricesFromProto(pbOutcome.TokenPriceOutcome.TokenPrices),
+			UpdatePolicyState: c.tr.updatePolicyStateFromProto(pbOutcome.TokenPriceOutcome.GetUpdatePolicyState()),
 		},
 		ChainFeeOutcome: chainfee.Outcome{
-			GasPrices: c.tr.gasPriceChainFromProto(pbOutcome.ChainFeeOutcome.GasPrices),
+			GasPrices:         c.tr.gasPriceChainFromProto(pbOutcome.ChainFeeOutcome.GasPrices),
+			UpdatePolicyState: c.tr.updatePolicyStateFromProto(pbOutcome.ChainFeeOutcome.GetUpdatePolicyState()),
 		},
 		MainOutcome: committypes.MainOutcome{
 			InflightPriceOcrSequenceNumber: cciptypes.SeqNum(pbOutcome.MainOutcome.InflightPriceOcrSequenceNumber),
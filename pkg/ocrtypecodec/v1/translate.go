@@ -10,6 +10,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-ccip/commit/chainfee"
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
 	"github.com/smartcontractkit/chainlink-ccip/pkg/ocrtypecodec/v1/ocrtypecodecpb"
@@ -189,6 +190,32 @@ func (t *protoTranslator) rmnEnabledChainsFromProto(rmnEnabledChains map[uint64]
 	return rmnEnabled
 }
 
+func (t *protoTranslator) laneWaitingRoundsToProto(rounds map[cciptypes.ChainSelector]uint64) map[uint64]uint64 {
+	var laneWaitingRounds map[uint64]uint64
+	if len(rounds) > 0 {
+		laneWaitingRounds = make(map[uint64]uint64, len(rounds))
+	}
+
+	for k, v := range rounds {
+		laneWaitingRounds[uint64(k)] = v
+	}
+	return laneWaitingRounds
+}
+
+func (t *protoTranslator) laneWaitingRoundsFromProto(
+	laneWaitingRounds map[uint64]uint64,
+) map[cciptypes.ChainSelector]uint64 {
+	var rounds map[cciptypes.ChainSelector]uint64
+	if len(laneWaitingRounds) > 0 {
+		rounds = make(map[cciptypes.ChainSelector]uint64, len(laneWaitingRounds))
+	}
+
+	for k, v := range laneWaitingRounds {
+		rounds[cciptypes.ChainSelector(k)] = v
+	}
+	return rounds
+}
+
 func (t *protoTranslator) seqNumChainToProto(snc []plugintypes.SeqNumChain) []*ocrtypecodecpb.SeqNumChain {
 	pbSnc := make([]*ocrtypecodecpb.SeqNumChain, len(snc))
 	for i, s := range snc {
@@ -338,6 +365,46 @@ func (t *protoTranslator) feeQuoterTokenUpdatesFromProto(
 	return feeQuoterTokenUpdates
 }
 
+func (t *protoTranslator) updatePolicyStateToProto(state updatepolicy.State) *ocrtypecodecpb.UpdatePolicyState {
+	if len(state.Smoothed) == 0 && len(state.Updates) == 0 {
+		return nil
+	}
+
+	pbState := &ocrtypecodecpb.UpdatePolicyState{}
+	if len(state.Smoothed) > 0 {
+		pbState.Smoothed = make(map[string]*ocrtypecodecpb.RepeatedBytes, len(state.Smoothed))
+	}
+	for k, values := range state.Smoothed {
+		items := make([][]byte, len(values))
+		for i, v := range values {
+			items[i] = v.Bytes()
+		}
+		pbState.Smoothed[k] = &ocrtypecodecpb.RepeatedBytes{Items: items}
+	}
+	for _, ts := range state.Updates {
+		pbState.Updates = append(pbState.Updates, timestamppb.New(ts))
+	}
+	return pbState
+}
+
+func (t *protoTranslator) updatePolicyStateFromProto(pbState *ocrtypecodecpb.UpdatePolicyState) updatepolicy.State {
+	var state updatepolicy.State
+	if len(pbState.GetSmoothed()) > 0 {
+		state.Smoothed = make(map[string][]cciptypes.BigInt, len(pbState.GetSmoothed()))
+	}
+	for k, pbValues := range pbState.GetSmoothed() {
+		values := make([]cciptypes.BigInt, len(pbValues.GetItems()))
+		for i, v := range pbValues.GetItems() {
+			values[i] = cciptypes.NewBigInt(big.NewInt(0).SetBytes(v))
+		}
+		state.Smoothed[k] = values
+	}
+	for _, ts := range pbState.GetUpdates() {
+		state.Updates = append(state.Updates, ts.AsTime())
+	}
+	return state
+}
+
 func (t *protoTranslator) feeComponentsToProto(
 	feeComponents map[cciptypes.ChainSelector]types.ChainFeeComponents,
 ) map[uint64]*ocrtypecodecpb.ChainFeeComponents {
@@ -21,6 +21,7 @@ import (
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot"
 	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn"
 	"github.com/smartcontractkit/chainlink-ccip/commit/tokenprice"
+	"github.com/smartcontractkit/chainlink-ccip/commit/updatepolicy"
 	"github.com/smartcontractkit/chainlink-ccip/execute/exectypes"
 	dt "github.com/smartcontractkit/chainlink-ccip/internal/plugincommon/discovery/discoverytypes"
 	"github.com/smartcontractkit/chainlink-ccip/internal/plugintypes"
@@ -383,6 +384,14 @@ func (d *dataGenerator) commitObservation() committypes.Observation {
 		}
 	}
 
+	msgHashes := make(plugintypes.MessageHashes, len(merkleRoots))
+	for _, root := range merkleRoots {
+		msgHashes[root.ChainSel] = make(map[cciptypes.SeqNum]cciptypes.Bytes32, d.numMessagesPerChain)
+		for j := 0; j < d.numMessagesPerChain; j++ {
+			msgHashes[root.ChainSel][cciptypes.SeqNum(rand.Uint64())] = randomBytes32()
+		}
+	}
+
 	return committypes.Observation{
 		MerkleRootObs: merkleroot.Observation{
 			MerkleRoots:        merkleRoots,
@@ -391,6 +400,8 @@ func (d *dataGenerator) commitObservation() committypes.Observation {
 			OffRampNextSeqNums: offRampNextSeqNums,
 			RMNRemoteConfig:    genRmnRemoteConfig(d.numRmnNodes),
 			FChain:             fChain,
+			MessageHashes:      msgHashes,
+			CommittedRoots:     genMerkleRootChain(d.numSourceChains),
 		},
 		TokenPriceObs: tokenprice.Observation{
 			FeedTokenPrices:       feedTokenPrices,
@@ -426,11 +437,13 @@ func (d *dataGenerator) commitOutcome() committypes.Outcome {
 	}
 
 	gasPrices := make([]cciptypes.GasPriceChain, d.numSourceChains)
+	laneWaitingRounds := make(map[cciptypes.ChainSelector]uint64, d.numSourceChains)
 	for i := 0; i < d.numSourceChains; i++ {
 		gasPrices[i] = cciptypes.GasPriceChain{
 			ChainSel: cciptypes.ChainSelector(rand.Uint64()),
 			GasPrice: randBigInt(),
 		}
+		laneWaitingRounds[cciptypes.ChainSelector(rand.Uint64())] = uint64(rand.Intn(128))
 	}
 
 	return committypes.Outcome{
@@ -443,12 +456,15 @@ func (d *dataGenerator) commitOutcome() committypes.Outcome {
 			ReportTransmissionCheckAttempts: uint(rand.Intn(128)),
 			RMNReportSignatures:             rmnReportSigs,
 			RMNRemoteCfg:                    genRmnRemoteConfig(d.numRmnNodes),
+			LaneWaitingRounds:               laneWaitingRounds,
 		},
 		TokenPriceOutcome: tokenprice.Outcome{
-			TokenPrices: tokenPrices,
+			TokenPrices:       tokenPrices,
+			UpdatePolicyState: genUpdatePolicyState(d.numPricedTokens),
 		},
 		ChainFeeOutcome: chainfee.Outcome{
-			GasPrices: gasPrices,
+			GasPrices:         gasPrices,
+			UpdatePolicyState: genUpdatePolicyState(d.numSourceChains),
 		},
 		MainOutcome: committypes.MainOutcome{
 			InflightPriceOcrSequenceNumber: cciptypes.SeqNum(rand.Uint64()),
@@ -708,6 +724,15 @@ func genRmnRemoteConfig(numSigners int) cciptypes.RemoteConfig {
 	}
 }
 
+func genUpdatePolicyState(n int) updatepolicy.State {
+	state := updatepolicy.State{Smoothed: make(map[string][]cciptypes.BigInt, n)}
+	for i := 0; i < n; i++ {
+		state.Smoothed[genRandomString(40)] = []cciptypes.BigInt{randBigInt(), randBigInt()}
+		state.Updates = append(state.Updates, time.Now().UTC())
+	}
+	return state
+}
+
 func randBigInt() cciptypes.BigInt {
 	return cciptypes.NewBigInt(big.NewInt(rand.Int63()))
 }
@@ -0,0 +1,232 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	commontypes "github.com/smartcontractkit/chainlink-common/pkg/types"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/consts"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/contractreader"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// PriceFeedReader reads the price feeds and rate providers of a chain, see pluginconfig.PriceFeed and
+// pluginconfig.RateProvider. The addresses are encoded as addresses of the chain family of the chain.
+type PriceFeedReader interface {
+	// GetFeedAnswers returns the latest answers of the feeds, the feeds that could not be read are not returned.
+	// An error is returned when the feeds could not be read at all.
+	GetFeedAnswers(
+		ctx context.Context,
+		feeds []ccipocr3.UnknownEncodedAddress,
+	) (map[ccipocr3.UnknownEncodedAddress]FeedAnswer, error)
+
+	// GetRates returns the rates of the rate providers with 18 decimals, the rate providers that could not be read are
+	// not returned. An error is returned when the rate providers could not be read at all.
+	GetRates(
+		ctx context.Context,
+		rateProviders []ccipocr3.UnknownEncodedAddress,
+	) (map[ccipocr3.UnknownEncodedAddress]*big.Int, error)
+}
+
+// FeedAnswer is the latest round of a feed, with its answer normalized to 18 decimals.
+type FeedAnswer struct {
+	Round *LatestRoundData
+	// Price is nil when the round has no answer.
+	Price *big.Int
+}
+
+// Number of batch operations performed (getLatestRoundData and getDecimals)
+const priceReaderOperationCount = 2
+
+// contractPriceFeedReader reads the feeds and rate providers with the contract reader of the chain, using the
+// AggregatorV3Interface and RateProvider read names. It is the PriceFeedReader of the chains that do not provide their
+// own, non-EVM contract readers can support it by mapping the same read names.
+type contractPriceFeedReader struct {
+	lggr   logger.Logger
+	reader contractreader.ContractReaderFacade
+}
+
+// NewContractPriceFeedReader returns a PriceFeedReader reading the feeds with the contract reader of their chain.
+func NewContractPriceFeedReader(lggr logger.Logger, reader contractreader.ContractReaderFacade) PriceFeedReader {
+	return &contractPriceFeedReader{
+		lggr:   lggr,
+		reader: reader,
+	}
+}
+
+func (r *contractPriceFeedReader) GetFeedAnswers(
+	ctx context.Context,
+	feeds []ccipocr3.UnknownEncodedAddress,
+) (map[ccipocr3.UnknownEncodedAddress]FeedAnswer, error) {
+	batchRequest := make(commontypes.BatchGetLatestValuesRequest, len(feeds))
+	for _, feed := range feeds {
+		boundContract := commontypes.BoundContract{
+			Address: string(feed),
+			Name:    consts.ContractNamePriceAggregator,
+		}
+		batchRequest[boundContract] = commontypes.ContractBatch{
+			{
+				ReadName:  consts.MethodNameGetLatestRoundData,
+				Params:    nil,
+				ReturnVal: &LatestRoundData{},
+			},
+			{
+				ReadName:  consts.MethodNameGetDecimals,
+				Params:    nil,
+				ReturnVal: new(uint8),
+			},
+		}
+	}
+
+	results, err := r.reader.BatchGetLatestValues(ctx, batchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("batch get latest feed values: %w", err)
+	}
+
+	answers := make(map[ccipocr3.UnknownEncodedAddress]FeedAnswer, len(feeds))
+	for boundContract := range batchRequest {
+		answer, err := getFeedAnswer(results[boundContract], boundContract)
+		if err != nil {
+			r.lggr.Errorw("failed to read price feed", "err", err)
+			continue
+		}
+		answers[ccipocr3.UnknownEncodedAddress(boundContract.Address)] = answer
+	}
+	return answers, nil
+}
+
+func (r *contractPriceFeedReader) GetRates(
+	ctx context.Context,
+	rateProviders []ccipocr3.UnknownEncodedAddress,
+) (map[ccipocr3.UnknownEncodedAddress]*big.Int, error) {
+	batchRequest := make(commontypes.BatchGetLatestValuesRequest, len(rateProviders))
+	for _, rateProvider := range rateProviders {
+		boundContract := commontypes.BoundContract{
+			Address: string(rateProvider),
+			Name:    consts.ContractNameRateProvider,
+		}
+		batchRequest[boundContract] = commontypes.ContractBatch{{
+			ReadName:  consts.MethodNameGetRate,
+			Params:    nil,
+			ReturnVal: new(big.Int),
+		}}
+	}
+
+	results, err := r.reader.BatchGetLatestValues(ctx, batchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("batch get latest rates: %w", err)
+	}
+
+	rates := make(map[ccipocr3.UnknownEncodedAddress]*big.Int, len(rateProviders))
+	for boundContract := range batchRequest {
+		rate, err := getRate(results[boundContract], boundContract)
+		if err != nil {
+			r.lggr.Errorw("failed to read rate provider", "err", err)
+			continue
+		}
+		rates[ccipocr3.UnknownEncodedAddress(boundContract.Address)] = rate
+	}
+	return rates, nil
+}
+
+// getFeedAnswer returns the latest round of a feed contract from its batch results.
+func getFeedAnswer(
+	contractResults []commontypes.BatchReadResult,
+	boundContract commontypes.BoundContract,
+) (FeedAnswer, error) {
+	if len(contractResults) != priceReaderOperationCount {
+		return FeedAnswer{}, fmt.Errorf("invalid results for contract %s", boundContract.Address)
+	}
+
+	// Get price data
+	latestRoundData, err := getPriceData(contractResults[0], boundContract)
+	if err != nil {
+		return FeedAnswer{}, fmt.Errorf("calling getPriceData: %w", err)
+	}
+
+	// Get decimals
+	decimals, err := getDecimals(contractResults[1], boundContract)
+	if err != nil {
+		return FeedAnswer{}, fmt.Errorf("calling getDecimals: %w", err)
+	}
+
+	answer := FeedAnswer{Round: latestRoundData}
+	if latestRoundData.Answer != nil {
+		answer.Price = normalizePrice(latestRoundData.Answer, *decimals)
+	}
+	return answer, nil
+}
+
+func getPriceData(
+	result commontypes.BatchReadResult,
+	boundContract commontypes.BoundContract,
+) (*LatestRoundData, error) {
+	priceResult, err := result.GetResult()
+	if err != nil {
+		return nil, fmt.Errorf("get price for contract %s: %w", boundContract.Address, err)
+	}
+	if priceResult == nil {
+		return nil, fmt.Errorf("priceResult value is nil for contract %s", boundContract.Address)
+	}
+	latestRoundData, ok := priceResult.(*LatestRoundData)
+	if !ok {
+		return nil, fmt.Errorf("invalid price data type for contract %s", boundContract.Address)
+	}
+	return latestRoundData, nil
+}
+
+func getDecimals(
+	result commontypes.BatchReadResult,
+	boundContract commontypes.BoundContract,
+) (*uint8, error) {
+	decimalResult, err := result.GetResult()
+	if err != nil {
+		return nil, fmt.Errorf("get decimals for contract %s: %w", boundContract.Address, err)
+	}
+	if decimalResult == nil {
+		return nil, fmt.Errorf("decimalResult value is nil for contract %s", boundContract.Address)
+	}
+	decimals, ok := decimalResult.(*uint8)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimals data type for contract %s", boundContract.Address)
+	}
+	return decimals, nil
+}
+
+func getRate(
+	contractResults []commontypes.BatchReadResult,
+	boundContract commontypes.BoundContract,
+) (*big.Int, error) {
+	if len(contractResults) != 1 {
+		return nil, fmt.Errorf("invalid results for contract %s", boundContract.Address)
+	}
+	rateResult, err := contractResults[0].GetResult()
+	if err != nil {
+		return nil, fmt.Errorf("get rate for contract %s: %w", boundContract.Address, err)
+	}
+	if rateResult == nil {
+		return nil, fmt.Errorf("rateResult value is nil for contract %s", boundContract.Address)
+	}
+	rate, ok := rateResult.(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("invalid rate data type for contract %s", boundContract.Address)
+	}
+	return rate, nil
+}
+
+func normalizePrice(price *big.Int, decimals uint8) *big.Int {
+	answer := new(big.Int).Set(price)
+	if decimals < 18 {
+		return answer.Mul(answer, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(18-int64(decimals)), nil))
+	}
+	if decimals > 18 {
+		return answer.Div(answer, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(decimals)-18), nil))
+	}
+	return answer
+}
+
+// Ensure contractPriceFeedReader implements PriceFeedReader
+var _ PriceFeedReader = (*contractPriceFeedReader)(nil)
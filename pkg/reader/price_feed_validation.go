@@ -0,0 +1,100 @@
+package reader
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+)
+
+// Reasons of an InvalidFeedAnswer.
+const (
+	FeedAnswerNonPositive = "nonPositive"
+	FeedAnswerStaleRound  = "staleRound"
+	FeedAnswerStale       = "stale"
+	FeedAnswerOutOfBounds = "outOfBounds"
+)
+
+// InvalidFeedAnswer is a feed answer that was ignored when computing the price of a token.
+// Feed is the rate provider contract for an invalid rate, and is empty for an invalid report price of the token or
+// when the derived price of the token is out of its bounds.
+type InvalidFeedAnswer struct {
+	Token  ccipocr3.UnknownEncodedAddress
+	Feed   pluginconfig.PriceFeed
+	Reason string
+}
+
+// InvalidFeedAnswersError is returned by PriceReader.GetFeedPricesUSD along with the prices computed from the valid
+// feed answers when some feed answers were ignored.
+type InvalidFeedAnswersError struct {
+	Answers []InvalidFeedAnswer
+}
+
+func (e *InvalidFeedAnswersError) Error() string {
+	answers := make([]string, len(e.Answers))
+	for i, a := range e.Answers {
+		answers[i] = fmt.Sprintf("token %s feed %s on chain %d: %s",
+			a.Token, a.Feed.AggregatorAddress, a.Feed.ChainSelector, a.Reason)
+	}
+	return "invalid feed answers: " + strings.Join(answers, ", ")
+}
+
+// validateFeedAnswer checks the latest round of a feed of a token, price being its answer normalized to 18 decimals.
+// Returns the reason why the answer is invalid, or an empty string if it is valid.
+// The feeds of a derived token do not answer its USD price, so their answers are not checked against its bounds.
+func validateFeedAnswer(
+	tokenInfo pluginconfig.TokenInfo,
+	round *LatestRoundData,
+	price *big.Int,
+	now time.Time,
+) string {
+	if round.Answer == nil || round.Answer.Sign() <= 0 {
+		return FeedAnswerNonPositive
+	}
+
+	// The answer was carried over from a previous round.
+	if round.RoundID != nil && round.AnsweredInRound != nil && round.AnsweredInRound.Cmp(round.RoundID) < 0 {
+		return FeedAnswerStaleRound
+	}
+
+	if threshold := tokenInfo.StalenessThreshold.Duration(); threshold > 0 {
+		if round.UpdatedAt == nil || round.UpdatedAt.Sign() <= 0 ||
+			now.Sub(time.Unix(round.UpdatedAt.Int64(), 0)) > threshold {
+			return FeedAnswerStale
+		}
+	}
+
+	if tokenInfo.Derived != nil {
+		// The bounds apply to the derived price, see validateFeedPrice.
+		return ""
+	}
+	return validateFeedPrice(tokenInfo, price)
+}
+
+// validateReportPrice checks the price of the report of a token, the reports are verified by the PriceReportReader.
+// Returns the reason why the price is invalid, or an empty string if it is valid.
+func validateReportPrice(tokenInfo pluginconfig.TokenInfo, report ReportPrice, now time.Time) string {
+	if report.Price == nil || report.Price.Sign() <= 0 {
+		return FeedAnswerNonPositive
+	}
+
+	if threshold := tokenInfo.StalenessThreshold.Duration(); threshold > 0 && now.Sub(report.ObservedAt) > threshold {
+		return FeedAnswerStale
+	}
+
+	return validateFeedPrice(tokenInfo, report.Price)
+}
+
+// validateFeedPrice checks the USD price of a full token with 18 decimals against the bounds of the token info.
+func validateFeedPrice(tokenInfo pluginconfig.TokenInfo, price *big.Int) string {
+	if !tokenInfo.MinFeedPrice.IsEmpty() && price.Cmp(tokenInfo.MinFeedPrice.Int) < 0 {
+		return FeedAnswerOutOfBounds
+	}
+	if !tokenInfo.MaxFeedPrice.IsEmpty() && price.Cmp(tokenInfo.MaxFeedPrice.Int) > 0 {
+		return FeedAnswerOutOfBounds
+	}
+	return ""
+}
@@ -1,9 +1,14 @@
 package reader
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	commontypes "github.com/smartcontractkit/chainlink-common/pkg/types"
@@ -22,6 +27,8 @@ type PriceReader interface {
 	//	1 ETH = 2,000 USD per full token, each full token is 1e18 units -> 2000 * 1e18 * 1e18 / 1e18 = 2_000e18
 	//	1 LINK = 5.00 USD per full token, each full token is 1e18 units -> 5 * 1e18 * 1e18 / 1e18 = 5e18
 	// The order of the returned prices corresponds to the order of the provided tokens.
+	// Feed answers that are stale or out of the bounds of the token info are ignored, they are returned in an
+	// *InvalidFeedAnswersError along with the prices computed from the other answers.
 	GetFeedPricesUSD(ctx context.Context,
 		tokens []ccipocr3.UnknownEncodedAddress) (ccipocr3.TokenPriceMap, error)
 
@@ -40,23 +47,38 @@ type priceReader struct {
 	ccipReader   CCIPReader
 	feedChain    ccipocr3.ChainSelector
 	addressCodec ccipocr3.AddressCodec
+	feedReaders  map[ccipocr3.ChainSelector]PriceFeedReader
+	reportReader PriceReportReader
 }
 
-func NewPriceReader(
-	lggr logger.Logger,
-	chainReaders map[ccipocr3.ChainSelector]contractreader.ContractReaderFacade,
-	tokenInfo map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo,
-	ccipReader CCIPReader,
-	feedChain ccipocr3.ChainSelector,
-	addressCodec ccipocr3.AddressCodec,
-) PriceReader {
+// PriceReaderParams are the dependencies of the PriceReader created by NewPriceReader.
+type PriceReaderParams struct {
+	Lggr         logger.Logger
+	ChainReaders map[ccipocr3.ChainSelector]contractreader.ContractReaderFacade
+	TokenInfo    map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo
+	CCIPReader   CCIPReader
+	// FeedChain is the chain of the feeds that do not set their chain, see pluginconfig.TokenInfo.PriceFeeds.
+	FeedChain    ccipocr3.ChainSelector
+	AddressCodec ccipocr3.AddressCodec
+	// FeedReaders are the readers of the feeds of the chains that are not read with their contract reader, optional.
+	FeedReaders map[ccipocr3.ChainSelector]PriceFeedReader
+	// ReportReader reads the report prices of the tokens with a pluginconfig.TokenInfo.ReportFeedID, optional when
+	// no token has one.
+	ReportReader PriceReportReader
+}
+
+// NewPriceReader creates a PriceReader. The feeds of a chain are read with its feed reader from
+// PriceReaderParams.FeedReaders if it has one, with its contract reader otherwise, see NewContractPriceFeedReader.
+func NewPriceReader(params PriceReaderParams) PriceReader {
 	return &priceReader{
-		lggr:         lggr,
-		chainReaders: chainReaders,
-		tokenInfo:    tokenInfo,
-		ccipReader:   ccipReader,
-		feedChain:    feedChain,
-		addressCodec: addressCodec,
+		lggr:         params.Lggr,
+		chainReaders: params.ChainReaders,
+		tokenInfo:    params.TokenInfo,
+		ccipReader:   params.CCIPReader,
+		feedChain:    params.FeedChain,
+		addressCodec: params.AddressCodec,
+		feedReaders:  params.FeedReaders,
+		reportReader: params.ReportReader,
 	}
 }
 
@@ -72,11 +94,8 @@ type LatestRoundData struct {
 	AnsweredInRound *big.Int
 }
 
-// ContractTokenMap maps contracts to their token indices
-type ContractTokenMap map[commontypes.BoundContract][]ccipocr3.UnknownEncodedAddress
-
-// Number of batch operations performed (getLatestRoundData and getDecimals)
-const priceReaderOperationCount = 2
+// TokenFeedMap maps tokens to their price feeds
+type TokenFeedMap map[ccipocr3.UnknownEncodedAddress][]pluginconfig.PriceFeed
 
 func (pr *priceReader) GetFeeQuoterTokenUpdates(
 	ctx context.Context,
@@ -155,114 +174,289 @@ func (pr *priceReader) GetFeeQuoterTokenUpdates(
 	return updateMap, nil
 }
 
-// GetFeedPricesUSD gets USD prices for multiple tokens using batch requests
+// GetFeedPricesUSD gets USD prices for multiple tokens reading the feeds of each feed chain with its PriceFeedReader.
+// The prices of the feeds of a token are combined with its pluginconfig.TokenInfo.FeedAggregation, feeds that cannot
+// be read, e.g. because their chain is not supported by the node, are skipped.
+// The prices of derived tokens are the product of their factors, see pluginconfig.DerivedPrice.
 func (pr *priceReader) GetFeedPricesUSD(
 	ctx context.Context,
 	tokens []ccipocr3.UnknownEncodedAddress,
 ) (ccipocr3.TokenPriceMap, error) {
 	lggr := logutil.WithContextValues(ctx, pr.lggr)
 	prices := make(ccipocr3.TokenPriceMap)
-	if pr.feedChainReader() == nil {
-		lggr.Debug("node does not support feed chain")
-		return prices, nil
-	}
 
-	// Create batch request grouped by contract
-	batchRequest, contractTokenMap := pr.prepareBatchRequest(tokens)
+	// Group the feeds and rate providers to read by chain
+	chainRequests, tokenFeeds, derivedPrices := pr.prepareFeedRequests(tokens)
 
-	// Execute batch request
-	results, err := pr.feedChainReader().BatchGetLatestValues(ctx, batchRequest)
-	if err != nil {
-		return nil, fmt.Errorf("batch request failed: %w", err)
-	}
-
-	// Process results by contract
-	for boundContract, tokens := range contractTokenMap {
-		contractResults, ok := results[boundContract]
-		if !ok || len(contractResults) != priceReaderOperationCount {
-			lggr.Errorf("invalid results for contract %s", boundContract.Address)
+	feedAnswers := make(map[pluginconfig.PriceFeed]FeedAnswer)
+	rates := make(map[pluginconfig.RateProvider]*big.Int)
+	var errs []error
+	var requests int
+	for chain, chainRequest := range chainRequests {
+		feedReader, ok := pr.feedReader(chain)
+		if !ok {
+			lggr.Debugw("node does not support feed chain", "chain", chain)
 			continue
 		}
 
-		// Get price data
-		latestRoundData, err := pr.getPriceData(contractResults[0], boundContract)
-		if err != nil {
-			lggr.Errorw("calling getPriceData", err)
-			continue
+		if len(chainRequest.feeds) > 0 {
+			requests++
+			answers, err := feedReader.GetFeedAnswers(ctx, chainRequest.feeds)
+			if err != nil {
+				lggr.Errorw("failed to read price feeds", "chain", chain, "err", err)
+				errs = append(errs, fmt.Errorf("reading price feeds on chain %d failed: %w", chain, err))
+			}
+			for feed, answer := range answers {
+				feedAnswers[pluginconfig.PriceFeed{ChainSelector: chain, AggregatorAddress: feed}] = answer
+			}
 		}
 
-		// Get decimals
-		decimals, err := pr.getDecimals(contractResults[1], boundContract)
+		if len(chainRequest.rates) > 0 {
+			requests++
+			chainRates, err := feedReader.GetRates(ctx, chainRequest.rates)
+			if err != nil {
+				lggr.Errorw("failed to read rate providers", "chain", chain, "err", err)
+				errs = append(errs, fmt.Errorf("reading rate providers on chain %d failed: %w", chain, err))
+			}
+			for rateProvider, rate := range chainRates {
+				rates[pluginconfig.RateProvider{ChainSelector: chain, Address: rateProvider}] = rate
+			}
+		}
+	}
+	reportPrices := make(map[ccipocr3.Bytes32]ReportPrice)
+	if reportFeedIDs := pr.reportFeedIDs(tokenFeeds); len(reportFeedIDs) > 0 {
+		requests++
+		var err error
+		reportPrices, err = pr.reportReader.GetReportPrices(ctx, reportFeedIDs)
 		if err != nil {
-			lggr.Errorw("calling getPriceData", err)
-			continue
+			lggr.Errorw("failed to read price reports", "err", err)
+			errs = append(errs, fmt.Errorf("reading price reports failed: %w", err))
 		}
+	}
+
+	if len(errs) > 0 && len(errs) == requests {
+		return nil, errors.Join(errs...)
+	}
 
-		if latestRoundData.Answer == nil || latestRoundData.Answer.Cmp(big.NewInt(0)) <= 0 {
-			lggr.Errorw("latestRoundData.Answer is nil or non positive", "contract", boundContract.Address)
+	now := time.Now()
+	var invalidAnswers []InvalidFeedAnswer
+
+	// USD prices of a full token with 18 decimals.
+	feedPrices := make(map[ccipocr3.UnknownEncodedAddress]*big.Int, len(tokenFeeds)+len(derivedPrices))
+	for token, feeds := range tokenFeeds {
+		tokenInfo := pr.tokenInfo[token]
+		tokenFeedPrices, invalid := pr.validFeedPrices(lggr, token, tokenInfo, feeds, feedAnswers, now)
+		invalidAnswers = append(invalidAnswers, invalid...)
+
+		// The report price is the primary price of the token.
+		if !tokenInfo.ReportFeedID.IsEmpty() && pr.reportReader != nil {
+			var reportPrice *big.Int
+			if report, ok := reportPrices[tokenInfo.ReportFeedID]; ok {
+				if reason := validateReportPrice(tokenInfo, report, now); reason != "" {
+					lggr.Warnw("ignoring invalid report price",
+						"token", token, "feedID", tokenInfo.ReportFeedID, "reason", reason, "report", report)
+					invalidAnswers = append(invalidAnswers, InvalidFeedAnswer{Token: token, Reason: reason})
+				} else {
+					reportPrice = report.Price
+				}
+			}
+			tokenFeedPrices = append([]*big.Int{reportPrice}, tokenFeedPrices...)
+		}
+
+		feedPrice := aggregateFeedPrices(tokenInfo.FeedAggregation, tokenFeedPrices)
+		if feedPrice == nil {
+			lggr.Warnw("no price available from the feeds of the token", "token", token, "feeds", feeds)
 			continue
 		}
+		feedPrices[token] = feedPrice
+	}
 
-		// Normalize price for this contract
-		normalizedContractPrice := pr.normalizePrice(latestRoundData.Answer, *decimals)
+	for token, derived := range derivedPrices {
+		tokenInfo := pr.tokenInfo[token]
+		factors, invalid := pr.validFeedPrices(lggr, token, tokenInfo, derived.Feeds, feedAnswers, now)
+		invalidAnswers = append(invalidAnswers, invalid...)
 
-		// Apply the normalized price to all tokens using this contract
-		for _, token := range tokens {
-			tokenInfo := pr.tokenInfo[token]
-			price := calculateUsdPer1e18TokenAmount(normalizedContractPrice, tokenInfo.Decimals)
-			if price == nil {
-				lggr.Errorw("failed to calculate price", "token", token)
-				continue
+		if derived.BaseToken != "" {
+			factors = append([]*big.Int{feedPrices[derived.BaseToken]}, factors...)
+		}
+		for _, rateProvider := range derived.Rates {
+			rate := rates[rateProvider]
+			if rate != nil && rate.Sign() <= 0 {
+				invalidAnswers = append(invalidAnswers, InvalidFeedAnswer{
+					Token: token,
+					Feed: pluginconfig.PriceFeed{
+						ChainSelector:     rateProvider.ChainSelector,
+						AggregatorAddress: rateProvider.Address,
+					},
+					Reason: FeedAnswerNonPositive,
+				})
+				rate = nil
 			}
-			prices[token] = ccipocr3.NewBigInt(price)
+			factors = append(factors, rate)
+		}
+
+		feedPrice := derivePrice(factors)
+		if feedPrice == nil {
+			lggr.Warnw("missing factors of the derived price of the token", "token", token, "derived", derived)
+			continue
+		}
+		if reason := validateFeedPrice(tokenInfo, feedPrice); reason != "" {
+			lggr.Warnw("ignoring invalid derived price", "token", token, "price", feedPrice, "reason", reason)
+			invalidAnswers = append(invalidAnswers, InvalidFeedAnswer{Token: token, Reason: reason})
+			continue
+		}
+		feedPrices[token] = feedPrice
+	}
+
+	for _, token := range tokens {
+		feedPrice, ok := feedPrices[token]
+		if !ok {
+			continue
+		}
+
+		price := calculateUsdPer1e18TokenAmount(feedPrice, pr.tokenInfo[token].Decimals)
+		if price == nil {
+			lggr.Errorw("failed to calculate price", "token", token)
+			continue
 		}
+		prices[token] = ccipocr3.NewBigInt(price)
 	}
 
+	if len(invalidAnswers) > 0 {
+		sort.Slice(invalidAnswers, func(i, j int) bool {
+			a, b := invalidAnswers[i], invalidAnswers[j]
+			if a.Token != b.Token {
+				return a.Token < b.Token
+			}
+			if a.Feed.ChainSelector != b.Feed.ChainSelector {
+				return a.Feed.ChainSelector < b.Feed.ChainSelector
+			}
+			return a.Feed.AggregatorAddress < b.Feed.AggregatorAddress
+		})
+		return prices, &InvalidFeedAnswersError{Answers: invalidAnswers}
+	}
 	return prices, nil
 }
 
-func (pr *priceReader) getPriceData(
-	result commontypes.BatchReadResult,
-	boundContract commontypes.BoundContract,
-) (*LatestRoundData, error) {
-	priceResult, err := result.GetResult()
-	if err != nil {
-		return nil, fmt.Errorf("get price for contract %s: %w", boundContract.Address, err)
+// validFeedPrices returns the prices of the valid answers of the feeds of a token, in the order of the feeds and nil
+// for the feeds without a valid answer, and the invalid answers.
+func (pr *priceReader) validFeedPrices(
+	lggr logger.Logger,
+	token ccipocr3.UnknownEncodedAddress,
+	tokenInfo pluginconfig.TokenInfo,
+	feeds []pluginconfig.PriceFeed,
+	feedAnswers map[pluginconfig.PriceFeed]FeedAnswer,
+	now time.Time,
+) ([]*big.Int, []InvalidFeedAnswer) {
+	feedPrices := make([]*big.Int, len(feeds))
+	var invalidAnswers []InvalidFeedAnswer
+	for i, feed := range feeds {
+		answer, ok := feedAnswers[feed]
+		if !ok {
+			continue
+		}
+		if reason := validateFeedAnswer(tokenInfo, answer.Round, answer.Price, now); reason != "" {
+			lggr.Warnw("ignoring invalid feed answer",
+				"token", token, "feed", feed, "reason", reason, "latestRoundData", answer.Round)
+			invalidAnswers = append(invalidAnswers, InvalidFeedAnswer{Token: token, Feed: feed, Reason: reason})
+			continue
+		}
+		feedPrices[i] = answer.Price
 	}
-	if priceResult == nil {
-		return nil, fmt.Errorf("priceResult value is nil for contract %s", boundContract.Address)
+	return feedPrices, invalidAnswers
+}
+
+// derivePrice multiplies the factors of a derived price, all with 18 decimals.
+// Returns nil if there are no factors or one of them is missing.
+func derivePrice(factors []*big.Int) *big.Int {
+	if len(factors) == 0 || factors[0] == nil {
+		return nil
 	}
-	latestRoundData, ok := priceResult.(*LatestRoundData)
-	if !ok {
-		return nil, fmt.Errorf("invalid price data type for contract %s", boundContract.Address)
+	price := new(big.Int).Set(factors[0])
+	for _, factor := range factors[1:] {
+		if factor == nil {
+			return nil
+		}
+		price.Mul(price, factor)
+		price.Div(price, big.NewInt(1e18))
 	}
-	return latestRoundData, nil
+	return price
 }
 
-func (pr *priceReader) getDecimals(
-	result commontypes.BatchReadResult,
-	boundContract commontypes.BoundContract,
-) (*uint8, error) {
-	decimalResult, err := result.GetResult()
-	if err != nil {
-		return nil, fmt.Errorf("get decimals for contract %s: %w", boundContract.Address, err)
+// aggregateFeedPrices combines the prices of the feeds of a token, provided in the order of the feeds and nil for
+// the feeds without a price. Returns nil if none of the feeds has a price.
+func aggregateFeedPrices(aggregation pluginconfig.FeedAggregation, prices []*big.Int) *big.Int {
+	available := make([]*big.Int, 0, len(prices))
+	for _, price := range prices {
+		if price != nil {
+			available = append(available, price)
+		}
 	}
-	if decimalResult == nil {
-		return nil, fmt.Errorf("decimalResult value is nil for contract %s", boundContract.Address)
+	if len(available) == 0 {
+		return nil
 	}
-	decimals, ok := decimalResult.(*uint8)
-	if !ok {
-		return nil, fmt.Errorf("invalid decimals data type for contract %s", boundContract.Address)
+
+	switch aggregation {
+	case pluginconfig.FeedAggregationMedian:
+		slices.SortFunc(available, func(a, b *big.Int) int { return a.Cmp(b) })
+		mid := len(available) / 2
+		if len(available)%2 == 1 {
+			return available[mid]
+		}
+		sum := new(big.Int).Add(available[mid-1], available[mid])
+		return sum.Div(sum, big.NewInt(2))
+	case pluginconfig.FeedAggregationMin:
+		return slices.MinFunc(available, func(a, b *big.Int) int { return a.Cmp(b) })
+	default:
+		return available[0]
 	}
-	return decimals, nil
 }
 
-// prepareBatchRequest creates a batch request grouped by contract and returns the mapping of contracts to token indices
-func (pr *priceReader) prepareBatchRequest(
+// feedRequests are the feeds and rate providers to read on a chain.
+type feedRequests struct {
+	feeds []ccipocr3.UnknownEncodedAddress
+	rates []ccipocr3.UnknownEncodedAddress
+}
+
+func (r *feedRequests) addFeed(feed ccipocr3.UnknownEncodedAddress) {
+	if !slices.Contains(r.feeds, feed) {
+		r.feeds = append(r.feeds, feed)
+	}
+}
+
+func (r *feedRequests) addRate(rateProvider ccipocr3.UnknownEncodedAddress) {
+	if !slices.Contains(r.rates, rateProvider) {
+		r.rates = append(r.rates, rateProvider)
+	}
+}
+
+// prepareFeedRequests returns the feeds and rate providers to read on every chain, the feeds of every token,
+// including the base tokens of derived prices, and the derived prices.
+func (pr *priceReader) prepareFeedRequests(
 	tokens []ccipocr3.UnknownEncodedAddress,
-) (commontypes.BatchGetLatestValuesRequest, ContractTokenMap) {
-	batchRequest := make(commontypes.BatchGetLatestValuesRequest)
-	contractTokenMap := make(ContractTokenMap)
+) (
+	map[ccipocr3.ChainSelector]*feedRequests,
+	TokenFeedMap,
+	map[ccipocr3.UnknownEncodedAddress]pluginconfig.DerivedPrice,
+) {
+	requests := make(map[ccipocr3.ChainSelector]*feedRequests)
+	tokenFeeds := make(TokenFeedMap)
+	derivedPrices := make(map[ccipocr3.UnknownEncodedAddress]pluginconfig.DerivedPrice)
+
+	chainRequests := func(chain ccipocr3.ChainSelector) *feedRequests {
+		if _, ok := requests[chain]; !ok {
+			requests[chain] = &feedRequests{}
+		}
+		return requests[chain]
+	}
+	addTokenFeeds := func(token ccipocr3.UnknownEncodedAddress, tokenInfo pluginconfig.TokenInfo) {
+		feeds := tokenInfo.PriceFeeds(pr.feedChain)
+		for _, feed := range feeds {
+			chainRequests(feed.ChainSelector).addFeed(feed.AggregatorAddress)
+		}
+		// Track which feeds are used by this token
+		tokenFeeds[token] = feeds
+	}
 
 	for _, token := range tokens {
 		tokenInfo, ok := pr.tokenInfo[token]
@@ -271,46 +465,60 @@ func (pr *priceReader) prepareBatchRequest(
 			continue
 		}
 
-		boundContract := commontypes.BoundContract{
-			Address: string(tokenInfo.AggregatorAddress),
-			Name:    consts.ContractNamePriceAggregator,
+		if tokenInfo.Derived == nil {
+			addTokenFeeds(token, tokenInfo)
+			continue
 		}
 
-		// Initialize contract batch if it doesn't exist
-		if _, exists := batchRequest[boundContract]; !exists {
-			batchRequest[boundContract] = make(commontypes.ContractBatch, priceReaderOperationCount)
-			batchRequest[boundContract][0] = commontypes.BatchRead{
-				ReadName:  consts.MethodNameGetLatestRoundData,
-				Params:    nil,
-				ReturnVal: &LatestRoundData{},
-			}
-			batchRequest[boundContract][1] = commontypes.BatchRead{
-				ReadName:  consts.MethodNameGetDecimals,
-				Params:    nil,
-				ReturnVal: new(uint8),
+		derived := tokenInfo.Derived.WithChain(pr.feedChain)
+		if derived.BaseToken != "" {
+			baseInfo, ok := pr.tokenInfo[derived.BaseToken]
+			if !ok {
+				pr.lggr.Errorw("missing token info of base token, token skipped",
+					"token", token, "baseToken", derived.BaseToken)
+				continue
 			}
+			addTokenFeeds(derived.BaseToken, baseInfo)
 		}
-
-		// Track which tokens use this contract
-		contractTokenMap[boundContract] = append(contractTokenMap[boundContract], token)
+		for _, feed := range derived.Feeds {
+			chainRequests(feed.ChainSelector).addFeed(feed.AggregatorAddress)
+		}
+		for _, rate := range derived.Rates {
+			chainRequests(rate.ChainSelector).addRate(rate.Address)
+		}
+		derivedPrices[token] = derived
 	}
 
-	return batchRequest, contractTokenMap
+	return requests, tokenFeeds, derivedPrices
 }
 
-func (pr *priceReader) normalizePrice(price *big.Int, decimals uint8) *big.Int {
-	answer := new(big.Int).Set(price)
-	if decimals < 18 {
-		return answer.Mul(answer, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(18-int64(decimals)), nil))
+// reportFeedIDs returns the report feed IDs of the tokens, sorted.
+func (pr *priceReader) reportFeedIDs(tokenFeeds TokenFeedMap) []ccipocr3.Bytes32 {
+	if pr.reportReader == nil {
+		return nil
 	}
-	if decimals > 18 {
-		return answer.Div(answer, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(decimals)-18), nil))
+	feedIDs := make([]ccipocr3.Bytes32, 0)
+	for token := range tokenFeeds {
+		feedID := pr.tokenInfo[token].ReportFeedID
+		if !feedID.IsEmpty() && !slices.Contains(feedIDs, feedID) {
+			feedIDs = append(feedIDs, feedID)
+		}
 	}
-	return answer
+	slices.SortFunc(feedIDs, func(a, b ccipocr3.Bytes32) int { return bytes.Compare(a[:], b[:]) })
+	return feedIDs
 }
 
-func (pr *priceReader) feedChainReader() contractreader.ContractReaderFacade {
-	return pr.chainReaders[pr.feedChain]
+// feedReader returns the PriceFeedReader of the chain, reading with the contract reader of the chain when the chain
+// does not have its own.
+func (pr *priceReader) feedReader(chain ccipocr3.ChainSelector) (PriceFeedReader, bool) {
+	if feedReader, ok := pr.feedReaders[chain]; ok {
+		return feedReader, true
+	}
+	chainReader, ok := pr.chainReaders[chain]
+	if !ok {
+		return nil, false
+	}
+	return NewContractPriceFeedReader(pr.lggr, chainReader), true
 }
 
 // Input price is USD per full token, with 18 decimal precision
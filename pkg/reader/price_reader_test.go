@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 
 	"github.com/stretchr/testify/require"
@@ -63,6 +65,7 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 		mockPrices    map[cciptypes.UnknownEncodedAddress]*big.Int
 		want          cciptypes.TokenPriceMap
 		errorAccounts []cciptypes.UnknownEncodedAddress
+		wantInvalid   []InvalidFeedAnswer
 		wantErr       bool
 	}{
 		{
@@ -121,6 +124,11 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 			inputTokens: []cciptypes.UnknownEncodedAddress{ArbAddr},
 			mockPrices:  map[cciptypes.UnknownEncodedAddress]*big.Int{ArbAddr: big.NewInt(0)},
 			want:        cciptypes.TokenPriceMap{},
+			wantInvalid: []InvalidFeedAnswer{{
+				Token:  ArbAddr,
+				Feed:   pluginconfig.PriceFeed{ChainSelector: 1, AggregatorAddress: ArbAggregatorAddr},
+				Reason: FeedAnswerNonPositive,
+			}},
 		},
 		{
 			name: "Multiple error accounts",
@@ -155,12 +163,465 @@ func TestOnchainTokenPricesReader_GetTokenPricesUSD(t *testing.T) {
 				require.Error(t, err)
 				return
 			}
-			require.NoError(t, err)
+			if tc.wantInvalid != nil {
+				var invalidErr *InvalidFeedAnswersError
+				require.ErrorAs(t, err, &invalidErr)
+				require.Equal(t, tc.wantInvalid, invalidErr.Answers)
+			} else {
+				require.NoError(t, err)
+			}
 			require.Equal(t, tc.want, result)
 		})
 	}
 }
 
+func TestPriceReader_GetFeedPricesUSD_multipleFeeds(t *testing.T) {
+	const (
+		feedChain  = cciptypes.ChainSelector(1)
+		otherChain = cciptypes.ChainSelector(2)
+
+		feedA = cciptypes.UnknownEncodedAddress("0xa300000000000000000000000000000000000000")
+		feedB = cciptypes.UnknownEncodedAddress("0xa400000000000000000000000000000000000000")
+		feedC = cciptypes.UnknownEncodedAddress("0xa500000000000000000000000000000000000000")
+	)
+
+	// feedReader returns a reader of the provided feed answers, with 18 decimals, failing the feeds without answer.
+	feedReader := func(answers map[cciptypes.UnknownEncodedAddress]*big.Int) *readermock.MockContractReaderFacade {
+		reader := readermock.NewMockContractReaderFacade(t)
+		reader.EXPECT().BatchGetLatestValues(mock.Anything, mock.Anything).RunAndReturn(
+			func(_ context.Context, req commontypes.BatchGetLatestValuesRequest) (
+				commontypes.BatchGetLatestValuesResult, error) {
+				results := make(commontypes.BatchGetLatestValuesResult)
+				for boundContract := range req {
+					priceResult := commontypes.BatchReadResult{ReadName: consts.MethodNameGetLatestRoundData}
+					decimalsResult := commontypes.BatchReadResult{ReadName: consts.MethodNameGetDecimals}
+					answer, ok := answers[cciptypes.UnknownEncodedAddress(boundContract.Address)]
+					if ok {
+						priceResult.SetResult(&LatestRoundData{Answer: answer}, nil)
+					} else {
+						priceResult.SetResult(nil, fmt.Errorf("execution reverted"))
+					}
+					decimalsResult.SetResult(&Decimals18, nil)
+					results[boundContract] = commontypes.ContractBatchResults{priceResult, decimalsResult}
+				}
+				return results, nil
+			}).Once()
+		return reader
+	}
+
+	tokenInfo := func(aggregation pluginconfig.FeedAggregation) pluginconfig.TokenInfo {
+		return pluginconfig.TokenInfo{
+			AggregatorAddress: feedA,
+			Feeds: []pluginconfig.PriceFeed{
+				{AggregatorAddress: feedB},
+				{ChainSelector: otherChain, AggregatorAddress: feedC},
+			},
+			FeedAggregation: aggregation,
+			DeviationPPB:    cciptypes.NewBigInt(big.NewInt(1e5)),
+			Decimals:        Decimals18,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		aggregation  pluginconfig.FeedAggregation
+		feedAnswers  map[cciptypes.UnknownEncodedAddress]*big.Int
+		otherAnswers map[cciptypes.UnknownEncodedAddress]*big.Int
+		noOtherChain bool
+		want         cciptypes.TokenPriceMap
+		wantInvalid  []InvalidFeedAnswer
+	}{
+		{
+			name:         "primary feed is used first",
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{feedA: big.NewInt(10), feedB: big.NewInt(20)},
+			otherAnswers: map[cciptypes.UnknownEncodedAddress]*big.Int{feedC: big.NewInt(30)},
+			want:         cciptypes.TokenPriceMap{ArbAddr: cciptypes.NewBigIntFromInt64(10)},
+		},
+		{
+			name:         "fallback when the primary feed fails",
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{feedB: big.NewInt(20)},
+			otherAnswers: map[cciptypes.UnknownEncodedAddress]*big.Int{feedC: big.NewInt(30)},
+			want:         cciptypes.TokenPriceMap{ArbAddr: cciptypes.NewBigIntFromInt64(20)},
+		},
+		{
+			name:         "fallback to a feed on another chain",
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{feedA: big.NewInt(0)},
+			otherAnswers: map[cciptypes.UnknownEncodedAddress]*big.Int{feedC: big.NewInt(30)},
+			want:         cciptypes.TokenPriceMap{ArbAddr: cciptypes.NewBigIntFromInt64(30)},
+			wantInvalid: []InvalidFeedAnswer{{
+				Token:  ArbAddr,
+				Feed:   pluginconfig.PriceFeed{ChainSelector: feedChain, AggregatorAddress: feedA},
+				Reason: FeedAnswerNonPositive,
+			}},
+		},
+		{
+			name:         "median",
+			aggregation:  pluginconfig.FeedAggregationMedian,
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{feedA: big.NewInt(10), feedB: big.NewInt(40)},
+			otherAnswers: map[cciptypes.UnknownEncodedAddress]*big.Int{feedC: big.NewInt(30)},
+			want:         cciptypes.TokenPriceMap{ArbAddr: cciptypes.NewBigIntFromInt64(30)},
+		},
+		{
+			name:         "median of the feeds of the supported chains",
+			aggregation:  pluginconfig.FeedAggregationMedian,
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{feedA: big.NewInt(10), feedB: big.NewInt(40)},
+			noOtherChain: true,
+			want:         cciptypes.TokenPriceMap{ArbAddr: cciptypes.NewBigIntFromInt64(25)},
+		},
+		{
+			name:         "min",
+			aggregation:  pluginconfig.FeedAggregationMin,
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{feedA: big.NewInt(10), feedB: big.NewInt(40)},
+			otherAnswers: map[cciptypes.UnknownEncodedAddress]*big.Int{feedC: big.NewInt(5)},
+			want:         cciptypes.TokenPriceMap{ArbAddr: cciptypes.NewBigIntFromInt64(5)},
+		},
+		{
+			name:         "no feed available",
+			feedAnswers:  map[cciptypes.UnknownEncodedAddress]*big.Int{},
+			otherAnswers: map[cciptypes.UnknownEncodedAddress]*big.Int{},
+			want:         cciptypes.TokenPriceMap{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chainReaders := map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
+				feedChain: feedReader(tc.feedAnswers),
+			}
+			if !tc.noOtherChain {
+				chainReaders[otherChain] = feedReader(tc.otherAnswers)
+			}
+			pr := priceReader{
+				lggr:         logger.Test(t),
+				chainReaders: chainReaders,
+				tokenInfo:    map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{ArbAddr: tokenInfo(tc.aggregation)},
+				feedChain:    feedChain,
+			}
+
+			prices, err := pr.GetFeedPricesUSD(context.Background(), []cciptypes.UnknownEncodedAddress{ArbAddr})
+			if tc.wantInvalid != nil {
+				var invalidErr *InvalidFeedAnswersError
+				require.ErrorAs(t, err, &invalidErr)
+				require.Equal(t, tc.wantInvalid, invalidErr.Answers)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.want, prices)
+		})
+	}
+}
+
+func TestPriceReader_GetFeedPricesUSD_derivedPrices(t *testing.T) {
+	const (
+		feedChain = cciptypes.ChainSelector(1)
+
+		baseToken    = cciptypes.UnknownEncodedAddress("0xb100000000000000000000000000000000000000")
+		wrappedToken = cciptypes.UnknownEncodedAddress("0xb200000000000000000000000000000000000000")
+		ethToken     = cciptypes.UnknownEncodedAddress("0xb300000000000000000000000000000000000000")
+
+		baseFeed     = cciptypes.UnknownEncodedAddress("0xa100000000000000000000000000000000000000")
+		tokenEthFeed = cciptypes.UnknownEncodedAddress("0xa200000000000000000000000000000000000000")
+		ethUsdFeed   = cciptypes.UnknownEncodedAddress("0xa300000000000000000000000000000000000000")
+		rateProvider = cciptypes.UnknownEncodedAddress("0xc100000000000000000000000000000000000000")
+	)
+
+	e18 := func(v int64) *big.Int { return new(big.Int).Mul(big.NewInt(v), big.NewInt(1e18)) }
+
+	// chainReader returns the feed answers and rates, with 18 decimals, failing the contracts without value.
+	chainReader := func(values map[cciptypes.UnknownEncodedAddress]*big.Int) *readermock.MockContractReaderFacade {
+		reader := readermock.NewMockContractReaderFacade(t)
+		reader.EXPECT().BatchGetLatestValues(mock.Anything, mock.Anything).RunAndReturn(
+			func(_ context.Context, req commontypes.BatchGetLatestValuesRequest) (
+				commontypes.BatchGetLatestValuesResult, error) {
+				results := make(commontypes.BatchGetLatestValuesResult)
+				for boundContract := range req {
+					value, ok := values[cciptypes.UnknownEncodedAddress(boundContract.Address)]
+					var err error
+					if !ok {
+						err = fmt.Errorf("execution reverted")
+					}
+					if boundContract.Name == consts.ContractNameRateProvider {
+						rateResult := commontypes.BatchReadResult{ReadName: consts.MethodNameGetRate}
+						rateResult.SetResult(value, err)
+						results[boundContract] = commontypes.ContractBatchResults{rateResult}
+						continue
+					}
+					priceResult := commontypes.BatchReadResult{ReadName: consts.MethodNameGetLatestRoundData}
+					priceResult.SetResult(&LatestRoundData{Answer: value}, err)
+					decimalsResult := commontypes.BatchReadResult{ReadName: consts.MethodNameGetDecimals}
+					decimalsResult.SetResult(&Decimals18, nil)
+					results[boundContract] = commontypes.ContractBatchResults{priceResult, decimalsResult}
+				}
+				return results, nil
+			}).Twice() // the feeds and the rate providers
+		return reader
+	}
+
+	deviation := cciptypes.NewBigInt(big.NewInt(1e5))
+	tokenInfo := map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+		baseToken: {AggregatorAddress: baseFeed, DeviationPPB: deviation, Decimals: Decimals18},
+		wrappedToken: {
+			DeviationPPB: deviation,
+			Decimals:     Decimals18,
+			Derived: &pluginconfig.DerivedPrice{
+				BaseToken: baseToken,
+				Rates:     []pluginconfig.RateProvider{{Address: rateProvider}},
+			},
+			MaxFeedPrice: cciptypes.NewBigInt(e18(5000)),
+		},
+		ethToken: {
+			DeviationPPB: deviation,
+			Decimals:     Decimals18,
+			Derived: &pluginconfig.DerivedPrice{
+				Feeds: []pluginconfig.PriceFeed{{AggregatorAddress: tokenEthFeed}, {AggregatorAddress: ethUsdFeed}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		values      map[cciptypes.UnknownEncodedAddress]*big.Int
+		want        cciptypes.TokenPriceMap
+		wantInvalid []InvalidFeedAnswer
+	}{
+		{
+			name: "derived prices",
+			values: map[cciptypes.UnknownEncodedAddress]*big.Int{
+				baseFeed:     e18(2000),
+				rateProvider: big.NewInt(1.2e18),
+				tokenEthFeed: big.NewInt(0.5e18),
+				ethUsdFeed:   e18(3000),
+			},
+			want: cciptypes.TokenPriceMap{
+				wrappedToken: cciptypes.NewBigInt(e18(2400)),
+				ethToken:     cciptypes.NewBigInt(e18(1500)),
+			},
+		},
+		{
+			name: "missing factors",
+			values: map[cciptypes.UnknownEncodedAddress]*big.Int{
+				rateProvider: big.NewInt(1.2e18),
+				tokenEthFeed: big.NewInt(0.5e18),
+			},
+			want: cciptypes.TokenPriceMap{},
+		},
+		{
+			name: "non positive rate",
+			values: map[cciptypes.UnknownEncodedAddress]*big.Int{
+				baseFeed:     e18(2000),
+				rateProvider: big.NewInt(0),
+				tokenEthFeed: big.NewInt(0.5e18),
+				ethUsdFeed:   e18(3000),
+			},
+			want: cciptypes.TokenPriceMap{ethToken: cciptypes.NewBigInt(e18(1500))},
+			wantInvalid: []InvalidFeedAnswer{{
+				Token:  wrappedToken,
+				Feed:   pluginconfig.PriceFeed{ChainSelector: feedChain, AggregatorAddress: rateProvider},
+				Reason: FeedAnswerNonPositive,
+			}},
+		},
+		{
+			name: "derived price out of bounds",
+			values: map[cciptypes.UnknownEncodedAddress]*big.Int{
+				baseFeed:     e18(2000),
+				rateProvider: big.NewInt(3e18),
+				tokenEthFeed: big.NewInt(0.5e18),
+				ethUsdFeed:   e18(3000),
+			},
+			want:        cciptypes.TokenPriceMap{ethToken: cciptypes.NewBigInt(e18(1500))},
+			wantInvalid: []InvalidFeedAnswer{{Token: wrappedToken, Reason: FeedAnswerOutOfBounds}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := priceReader{
+				lggr: logger.Test(t),
+				chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
+					feedChain: chainReader(tc.values),
+				},
+				tokenInfo: tokenInfo,
+				feedChain: feedChain,
+			}
+
+			// the price of the base token is not returned when it is not requested
+			prices, err := pr.GetFeedPricesUSD(context.Background(),
+				[]cciptypes.UnknownEncodedAddress{wrappedToken, ethToken})
+			if tc.wantInvalid != nil {
+				var invalidErr *InvalidFeedAnswersError
+				require.ErrorAs(t, err, &invalidErr)
+				require.Equal(t, tc.wantInvalid, invalidErr.Answers)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.want, prices)
+		})
+	}
+}
+
+// stubFeedReader is a PriceFeedReader of the provided feed answers and rates.
+type stubFeedReader struct {
+	answers map[cciptypes.UnknownEncodedAddress]FeedAnswer
+	rates   map[cciptypes.UnknownEncodedAddress]*big.Int
+}
+
+func (r stubFeedReader) GetFeedAnswers(
+	_ context.Context,
+	feeds []cciptypes.UnknownEncodedAddress,
+) (map[cciptypes.UnknownEncodedAddress]FeedAnswer, error) {
+	answers := make(map[cciptypes.UnknownEncodedAddress]FeedAnswer)
+	for _, feed := range feeds {
+		if answer, ok := r.answers[feed]; ok {
+			answers[feed] = answer
+		}
+	}
+	return answers, nil
+}
+
+func (r stubFeedReader) GetRates(
+	_ context.Context,
+	rateProviders []cciptypes.UnknownEncodedAddress,
+) (map[cciptypes.UnknownEncodedAddress]*big.Int, error) {
+	rates := make(map[cciptypes.UnknownEncodedAddress]*big.Int)
+	for _, rateProvider := range rateProviders {
+		if rate, ok := r.rates[rateProvider]; ok {
+			rates[rateProvider] = rate
+		}
+	}
+	return rates, nil
+}
+
+func TestPriceReader_GetFeedPricesUSD_feedReaders(t *testing.T) {
+	const (
+		solanaChain = cciptypes.ChainSelector(124615329519749607)
+		evmChain    = cciptypes.ChainSelector(1)
+
+		// feed accounts of the feed chain
+		solFeed  = cciptypes.UnknownEncodedAddress("CH31Xns5z3M1cTAbKW34jcxPPciazARpijcHj9rxtemt")
+		linkFeed = cciptypes.UnknownEncodedAddress("HEvSKofvBgfaexv23kMabbYqxasxU3mQ4ibBMEmJWHny")
+	)
+
+	tokenInfo := map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+		EthAddr: {
+			AggregatorAddress: solFeed,
+			DeviationPPB:      cciptypes.NewBigInt(big.NewInt(1e5)),
+			Decimals:          Decimals18,
+		},
+		ArbAddr: {
+			AggregatorAddress: linkFeed,
+			// a feed on an evm chain read with its contract reader
+			Feeds:           []pluginconfig.PriceFeed{{ChainSelector: evmChain, AggregatorAddress: ArbAggregatorAddr}},
+			FeedAggregation: pluginconfig.FeedAggregationMin,
+			DeviationPPB:    cciptypes.NewBigInt(big.NewInt(1e5)),
+			Decimals:        Decimals18,
+		},
+	}
+	evmTokenInfo := map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{
+		ArbAddr: {AggregatorAddress: ArbAggregatorAddr, Decimals: Decimals18},
+	}
+
+	pr := priceReader{
+		lggr: logger.Test(t),
+		chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
+			evmChain: createMockReader(t, map[cciptypes.UnknownEncodedAddress]*big.Int{ArbAddr: big.NewInt(4e18)},
+				nil, evmTokenInfo),
+		},
+		feedReaders: map[cciptypes.ChainSelector]PriceFeedReader{
+			solanaChain: stubFeedReader{answers: map[cciptypes.UnknownEncodedAddress]FeedAnswer{
+				solFeed:  {Round: &LatestRoundData{Answer: big.NewInt(1.5e8)}, Price: big.NewInt(1.5e18)},
+				linkFeed: {Round: &LatestRoundData{Answer: big.NewInt(5e8)}, Price: big.NewInt(5e18)},
+			}},
+		},
+		tokenInfo: tokenInfo,
+		feedChain: solanaChain,
+	}
+
+	prices, err := pr.GetFeedPricesUSD(context.Background(), []cciptypes.UnknownEncodedAddress{EthAddr, ArbAddr})
+	require.NoError(t, err)
+	require.Equal(t, cciptypes.TokenPriceMap{
+		EthAddr: cciptypes.NewBigInt(big.NewInt(1.5e18)),
+		ArbAddr: cciptypes.NewBigInt(big.NewInt(4e18)),
+	}, prices)
+}
+
+func TestPriceReader_GetFeedPricesUSD_batchRequestFailures(t *testing.T) {
+	failingReader := func() *readermock.MockContractReaderFacade {
+		reader := readermock.NewMockContractReaderFacade(t)
+		reader.EXPECT().BatchGetLatestValues(mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("rpc down")).Once()
+		return reader
+	}
+
+	ethInfo := EthInfo
+	ethInfo.Feeds = []pluginconfig.PriceFeed{{ChainSelector: 2, AggregatorAddress: BtcAgregatorAddr}}
+	tokenInfo := map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{ArbAddr: ArbInfo, EthAddr: ethInfo}
+
+	// one of the feed chains fails, the prices of the other one are returned
+	pr := priceReader{
+		lggr: logger.Test(t),
+		chainReaders: map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{
+			1: createMockReader(t, map[cciptypes.UnknownEncodedAddress]*big.Int{ArbAddr: ArbPrice, EthAddr: EthPrice},
+				nil, tokenInfo),
+			2: failingReader(),
+		},
+		tokenInfo: tokenInfo,
+		feedChain: 1,
+	}
+	prices, err := pr.GetFeedPricesUSD(context.Background(), []cciptypes.UnknownEncodedAddress{ArbAddr, EthAddr})
+	require.NoError(t, err)
+	require.Equal(t, cciptypes.TokenPriceMap{
+		ArbAddr: cciptypes.NewBigInt(ArbPrice),
+		EthAddr: cciptypes.NewBigInt(EthPrice),
+	}, prices)
+
+	// every feed chain fails
+	pr.chainReaders = map[cciptypes.ChainSelector]contractreader.ContractReaderFacade{1: failingReader()}
+	_, err = pr.GetFeedPricesUSD(context.Background(), []cciptypes.UnknownEncodedAddress{ArbAddr, EthAddr})
+	require.Error(t, err)
+}
+
+func TestValidateFeedAnswer(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	round := func(answer, roundID, answeredInRound int64, updatedAgo time.Duration) *LatestRoundData {
+		return &LatestRoundData{
+			RoundID:         big.NewInt(roundID),
+			Answer:          big.NewInt(answer),
+			UpdatedAt:       big.NewInt(now.Add(-updatedAgo).Unix()),
+			AnsweredInRound: big.NewInt(answeredInRound),
+		}
+	}
+	info := pluginconfig.TokenInfo{
+		StalenessThreshold: *commonconfig.MustNewDuration(time.Hour),
+		MinFeedPrice:       cciptypes.NewBigIntFromInt64(10),
+		MaxFeedPrice:       cciptypes.NewBigIntFromInt64(1000),
+	}
+
+	testCases := []struct {
+		name      string
+		info      pluginconfig.TokenInfo
+		round     *LatestRoundData
+		expReason string
+	}{
+		{name: "valid", info: info, round: round(100, 5, 5, time.Minute)},
+		{name: "no checks configured", round: round(1, 5, 5, 24*time.Hour)},
+		{name: "zero answer", info: info, round: round(0, 5, 5, time.Minute), expReason: FeedAnswerNonPositive},
+		{name: "negative answer", round: round(-1, 5, 5, time.Minute), expReason: FeedAnswerNonPositive},
+		{name: "answered in a previous round", info: info, round: round(100, 5, 4, time.Minute),
+			expReason: FeedAnswerStaleRound},
+		{name: "stale", info: info, round: round(100, 5, 5, 2*time.Hour), expReason: FeedAnswerStale},
+		{name: "never updated", info: info, round: round(100, 5, 5, time.Duration(now.Unix())*time.Second),
+			expReason: FeedAnswerStale},
+		{name: "below min", info: info, round: round(9, 5, 5, time.Minute), expReason: FeedAnswerOutOfBounds},
+		{name: "above max", info: info, round: round(1001, 5, 5, time.Minute), expReason: FeedAnswerOutOfBounds},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expReason, validateFeedAnswer(tc.info, tc.round, tc.round.Answer, now))
+		})
+	}
+}
+
 func TestPriceService_calculateUsdPer1e18TokenAmount(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -276,6 +737,11 @@ func createMockReader(
 		expectedResults[boundContract] = results
 	}
 
+	// No batch request is made without any feed to read
+	if len(expectedResults) == 0 {
+		return reader
+	}
+
 	// Set up the mock expectation for BatchGetLatestValues
 	reader.On("BatchGetLatestValues",
 		mock.Anything,
@@ -0,0 +1,303 @@
+package reader
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// PriceReport is an offchain price report of a feed in the Data Streams report format (v3 schema).
+// The prices are the USD prices of a full token with 18 decimals.
+type PriceReport struct {
+	FeedID                ccipocr3.Bytes32
+	ValidFromTimestamp    uint32
+	ObservationsTimestamp uint32
+	NativeFee             *big.Int
+	LinkFee               *big.Int
+	ExpiresAt             uint32
+	BenchmarkPrice        *big.Int
+	Bid                   *big.Int
+	Ask                   *big.Int
+}
+
+// ReportContext is the context signed along with a report: config digest, epoch and round, extra hash.
+type ReportContext [3]ccipocr3.Bytes32
+
+func mustNewABIType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+var (
+	// priceReportArguments are the fields of the v3 report schema.
+	priceReportArguments = abi.Arguments{
+		{Name: "feedId", Type: mustNewABIType("bytes32")},
+		{Name: "validFromTimestamp", Type: mustNewABIType("uint32")},
+		{Name: "observationsTimestamp", Type: mustNewABIType("uint32")},
+		{Name: "nativeFee", Type: mustNewABIType("uint192")},
+		{Name: "linkFee", Type: mustNewABIType("uint192")},
+		{Name: "expiresAt", Type: mustNewABIType("uint32")},
+		{Name: "benchmarkPrice", Type: mustNewABIType("int192")},
+		{Name: "bid", Type: mustNewABIType("int192")},
+		{Name: "ask", Type: mustNewABIType("int192")},
+	}
+
+	// fullReportArguments are the fields of a full report: the report with its context and signatures.
+	fullReportArguments = abi.Arguments{
+		{Name: "reportContext", Type: mustNewABIType("bytes32[3]")},
+		{Name: "reportBlob", Type: mustNewABIType("bytes")},
+		{Name: "rawRs", Type: mustNewABIType("bytes32[]")},
+		{Name: "rawSs", Type: mustNewABIType("bytes32[]")},
+		{Name: "rawVs", Type: mustNewABIType("bytes32")},
+	}
+)
+
+// EncodePriceReport ABI encodes the report.
+func EncodePriceReport(report PriceReport) ([]byte, error) {
+	encoded, err := priceReportArguments.Pack(
+		report.FeedID,
+		report.ValidFromTimestamp,
+		report.ObservationsTimestamp,
+		bigOrZero(report.NativeFee),
+		bigOrZero(report.LinkFee),
+		report.ExpiresAt,
+		bigOrZero(report.BenchmarkPrice),
+		bigOrZero(report.Bid),
+		bigOrZero(report.Ask),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("abi encode price report: %w", err)
+	}
+	return encoded, nil
+}
+
+// DecodePriceReport decodes an ABI encoded report.
+func DecodePriceReport(encoded []byte) (PriceReport, error) {
+	values, err := priceReportArguments.Unpack(encoded)
+	if err != nil {
+		return PriceReport{}, fmt.Errorf("abi decode price report: %w", err)
+	}
+
+	var report PriceReport
+	var ok [9]bool
+	var feedID [32]byte
+	feedID, ok[0] = values[0].([32]byte)
+	report.FeedID = feedID
+	report.ValidFromTimestamp, ok[1] = values[1].(uint32)
+	report.ObservationsTimestamp, ok[2] = values[2].(uint32)
+	report.NativeFee, ok[3] = values[3].(*big.Int)
+	report.LinkFee, ok[4] = values[4].(*big.Int)
+	report.ExpiresAt, ok[5] = values[5].(uint32)
+	report.BenchmarkPrice, ok[6] = values[6].(*big.Int)
+	report.Bid, ok[7] = values[7].(*big.Int)
+	report.Ask, ok[8] = values[8].(*big.Int)
+	for i := range ok {
+		if !ok[i] {
+			return PriceReport{}, fmt.Errorf("invalid type of price report field %s", priceReportArguments[i].Name)
+		}
+	}
+	return report, nil
+}
+
+// priceReportHash returns the hash signed by the signers of a report: keccak256(keccak256(report) || reportContext).
+func priceReportHash(report []byte, reportContext ReportContext) common.Hash {
+	reportHash := crypto.Keccak256(report)
+	return crypto.Keccak256Hash(reportHash, reportContext[0][:], reportContext[1][:], reportContext[2][:])
+}
+
+// SignPriceReport signs the report with the keys and returns the ABI encoded full report.
+func SignPriceReport(report PriceReport, reportContext ReportContext, keys []*ecdsa.PrivateKey) ([]byte, error) {
+	if len(keys) > 32 {
+		return nil, fmt.Errorf("at most 32 signatures, got %d", len(keys))
+	}
+
+	encoded, err := EncodePriceReport(report)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := priceReportHash(encoded, reportContext)
+	rs := make([][32]byte, len(keys))
+	ss := make([][32]byte, len(keys))
+	var vs [32]byte
+	for i, key := range keys {
+		sig, err := crypto.Sign(hash[:], key)
+		if err != nil {
+			return nil, fmt.Errorf("sign price report: %w", err)
+		}
+		copy(rs[i][:], sig[:32])
+		copy(ss[i][:], sig[32:64])
+		vs[i] = sig[64]
+	}
+
+	fullReport, err := fullReportArguments.Pack(
+		[3][32]byte{reportContext[0], reportContext[1], reportContext[2]}, encoded, rs, ss, vs)
+	if err != nil {
+		return nil, fmt.Errorf("abi encode full report: %w", err)
+	}
+	return fullReport, nil
+}
+
+// VerifyPriceReport decodes a full report and verifies that it is signed under configDigest by at least minSigners
+// distinct signers. Signatures from other keys are ignored.
+func VerifyPriceReport(
+	fullReport []byte,
+	configDigest ccipocr3.Bytes32,
+	signers map[common.Address]struct{},
+	minSigners int,
+) (PriceReport, error) {
+	values, err := fullReportArguments.Unpack(fullReport)
+	if err != nil {
+		return PriceReport{}, fmt.Errorf("abi decode full report: %w", err)
+	}
+	reportContext, ok1 := values[0].([3][32]byte)
+	encoded, ok2 := values[1].([]byte)
+	rs, ok3 := values[2].([][32]byte)
+	ss, ok4 := values[3].([][32]byte)
+	vs, ok5 := values[4].([32]byte)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return PriceReport{}, errors.New("invalid types of full report fields")
+	}
+	if len(rs) != len(ss) || len(rs) > len(vs) {
+		return PriceReport{}, fmt.Errorf("mismatched signatures, %d r and %d s", len(rs), len(ss))
+	}
+	if ccipocr3.Bytes32(reportContext[0]) != configDigest {
+		return PriceReport{}, fmt.Errorf("report signed under config digest %s, expected %s",
+			ccipocr3.Bytes32(reportContext[0]), configDigest)
+	}
+
+	hash := priceReportHash(encoded, ReportContext{reportContext[0], reportContext[1], reportContext[2]})
+	verified := make(map[common.Address]struct{}, len(rs))
+	for i := range rs {
+		sig := make([]byte, crypto.SignatureLength)
+		copy(sig[:32], rs[i][:])
+		copy(sig[32:64], ss[i][:])
+		sig[64] = vs[i]
+		pub, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			continue
+		}
+		signer := crypto.PubkeyToAddress(*pub)
+		if _, ok := signers[signer]; ok {
+			verified[signer] = struct{}{}
+		}
+	}
+	if len(verified) < minSigners {
+		return PriceReport{}, fmt.Errorf("report signed by %d of the signers, %d required", len(verified), minSigners)
+	}
+
+	return DecodePriceReport(encoded)
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return v
+}
+
+// PriceReportFetcher fetches the latest full reports of feeds, see SignPriceReport. The reports are verified by the
+// caller, so that fetchers only transport them.
+type PriceReportFetcher interface {
+	// FetchLatestReports returns the latest full report of each feed, the feeds without a report are not returned.
+	// An error is returned when the reports could not be fetched at all.
+	FetchLatestReports(ctx context.Context, feedIDs []ccipocr3.Bytes32) (map[ccipocr3.Bytes32][]byte, error)
+}
+
+// ReportPrice is the price of a verified price report.
+type ReportPrice struct {
+	// Price is the USD price of a full token with 18 decimals.
+	Price *big.Int
+	// ObservedAt is the time the price was observed at.
+	ObservedAt time.Time
+}
+
+// PriceReportReader reads the prices of feeds from verified offchain price reports.
+type PriceReportReader interface {
+	// GetReportPrices returns the prices of the valid latest reports of the feeds, expired reports and reports that
+	// are not signed by enough signers are ignored.
+	GetReportPrices(ctx context.Context, feedIDs []ccipocr3.Bytes32) (map[ccipocr3.Bytes32]ReportPrice, error)
+}
+
+type priceReportReader struct {
+	lggr         logger.Logger
+	fetcher      PriceReportFetcher
+	configDigest ccipocr3.Bytes32
+	signers      map[common.Address]struct{}
+	minSigners   int
+}
+
+// NewPriceReportReader creates a PriceReportReader of the reports of the fetcher signed under configDigest by at least
+// minSigners of the signers.
+func NewPriceReportReader(
+	lggr logger.Logger,
+	fetcher PriceReportFetcher,
+	configDigest ccipocr3.Bytes32,
+	signers []ccipocr3.UnknownEncodedAddress,
+	minSigners int,
+) (PriceReportReader, error) {
+	signerSet := make(map[common.Address]struct{}, len(signers))
+	for _, signer := range signers {
+		if !common.IsHexAddress(string(signer)) {
+			return nil, fmt.Errorf("invalid signer address %s", signer)
+		}
+		signerSet[common.HexToAddress(string(signer))] = struct{}{}
+	}
+	return &priceReportReader{
+		lggr:         lggr,
+		fetcher:      fetcher,
+		configDigest: configDigest,
+		signers:      signerSet,
+		minSigners:   minSigners,
+	}, nil
+}
+
+func (r *priceReportReader) GetReportPrices(
+	ctx context.Context,
+	feedIDs []ccipocr3.Bytes32,
+) (map[ccipocr3.Bytes32]ReportPrice, error) {
+	fullReports, err := r.fetcher.FetchLatestReports(ctx, feedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetch price reports: %w", err)
+	}
+
+	now := time.Now()
+	prices := make(map[ccipocr3.Bytes32]ReportPrice, len(fullReports))
+	for feedID, fullReport := range fullReports {
+		report, err := VerifyPriceReport(fullReport, r.configDigest, r.signers, r.minSigners)
+		if err != nil {
+			r.lggr.Warnw("ignoring invalid price report", "feedID", feedID, "err", err)
+			continue
+		}
+		if report.FeedID != feedID {
+			r.lggr.Warnw("ignoring price report of another feed", "feedID", feedID, "reportFeedID", report.FeedID)
+			continue
+		}
+		if report.ExpiresAt != 0 && now.After(time.Unix(int64(report.ExpiresAt), 0)) {
+			r.lggr.Warnw("ignoring expired price report", "feedID", feedID, "expiresAt", report.ExpiresAt)
+			continue
+		}
+		prices[feedID] = ReportPrice{
+			Price:      report.BenchmarkPrice,
+			ObservedAt: time.Unix(int64(report.ObservationsTimestamp), 0),
+		}
+	}
+	return prices, nil
+}
+
+// Ensure priceReportReader implements PriceReportReader
+var _ PriceReportReader = (*priceReportReader)(nil)
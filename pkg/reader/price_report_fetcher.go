@@ -0,0 +1,121 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// priceReportLatestPath is the path of the latest report of a feed, with the feed ID in the feedID query parameter.
+const priceReportLatestPath = "/api/v1/reports/latest"
+
+// priceReportResponse is the response of the latest report of a feed.
+type priceReportResponse struct {
+	Report struct {
+		FeedID                ccipocr3.Bytes32 `json:"feedID"`
+		ValidFromTimestamp    uint32           `json:"validFromTimestamp"`
+		ObservationsTimestamp uint32           `json:"observationsTimestamp"`
+		FullReport            ccipocr3.Bytes   `json:"fullReport"`
+	} `json:"report"`
+}
+
+// httpPriceReportFetcher fetches the latest reports of feeds from a Data Streams style REST API:
+//
+//	GET {url}/api/v1/reports/latest?feedID=0x...
+//
+// Feeds without a report are answered with 404.
+type httpPriceReportFetcher struct {
+	lggr   logger.Logger
+	apiURL *url.URL
+	client *http.Client
+}
+
+// NewHTTPPriceReportFetcher creates a PriceReportFetcher of the report server at apiURL.
+func NewHTTPPriceReportFetcher(
+	lggr logger.Logger,
+	apiURL string,
+	timeout time.Duration,
+) (PriceReportFetcher, error) {
+	u, err := url.ParseRequestURI(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse price report api url: %w", err)
+	}
+	return &httpPriceReportFetcher{
+		lggr:   lggr,
+		apiURL: u,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (f *httpPriceReportFetcher) FetchLatestReports(
+	ctx context.Context,
+	feedIDs []ccipocr3.Bytes32,
+) (map[ccipocr3.Bytes32][]byte, error) {
+	reports := make(map[ccipocr3.Bytes32][]byte, len(feedIDs))
+	var errs []error
+	for _, feedID := range feedIDs {
+		report, err := f.fetchLatestReport(ctx, feedID)
+		if err != nil {
+			f.lggr.Warnw("failed to fetch price report", "feedID", feedID, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		if report != nil {
+			reports[feedID] = report
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(feedIDs) {
+		return nil, errors.Join(errs...)
+	}
+	return reports, nil
+}
+
+// fetchLatestReport returns the latest full report of the feed, nil if the feed has no report.
+func (f *httpPriceReportFetcher) fetchLatestReport(ctx context.Context, feedID ccipocr3.Bytes32) ([]byte, error) {
+	requestURL := *f.apiURL
+	requestURL.Path = path.Join(requestURL.Path, priceReportLatestPath)
+	requestURL.RawQuery = url.Values{"feedID": {feedID.String()}}.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("get latest report of feed %s: %w", feedID, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get latest report of feed %s: status %d", feedID, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read latest report of feed %s: %w", feedID, err)
+	}
+	var decoded priceReportResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("decode latest report of feed %s: %w", feedID, err)
+	}
+	if decoded.Report.FeedID != feedID {
+		return nil, fmt.Errorf("got report of feed %s instead of %s", decoded.Report.FeedID, feedID)
+	}
+	return decoded.Report.FullReport, nil
+}
+
+// Ensure httpPriceReportFetcher implements PriceReportFetcher
+var _ PriceReportFetcher = (*httpPriceReportFetcher)(nil)
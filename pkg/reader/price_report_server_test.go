@@ -0,0 +1,120 @@
+package reader
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// priceReportServer is an HTTP stand-in of a price report server, serving the latest reports of feeds with the API
+// read by NewHTTPPriceReportFetcher.
+type priceReportServer struct {
+	server       *httptest.Server
+	configDigest ccipocr3.Bytes32
+	keys         []*ecdsa.PrivateKey
+
+	mu      sync.RWMutex
+	reports map[ccipocr3.Bytes32]priceReportResponse
+}
+
+// newPriceReportServer starts a server signing the reports under configDigest with numSigners generated keys, close it
+// with Close.
+func newPriceReportServer(configDigest ccipocr3.Bytes32, numSigners int) (*priceReportServer, error) {
+	keys := make([]*ecdsa.PrivateKey, numSigners)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("generate signer key: %w", err)
+		}
+		keys[i] = key
+	}
+
+	s := &priceReportServer{
+		configDigest: configDigest,
+		keys:         keys,
+		reports:      make(map[ccipocr3.Bytes32]priceReportResponse),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(priceReportLatestPath, s.handleLatestReport)
+	s.server = httptest.NewServer(mux)
+	return s, nil
+}
+
+// URL returns the base URL of the server.
+func (s *priceReportServer) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+func (s *priceReportServer) Close() {
+	s.server.Close()
+}
+
+// Signers returns the addresses of the keys signing the reports.
+func (s *priceReportServer) Signers() []ccipocr3.UnknownEncodedAddress {
+	signers := make([]ccipocr3.UnknownEncodedAddress, len(s.keys))
+	for i, key := range s.keys {
+		signers[i] = ccipocr3.UnknownEncodedAddress(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	}
+	return signers
+}
+
+// SetReport signs the report with the first numSignatures keys of the server and serves it as the latest report of
+// its feed.
+func (s *priceReportServer) SetReport(report PriceReport, numSignatures int) error {
+	if numSignatures > len(s.keys) {
+		return fmt.Errorf("%d signatures requested, the server has %d keys", numSignatures, len(s.keys))
+	}
+	fullReport, err := SignPriceReport(report, ReportContext{s.configDigest}, s.keys[:numSignatures])
+	if err != nil {
+		return err
+	}
+	s.SetFullReport(report, fullReport)
+	return nil
+}
+
+// SetFullReport serves the full report as the latest report of the feed of the report, e.g. to serve a report
+// signed by other keys or under another config digest.
+func (s *priceReportServer) SetFullReport(report PriceReport, fullReport []byte) {
+	var response priceReportResponse
+	response.Report.FeedID = report.FeedID
+	response.Report.ValidFromTimestamp = report.ValidFromTimestamp
+	response.Report.ObservationsTimestamp = report.ObservationsTimestamp
+	response.Report.FullReport = fullReport
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[report.FeedID] = response
+}
+
+func (s *priceReportServer) handleLatestReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	feedID, err := ccipocr3.NewBytes32FromString(r.URL.Query().Get("feedID"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	response, ok := s.reports[feedID]
+	s.mu.RUnlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,258 @@
+package reader
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
+)
+
+var (
+	ethReportFeedID = cciptypes.Bytes32{0x00, 0x03, 0xe1}
+	arbReportFeedID = cciptypes.Bytes32{0x00, 0x03, 0xa1}
+
+	reportConfigDigest = cciptypes.Bytes32{0x00, 0x01, 0xcd}
+)
+
+func newPriceReport(feedID cciptypes.Bytes32, price *big.Int, observedAt time.Time) PriceReport {
+	return PriceReport{
+		FeedID:                feedID,
+		ValidFromTimestamp:    uint32(observedAt.Unix()),
+		ObservationsTimestamp: uint32(observedAt.Unix()),
+		NativeFee:             big.NewInt(1e15),
+		LinkFee:               big.NewInt(1e16),
+		ExpiresAt:             uint32(observedAt.Add(time.Hour).Unix()),
+		BenchmarkPrice:        price,
+		Bid:                   price,
+		Ask:                   price,
+	}
+}
+
+func TestVerifyPriceReport(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+	}
+	// the last key is not a signer
+	signers := make(map[common.Address]struct{})
+	for _, key := range keys[:3] {
+		signers[crypto.PubkeyToAddress(key.PublicKey)] = struct{}{}
+	}
+
+	report := newPriceReport(ethReportFeedID, EthPrice, time.Unix(1_700_000_000, 0))
+	reportContext := ReportContext{reportConfigDigest, {0x2}, {0x3}}
+
+	testCases := []struct {
+		name       string
+		keys       []*ecdsa.PrivateKey
+		minSigners int
+		wantErr    bool
+	}{
+		{name: "enough signers", keys: keys[:2], minSigners: 2},
+		{name: "all signers", keys: keys[:3], minSigners: 3},
+		{name: "not enough signers", keys: keys[:1], minSigners: 2, wantErr: true},
+		{name: "unknown signer is ignored", keys: []*ecdsa.PrivateKey{keys[0], keys[3]}, minSigners: 2, wantErr: true},
+		{name: "duplicate signatures count once", keys: []*ecdsa.PrivateKey{keys[0], keys[0]}, minSigners: 2,
+			wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fullReport, err := SignPriceReport(report, reportContext, tc.keys)
+			require.NoError(t, err)
+
+			verified, err := VerifyPriceReport(fullReport, reportConfigDigest, signers, tc.minSigners)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, report, verified)
+		})
+	}
+
+	t.Run("tampered report", func(t *testing.T) {
+		fullReport, err := SignPriceReport(report, reportContext, keys[:3])
+		require.NoError(t, err)
+		values, err := fullReportArguments.Unpack(fullReport)
+		require.NoError(t, err)
+
+		tampered := report
+		tampered.BenchmarkPrice = big.NewInt(0).Mul(EthPrice, big.NewInt(2))
+		encoded, err := EncodePriceReport(tampered)
+		require.NoError(t, err)
+		tamperedFullReport, err := fullReportArguments.Pack(values[0], encoded, values[2], values[3], values[4])
+		require.NoError(t, err)
+
+		_, err = VerifyPriceReport(tamperedFullReport, reportConfigDigest, signers, 1)
+		require.Error(t, err)
+	})
+
+	t.Run("signed under another config digest", func(t *testing.T) {
+		otherContext := ReportContext{{0x00, 0x01, 0xef}, {0x2}, {0x3}}
+		fullReport, err := SignPriceReport(report, otherContext, keys[:3])
+		require.NoError(t, err)
+
+		_, err = VerifyPriceReport(fullReport, reportConfigDigest, signers, 1)
+		require.ErrorContains(t, err, "config digest")
+	})
+}
+
+func TestPriceReportReader_httpFetcher(t *testing.T) {
+	server, err := newPriceReportServer(reportConfigDigest, 3)
+	require.NoError(t, err)
+	defer server.Close()
+
+	now := time.Now()
+	btcReportFeedID := cciptypes.Bytes32{0x00, 0x03, 0xb1}
+	otherDigestReportFeedID := cciptypes.Bytes32{0x00, 0x03, 0xd1}
+	missingReportFeedID := cciptypes.Bytes32{0x00, 0x03, 0xff}
+
+	require.NoError(t, server.SetReport(newPriceReport(ethReportFeedID, EthPrice, now), 2))
+	// not signed by enough signers
+	require.NoError(t, server.SetReport(newPriceReport(arbReportFeedID, ArbPrice, now), 1))
+	// expired
+	expired := newPriceReport(btcReportFeedID, big.NewInt(9e18), now.Add(-2*time.Hour))
+	require.NoError(t, server.SetReport(expired, 3))
+	// signed by the signers under another config digest
+	otherDigestReport := newPriceReport(otherDigestReportFeedID, big.NewInt(7e18), now)
+	otherDigestFullReport, err := SignPriceReport(otherDigestReport, ReportContext{{0x00, 0x01, 0xef}}, server.keys)
+	require.NoError(t, err)
+	server.SetFullReport(otherDigestReport, otherDigestFullReport)
+
+	fetcher, err := NewHTTPPriceReportFetcher(logger.Test(t), server.URL(), time.Second)
+	require.NoError(t, err)
+	reader, err := NewPriceReportReader(logger.Test(t), fetcher, reportConfigDigest, server.Signers(), 2)
+	require.NoError(t, err)
+
+	prices, err := reader.GetReportPrices(context.Background(), []cciptypes.Bytes32{
+		ethReportFeedID, arbReportFeedID, btcReportFeedID, otherDigestReportFeedID, missingReportFeedID})
+	require.NoError(t, err)
+	require.Equal(t, map[cciptypes.Bytes32]ReportPrice{
+		ethReportFeedID: {Price: EthPrice, ObservedAt: time.Unix(now.Unix(), 0)},
+	}, prices)
+
+	// the server is down
+	server.Close()
+	_, err = reader.GetReportPrices(context.Background(), []cciptypes.Bytes32{ethReportFeedID})
+	require.Error(t, err)
+}
+
+// stubReportReader is a PriceReportReader of the provided report prices.
+type stubReportReader struct {
+	prices map[cciptypes.Bytes32]ReportPrice
+	err    error
+}
+
+func (r stubReportReader) GetReportPrices(
+	_ context.Context,
+	feedIDs []cciptypes.Bytes32,
+) (map[cciptypes.Bytes32]ReportPrice, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	prices := make(map[cciptypes.Bytes32]ReportPrice)
+	for _, feedID := range feedIDs {
+		if price, ok := r.prices[feedID]; ok {
+			prices[feedID] = price
+		}
+	}
+	return prices, nil
+}
+
+func TestPriceReader_GetFeedPricesUSD_priceReports(t *testing.T) {
+	const feedChain = cciptypes.ChainSelector(1)
+	now := time.Now()
+	reportPrice := big.NewInt(8e18)
+
+	ethInfo := EthInfo
+	ethInfo.ReportFeedID = ethReportFeedID
+	ethInfo.StalenessThreshold = *commonconfig.MustNewDuration(time.Minute)
+	// a token priced from its report only
+	arbInfo := pluginconfig.TokenInfo{ReportFeedID: arbReportFeedID, Decimals: Decimals18}
+	tokenInfo := map[cciptypes.UnknownEncodedAddress]pluginconfig.TokenInfo{EthAddr: ethInfo, ArbAddr: arbInfo}
+
+	feedReader := stubFeedReader{answers: map[cciptypes.UnknownEncodedAddress]FeedAnswer{
+		EthAggregatorAddr: {Round: &LatestRoundData{Answer: EthPrice, UpdatedAt: big.NewInt(now.Unix())},
+			Price: EthPrice},
+	}}
+
+	testCases := []struct {
+		name        string
+		reports     stubReportReader
+		wantPrices  cciptypes.TokenPriceMap
+		wantInvalid []InvalidFeedAnswer
+	}{
+		{
+			name: "report price is the primary price",
+			reports: stubReportReader{prices: map[cciptypes.Bytes32]ReportPrice{
+				ethReportFeedID: {Price: reportPrice, ObservedAt: now},
+				arbReportFeedID: {Price: ArbPrice, ObservedAt: now},
+			}},
+			wantPrices: cciptypes.TokenPriceMap{
+				EthAddr: cciptypes.NewBigInt(reportPrice),
+				ArbAddr: cciptypes.NewBigInt(ArbPrice),
+			},
+		},
+		{
+			name:       "missing report falls back to the feed",
+			reports:    stubReportReader{},
+			wantPrices: cciptypes.TokenPriceMap{EthAddr: cciptypes.NewBigInt(EthPrice)},
+		},
+		{
+			name: "stale report falls back to the feed",
+			reports: stubReportReader{prices: map[cciptypes.Bytes32]ReportPrice{
+				ethReportFeedID: {Price: reportPrice, ObservedAt: now.Add(-time.Hour)},
+			}},
+			wantPrices:  cciptypes.TokenPriceMap{EthAddr: cciptypes.NewBigInt(EthPrice)},
+			wantInvalid: []InvalidFeedAnswer{{Token: EthAddr, Reason: FeedAnswerStale}},
+		},
+		{
+			name: "non positive report price is ignored",
+			reports: stubReportReader{prices: map[cciptypes.Bytes32]ReportPrice{
+				arbReportFeedID: {Price: big.NewInt(0), ObservedAt: now},
+			}},
+			wantPrices:  cciptypes.TokenPriceMap{EthAddr: cciptypes.NewBigInt(EthPrice)},
+			wantInvalid: []InvalidFeedAnswer{{Token: ArbAddr, Reason: FeedAnswerNonPositive}},
+		},
+		{
+			name:       "report server down falls back to the feed",
+			reports:    stubReportReader{err: errors.New("report server down")},
+			wantPrices: cciptypes.TokenPriceMap{EthAddr: cciptypes.NewBigInt(EthPrice)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := NewPriceReader(PriceReaderParams{
+				Lggr:         logger.Test(t),
+				TokenInfo:    tokenInfo,
+				FeedChain:    feedChain,
+				FeedReaders:  map[cciptypes.ChainSelector]PriceFeedReader{feedChain: feedReader},
+				ReportReader: tc.reports,
+			})
+
+			prices, err := pr.GetFeedPricesUSD(context.Background(), []cciptypes.UnknownEncodedAddress{EthAddr, ArbAddr})
+			if len(tc.wantInvalid) > 0 {
+				var invalidErr *InvalidFeedAnswersError
+				require.ErrorAs(t, err, &invalidErr)
+				require.ElementsMatch(t, tc.wantInvalid, invalidErr.Answers)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantPrices, prices)
+		})
+	}
+}
@@ -1,12 +1,11 @@
 package pluginconfig
 
 import (
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
-	"strings"
+	"sort"
 	"time"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
@@ -22,6 +21,7 @@ const (
 	defaultNewMsgScanBatchSize                = merklemulti.MaxNumberTreeLeaves
 	defaultEvmDefaultMaxMerkleTreeSize        = merklemulti.MaxNumberTreeLeaves
 	defaultMaxReportTransmissionCheckAttempts = 5
+	defaultReportTransmissionLookback         = 10 * time.Minute
 	defaultRemoteGasPriceBatchWriteFrequency  = 1 * time.Minute
 	defaultSignObservationPrefix              = "chainlink ccip 1.6 rmn observation"
 	defaultTransmissionDelayMultiplier        = 30 * time.Second
@@ -31,7 +31,8 @@ const (
 )
 
 type TokenInfo struct {
-	// AggregatorAddress is the address of the price feed TOKEN/USD aggregator on the feed chain.
+	// AggregatorAddress is the address of the price feed TOKEN/USD aggregator on the feed chain, encoded as an
+	// address of the chain family of the feed chain.
 	AggregatorAddress cciptypes.UnknownEncodedAddress `json:"aggregatorAddress"`
 
 	// DeviationPPB is the deviation in parts per billion that the price feed is allowed to deviate
@@ -40,20 +41,76 @@ type TokenInfo struct {
 
 	// Decimals is the number of decimals for the token (NOT the feed).
 	Decimals uint8 `json:"decimals"`
+
+	// Heartbeat overrides TokenPriceBatchWriteFrequency for this token when set.
+	Heartbeat commonconfig.Duration `json:"heartbeat"`
+
+	// Feeds are additional price feeds of the token, possibly on other chains than the feed chain.
+	// AggregatorAddress can be left empty when at least one feed is set here. The oracles observe the price of the
+	// token when they support one of the chains of its feeds, and the consensus on its price requires 2f+1
+	// observations with the largest f of those chains, see CommitOffchainConfig.TokenPriceChains.
+	Feeds []PriceFeed `json:"feeds"`
+
+	// FeedAggregation selects how the prices of the feeds are combined, FeedAggregationPrimaryWithFallback if not set.
+	FeedAggregation FeedAggregation `json:"feedAggregation"`
+
+	// StalenessThreshold is the maximum age of the latest round of a feed, older answers are ignored.
+	// Disable by setting to 0.
+	StalenessThreshold commonconfig.Duration `json:"stalenessThreshold"`
+
+	// MinFeedPrice and MaxFeedPrice bound the USD price of a full token answered by a feed, with 18 decimals.
+	// Answers out of the bounds are ignored. A bound is not checked when not set.
+	MinFeedPrice cciptypes.BigInt `json:"minFeedPrice"`
+	MaxFeedPrice cciptypes.BigInt `json:"maxFeedPrice"`
+
+	// Derived defines the price of a token without a TOKEN/USD feed from the prices of other assets.
+	// AggregatorAddress and Feeds must not be set for a derived token, the bounds apply to the derived price.
+	Derived *DerivedPrice `json:"derived,omitempty"`
+
+	// ReportFeedID is the feed ID of the token in the reports of CommitOffchainConfig.PriceReportSource. When set, the
+	// report price is the primary price of the token, before the prices of its feeds, and AggregatorAddress can be left
+	// empty. Cannot be set for a derived price.
+	ReportFeedID cciptypes.Bytes32 `json:"reportFeedID"`
+}
+
+// PriceFeeds returns every price feed of the token, the AggregatorAddress feed first, with the feeds that do not
+// set their chain on feedChain.
+func (a TokenInfo) PriceFeeds(feedChain cciptypes.ChainSelector) []PriceFeed {
+	feeds := make([]PriceFeed, 0, len(a.Feeds)+1)
+	if a.AggregatorAddress != "" {
+		feeds = append(feeds, PriceFeed{ChainSelector: feedChain, AggregatorAddress: a.AggregatorAddress})
+	}
+	for _, feed := range a.Feeds {
+		if feed.ChainSelector == 0 {
+			feed.ChainSelector = feedChain
+		}
+		feeds = append(feeds, feed)
+	}
+	return feeds
 }
 
 func (a TokenInfo) Validate() error {
-	if a.AggregatorAddress == "" {
-		return errors.New("aggregatorAddress not set")
+	if a.Derived != nil {
+		if a.AggregatorAddress != "" || len(a.Feeds) > 0 || !a.ReportFeedID.IsEmpty() {
+			return errors.New("aggregatorAddress, feeds and reportFeedID must not be set for a derived price")
+		}
+		if err := a.Derived.Validate(); err != nil {
+			return fmt.Errorf("invalid derived price: %w", err)
+		}
+	} else if a.AggregatorAddress != "" || (len(a.Feeds) == 0 && a.ReportFeedID.IsEmpty()) {
+		if err := validateAggregatorAddress(a.AggregatorAddress); err != nil {
+			return err
+		}
 	}
 
-	// aggregator must be an ethereum address
-	decoded, err := hex.DecodeString(strings.ToLower(strings.TrimPrefix(string(a.AggregatorAddress), "0x")))
-	if err != nil {
-		return fmt.Errorf("aggregatorAddress must be a valid ethereum address (i.e hex encoded 20 bytes): %w", err)
+	for i, feed := range a.Feeds {
+		if err := feed.Validate(); err != nil {
+			return fmt.Errorf("invalid feed %d: %w", i, err)
+		}
 	}
-	if len(decoded) != 20 {
-		return fmt.Errorf("aggregatorAddress must be a valid ethereum address, got %d bytes expected 20", len(decoded))
+
+	if err := a.FeedAggregation.Validate(); err != nil {
+		return err
 	}
 
 	if a.DeviationPPB.Int.Cmp(big.NewInt(0)) <= 0 {
@@ -64,6 +121,16 @@ func (a TokenInfo) Validate() error {
 		return fmt.Errorf("tokenDecimals can't be zero")
 	}
 
+	if !a.MinFeedPrice.IsEmpty() && !a.MinFeedPrice.IsPositive() {
+		return errors.New("minFeedPrice must be positive")
+	}
+	if !a.MaxFeedPrice.IsEmpty() && !a.MaxFeedPrice.IsPositive() {
+		return errors.New("maxFeedPrice must be positive")
+	}
+	if !a.MinFeedPrice.IsEmpty() && !a.MaxFeedPrice.IsEmpty() && a.MinFeedPrice.Cmp(a.MaxFeedPrice.Int) > 0 {
+		return fmt.Errorf("minFeedPrice (%s) is greater than maxFeedPrice (%s)", a.MinFeedPrice, a.MaxFeedPrice)
+	}
+
 	return nil
 }
 
@@ -83,10 +150,30 @@ type CommitOffchainConfig struct {
 	// If set to zero, no prices will be written (i.e keystone feeds would be active).
 	TokenPriceBatchWriteFrequency commonconfig.Duration `json:"tokenPriceBatchWriteFrequency"`
 
+	// TokenPriceUpdatePolicy selects how the token prices to write to the remote chain are chosen.
+	TokenPriceUpdatePolicy UpdatePolicyConfig `json:"tokenPriceUpdatePolicy"`
+
+	// ChainFeeUpdatePolicy selects how the chain fees to write to the remote chain are chosen.
+	ChainFeeUpdatePolicy UpdatePolicyConfig `json:"chainFeeUpdatePolicy"`
+
+	// ChainFeeOutlierMADMultiplier rejects the observed fee components and native token prices of a chain that are
+	// further than this many median absolute deviations from the median of the observations, before the consensus
+	// median is taken. Typically set to a value between 3 and 10. Disable by setting to 0.
+	ChainFeeOutlierMADMultiplier uint64 `json:"chainFeeOutlierMADMultiplier"`
+
+	// ChainFeeMaxChangePPB caps, per source chain, how much the USD execution and data availability gas prices may
+	// change in a single update, in parts per billion of the onchain values. A larger change is written in steps over
+	// the next updates. Chains that are not in the map are not capped.
+	ChainFeeMaxChangePPB map[cciptypes.ChainSelector]uint64 `json:"chainFeeMaxChangePPB"`
+
 	// TokenInfo is a map of Arbitrum price sources for each token.
 	// Note that the token address is that on the remote chain.
 	TokenInfo map[cciptypes.UnknownEncodedAddress]TokenInfo `json:"tokenInfo"`
 
+	// PriceReportSource is an offchain source of signed price reports for the tokens with a TokenInfo.ReportFeedID,
+	// optional.
+	PriceReportSource *PriceReportSource `json:"priceReportSource,omitempty"`
+
 	// PriceFeedChainSelector is the chain selector for the chain on which
 	// the token prices are read from.
 	// This will typically be an arbitrum testnet/mainnet chain depending on
@@ -99,6 +186,10 @@ type CommitOffchainConfig struct {
 	// The maximum number of times to check if the previous report has been transmitted
 	MaxReportTransmissionCheckAttempts uint `json:"maxReportTransmissionCheckAttempts"`
 
+	// ReportTransmissionLookback is how far back the CommitReportAccepted events of the destination chain are read
+	// to find the merkle roots of the report that is waiting for transmission, or a competing report.
+	ReportTransmissionLookback time.Duration `json:"reportTransmissionLookback"`
+
 	// RMNSignaturesTimeout is the timeout for RMN signature verification.
 	// Typically set to `MaxQueryDuration - e`, where e some small duration.
 	RMNSignaturesTimeout time.Duration `json:"rmnSignaturesTimeout"`
@@ -164,6 +255,8 @@ type CommitOffchainConfig struct {
 
 	// MaxRootsPerReport is the maximum number of roots to include in a single report.
 	// Set this to 1 for destination chains that cannot process more than one commit root per report (e.g, Solana)
+	// When more source chains have pending messages, the chains of the first report are scheduled by the age and
+	// the number of their pending messages so that busy chains cannot indefinitely delay quiet ones.
 	// Disable by setting to 0.
 	// NOTE:
 	//  * this can only be used if RMNEnabled == false.
@@ -205,6 +298,10 @@ func (c *CommitOffchainConfig) applyDefaults() {
 		c.MaxReportTransmissionCheckAttempts = defaultMaxReportTransmissionCheckAttempts
 	}
 
+	if c.ReportTransmissionLookback == 0 {
+		c.ReportTransmissionLookback = defaultReportTransmissionLookback
+	}
+
 	if c.MaxMerkleTreeSize == 0 {
 		c.MaxMerkleTreeSize = defaultEvmDefaultMaxMerkleTreeSize
 	}
@@ -252,6 +349,72 @@ func (c *CommitOffchainConfig) applyDefaults() {
 	if c.TokenPriceAsyncObserverSyncTimeout.Duration() == 0 {
 		c.TokenPriceAsyncObserverSyncTimeout = *commonconfig.MustNewDuration(defaultAsyncObserverSyncTimeout)
 	}
+
+	if c.PriceReportSource != nil && c.PriceReportSource.Timeout.Duration() == 0 {
+		c.PriceReportSource.Timeout = *commonconfig.MustNewDuration(defaultPriceReportTimeout)
+	}
+}
+
+// TokenPriceChains returns the chains the price of the token is read from, sorted and without duplicates: the chains
+// of its feeds, or for a derived price the chains of its feeds, rates and base token. A token priced from its report
+// only has none, its price is not read from a chain.
+func (c *CommitOffchainConfig) TokenPriceChains(token cciptypes.UnknownEncodedAddress) []cciptypes.ChainSelector {
+	tokenInfo, ok := c.TokenInfo[token]
+	if !ok {
+		return nil
+	}
+
+	chains := make(map[cciptypes.ChainSelector]struct{})
+	for _, feed := range tokenInfo.PriceFeeds(c.PriceFeedChainSelector) {
+		chains[feed.ChainSelector] = struct{}{}
+	}
+	if tokenInfo.Derived != nil {
+		derived := tokenInfo.Derived.WithChain(c.PriceFeedChainSelector)
+		for _, feed := range derived.Feeds {
+			chains[feed.ChainSelector] = struct{}{}
+		}
+		for _, rate := range derived.Rates {
+			chains[rate.ChainSelector] = struct{}{}
+		}
+		if derived.BaseToken != "" && derived.BaseToken != token {
+			for _, chain := range c.TokenPriceChains(derived.BaseToken) {
+				chains[chain] = struct{}{}
+			}
+		}
+	}
+
+	sortedChains := make([]cciptypes.ChainSelector, 0, len(chains))
+	for chain := range chains {
+		sortedChains = append(sortedChains, chain)
+	}
+	sort.Slice(sortedChains, func(i, j int) bool { return sortedChains[i] < sortedChains[j] })
+	return sortedChains
+}
+
+// ValidateFeedAddresses validates the addresses of the price feeds and rate providers of the tokens with the
+// address codec of the chain family of their chain, which Validate cannot do since it does not have the codecs.
+func (c *CommitOffchainConfig) ValidateFeedAddresses(addrCodec cciptypes.AddressCodec) error {
+	for token, tokenInfo := range c.TokenInfo {
+		feeds := tokenInfo.PriceFeeds(c.PriceFeedChainSelector)
+		var rates []RateProvider
+		if tokenInfo.Derived != nil {
+			derived := tokenInfo.Derived.WithChain(c.PriceFeedChainSelector)
+			feeds = append(feeds, derived.Feeds...)
+			rates = derived.Rates
+		}
+
+		for _, feed := range feeds {
+			if err := validateAddress(addrCodec, feed.ChainSelector, feed.AggregatorAddress); err != nil {
+				return fmt.Errorf("invalid feed of token %s: %w", token, err)
+			}
+		}
+		for _, rate := range rates {
+			if err := validateAddress(addrCodec, rate.ChainSelector, rate.Address); err != nil {
+				return fmt.Errorf("invalid rate provider of token %s: %w", token, err)
+			}
+		}
+	}
+	return nil
 }
 
 //nolint:gocyclo // it is considered ok since we don't have complicated logic here
@@ -274,6 +437,40 @@ func (c *CommitOffchainConfig) Validate() error {
 		if err := tokenInfo.Validate(); err != nil {
 			return fmt.Errorf("invalid token info for token %s: %w", token, err)
 		}
+		if !tokenInfo.ReportFeedID.IsEmpty() && c.PriceReportSource == nil {
+			return fmt.Errorf("reportFeedID of token %s is set without priceReportSource", token)
+		}
+		if tokenInfo.Derived == nil || tokenInfo.Derived.BaseToken == "" {
+			continue
+		}
+		// The base token must be priced by its own feeds.
+		baseInfo, ok := c.TokenInfo[tokenInfo.Derived.BaseToken]
+		if !ok {
+			return fmt.Errorf("base token %s of token %s has no token info", tokenInfo.Derived.BaseToken, token)
+		}
+		if baseInfo.Derived != nil {
+			return fmt.Errorf("base token %s of token %s must not be derived", tokenInfo.Derived.BaseToken, token)
+		}
+	}
+
+	if c.PriceReportSource != nil {
+		if err := c.PriceReportSource.Validate(); err != nil {
+			return fmt.Errorf("invalid price report source: %w", err)
+		}
+	}
+
+	if err := c.TokenPriceUpdatePolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid token price update policy: %w", err)
+	}
+
+	if err := c.ChainFeeUpdatePolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid chain fee update policy: %w", err)
+	}
+
+	for chain, maxChangePPB := range c.ChainFeeMaxChangePPB {
+		if maxChangePPB == 0 {
+			return fmt.Errorf("chainFeeMaxChangePPB of chain %d must be positive", chain)
+		}
 	}
 
 	if c.NewMsgScanBatchSize == 0 {
@@ -1,9 +1,12 @@
 package pluginconfig
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +24,12 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 		AggregatorAddress cciptypes.UnknownEncodedAddress
 		DeviationPPB      cciptypes.BigInt
 		Decimals          uint8
+		Feeds             []PriceFeed
+		FeedAggregation   FeedAggregation
+		MinFeedPrice      cciptypes.BigInt
+		MaxFeedPrice      cciptypes.BigInt
+		Derived           *DerivedPrice
+		ReportFeedID      cciptypes.Bytes32
 	}
 	tests := []struct {
 		name    string
@@ -64,29 +73,86 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 			true,
 		},
 		{
-			"invalid, invalid address",
+			"invalid, negative deviation",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(-1)},
+				Decimals:          18,
+			},
+			true,
+		},
+		{
+			"invalid, zero deviation",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(0)},
+				Decimals:          18,
+			},
+			true,
+		},
+		{
+			"valid, additional feeds",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				Feeds: []PriceFeed{
+					{ChainSelector: 2, AggregatorAddress: "0x3e03388D351BF87CF2409EFf18C45Df59775Fbb2"},
+				},
+				FeedAggregation: FeedAggregationMedian,
+			},
+			false,
+		},
+		{
+			"valid, feeds without aggregator address",
+			fields{
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:     18,
+				Feeds: []PriceFeed{
+					{AggregatorAddress: "0x3e03388D351BF87CF2409EFf18C45Df59775Fbb2"},
+				},
+			},
+			false,
+		},
+		{
+			"invalid, unknown feed aggregation",
 			fields{
-				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775b",
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
 				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
 				Decimals:          18,
+				FeedAggregation:   "max",
 			},
 			true,
 		},
 		{
-			"invalid, negative deviation",
+			"valid, feed price bounds",
 			fields{
 				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
-				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(-1)},
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
 				Decimals:          18,
+				MinFeedPrice:      cciptypes.NewBigIntFromInt64(1e17),
+				MaxFeedPrice:      cciptypes.NewBigIntFromInt64(1e18),
+			},
+			false,
+		},
+		{
+			"invalid, non positive min feed price",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				MinFeedPrice:      cciptypes.NewBigIntFromInt64(0),
 			},
 			true,
 		},
 		{
-			"invalid, zero deviation",
+			"invalid, min feed price greater than max",
 			fields{
 				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
-				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(0)},
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
 				Decimals:          18,
+				MinFeedPrice:      cciptypes.NewBigIntFromInt64(1e18),
+				MaxFeedPrice:      cciptypes.NewBigIntFromInt64(1e17),
 			},
 			true,
 		},
@@ -99,6 +165,72 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"valid, derived from a base token and a rate",
+			fields{
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:     18,
+				Derived: &DerivedPrice{
+					BaseToken: "0x1111111111111111111111Ff18C45Df59775Fbb2",
+					Rates:     []RateProvider{{Address: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2"}},
+				},
+			},
+			false,
+		},
+		{
+			"valid, derived from feeds",
+			fields{
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:     18,
+				Derived: &DerivedPrice{
+					Feeds: []PriceFeed{
+						{AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2"},
+						{ChainSelector: 2, AggregatorAddress: "0x1111111111111111111111Ff18C45Df59775Fbb2"},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"invalid, derived with an aggregator address",
+			fields{
+				AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+				DeviationPPB:      cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:          18,
+				Derived:           &DerivedPrice{BaseToken: "0x1111111111111111111111Ff18C45Df59775Fbb2"},
+			},
+			true,
+		},
+		{
+			"invalid, derived from rates only",
+			fields{
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:     18,
+				Derived: &DerivedPrice{
+					Rates: []RateProvider{{Address: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2"}},
+				},
+			},
+			true,
+		},
+		{
+			"valid, report price only",
+			fields{
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:     18,
+				ReportFeedID: cciptypes.Bytes32{0x00, 0x03, 0x01},
+			},
+			false,
+		},
+		{
+			"invalid, derived with a report feed ID",
+			fields{
+				DeviationPPB: cciptypes.BigInt{Int: big.NewInt(1)},
+				Decimals:     18,
+				Derived:      &DerivedPrice{BaseToken: "0x1111111111111111111111Ff18C45Df59775Fbb2"},
+				ReportFeedID: cciptypes.Bytes32{0x00, 0x03, 0x01},
+			},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -106,6 +238,12 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 				AggregatorAddress: tt.fields.AggregatorAddress,
 				DeviationPPB:      tt.fields.DeviationPPB,
 				Decimals:          tt.fields.Decimals,
+				Feeds:             tt.fields.Feeds,
+				FeedAggregation:   tt.fields.FeedAggregation,
+				MinFeedPrice:      tt.fields.MinFeedPrice,
+				MaxFeedPrice:      tt.fields.MaxFeedPrice,
+				Derived:           tt.fields.Derived,
+				ReportFeedID:      tt.fields.ReportFeedID,
 			}
 			if err := a.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("TokenInfo.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -114,6 +252,224 @@ func TestArbitrumPriceSource_Validate(t *testing.T) {
 	}
 }
 
+func TestTokenInfo_PriceFeeds(t *testing.T) {
+	info := TokenInfo{
+		AggregatorAddress: "0xa1",
+		Feeds: []PriceFeed{
+			{AggregatorAddress: "0xa2"},
+			{ChainSelector: 2, AggregatorAddress: "0xa3"},
+		},
+	}
+	require.Equal(t, []PriceFeed{
+		{ChainSelector: 1, AggregatorAddress: "0xa1"},
+		{ChainSelector: 1, AggregatorAddress: "0xa2"},
+		{ChainSelector: 2, AggregatorAddress: "0xa3"},
+	}, info.PriceFeeds(1))
+
+	info.AggregatorAddress = ""
+	require.Equal(t, []PriceFeed{
+		{ChainSelector: 1, AggregatorAddress: "0xa2"},
+		{ChainSelector: 2, AggregatorAddress: "0xa3"},
+	}, info.PriceFeeds(1))
+}
+
+func TestCommitOffchainConfig_TokenPriceChains(t *testing.T) {
+	cfg := CommitOffchainConfig{
+		PriceFeedChainSelector: 1,
+		TokenInfo: map[cciptypes.UnknownEncodedAddress]TokenInfo{
+			"0x1": {AggregatorAddress: "0xa1", Feeds: []PriceFeed{{ChainSelector: 3, AggregatorAddress: "0xa2"}}},
+			"0x2": {Derived: &DerivedPrice{
+				BaseToken: "0x1",
+				Feeds:     []PriceFeed{{ChainSelector: 3, AggregatorAddress: "0xa3"}},
+				Rates:     []RateProvider{{ChainSelector: 2, Address: "0xa4"}},
+			}},
+			"0x3": {ReportFeedID: cciptypes.Bytes32{0x1}},
+		},
+	}
+
+	require.Equal(t, []cciptypes.ChainSelector{1, 3}, cfg.TokenPriceChains("0x1"))
+	require.Equal(t, []cciptypes.ChainSelector{1, 2, 3}, cfg.TokenPriceChains("0x2"))
+	require.Empty(t, cfg.TokenPriceChains("0x3"))
+	require.Empty(t, cfg.TokenPriceChains("0x4"))
+}
+
+// testAddressCodec decodes hex addresses of 20 bytes on evmChain and of 32 bytes on the other chains.
+type testAddressCodec struct{}
+
+const evmChain = cciptypes.ChainSelector(1)
+
+func (testAddressCodec) AddressBytesToString(addr cciptypes.UnknownAddress, _ cciptypes.ChainSelector) (string, error) {
+	return "0x" + hex.EncodeToString(addr), nil
+}
+
+func (testAddressCodec) AddressStringToBytes(
+	addr string,
+	chain cciptypes.ChainSelector,
+) (cciptypes.UnknownAddress, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	size := 32
+	if chain == evmChain {
+		size = 20
+	}
+	if len(decoded) != size {
+		return nil, fmt.Errorf("got %d bytes expected %d", len(decoded), size)
+	}
+	return decoded, nil
+}
+
+func TestPriceReportSource_Validate(t *testing.T) {
+	signers := []cciptypes.UnknownEncodedAddress{
+		"0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+		"0x1111111111111111111111Ff18C45Df59775Fbb2",
+	}
+	digest := cciptypes.Bytes32{0x00, 0x01}
+	tests := []struct {
+		name    string
+		source  PriceReportSource
+		wantErr string
+	}{
+		{
+			name: "valid",
+			source: PriceReportSource{URL: "https://reports.example.com", Signers: signers, MinSigners: 2,
+				ConfigDigest: digest},
+		},
+		{
+			name:    "invalid url",
+			source:  PriceReportSource{URL: "reports", Signers: signers, MinSigners: 1},
+			wantErr: "invalid url",
+		},
+		{
+			name:    "no signers",
+			source:  PriceReportSource{URL: "https://reports.example.com", MinSigners: 1},
+			wantErr: "signers not set",
+		},
+		{
+			name: "invalid signer",
+			source: PriceReportSource{URL: "https://reports.example.com", MinSigners: 1,
+				Signers: []cciptypes.UnknownEncodedAddress{"0x2e03388D351BF87CF2409EFf18C45Df59775Fb"}},
+			wantErr: "valid ethereum address",
+		},
+		{
+			name: "duplicate signer",
+			source: PriceReportSource{URL: "https://reports.example.com", MinSigners: 1,
+				Signers: []cciptypes.UnknownEncodedAddress{signers[0], "0x2e03388d351bf87cf2409eff18c45df59775fbb2"}},
+			wantErr: "duplicate signer",
+		},
+		{
+			name:    "no min signers",
+			source:  PriceReportSource{URL: "https://reports.example.com", Signers: signers},
+			wantErr: "minSigners",
+		},
+		{
+			name:    "more min signers than signers",
+			source:  PriceReportSource{URL: "https://reports.example.com", Signers: signers, MinSigners: 3},
+			wantErr: "minSigners",
+		},
+		{
+			name:    "no config digest",
+			source:  PriceReportSource{URL: "https://reports.example.com", Signers: signers, MinSigners: 2},
+			wantErr: "configDigest not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.source.Validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCommitOffchainConfig_ValidateFeedAddresses(t *testing.T) {
+	const (
+		evmAddress   = "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2"
+		otherAddress = "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb22e03388D351BF87CF2409EFf"
+		otherChain   = cciptypes.ChainSelector(2)
+	)
+
+	tests := []struct {
+		name      string
+		feedChain cciptypes.ChainSelector
+		tokenInfo TokenInfo
+		wantErr   bool
+	}{
+		{
+			name:      "valid, evm feed chain",
+			feedChain: evmChain,
+			tokenInfo: TokenInfo{
+				AggregatorAddress: evmAddress,
+				Feeds:             []PriceFeed{{ChainSelector: otherChain, AggregatorAddress: otherAddress}},
+			},
+		},
+		{
+			name:      "valid, non-evm feed chain",
+			feedChain: otherChain,
+			tokenInfo: TokenInfo{
+				AggregatorAddress: otherAddress,
+				Feeds:             []PriceFeed{{ChainSelector: evmChain, AggregatorAddress: evmAddress}},
+			},
+		},
+		{
+			name:      "invalid, address of the feed chain",
+			feedChain: evmChain,
+			tokenInfo: TokenInfo{AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775b"},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid, evm address on a non-evm feed chain",
+			feedChain: otherChain,
+			tokenInfo: TokenInfo{AggregatorAddress: evmAddress},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid, feed address",
+			feedChain: evmChain,
+			tokenInfo: TokenInfo{
+				AggregatorAddress: evmAddress,
+				Feeds:             []PriceFeed{{ChainSelector: otherChain, AggregatorAddress: "0x3e03"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "valid, derived price",
+			feedChain: otherChain,
+			tokenInfo: TokenInfo{Derived: &DerivedPrice{
+				Feeds: []PriceFeed{{AggregatorAddress: otherAddress}},
+				Rates: []RateProvider{{ChainSelector: evmChain, Address: evmAddress}},
+			}},
+		},
+		{
+			name:      "invalid, derived price rate address",
+			feedChain: evmChain,
+			tokenInfo: TokenInfo{Derived: &DerivedPrice{
+				Feeds: []PriceFeed{{AggregatorAddress: evmAddress}},
+				Rates: []RateProvider{{Address: "0x2e03"}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := CommitOffchainConfig{
+				PriceFeedChainSelector: tt.feedChain,
+				TokenInfo:              map[cciptypes.UnknownEncodedAddress]TokenInfo{"0x1": tt.tokenInfo},
+			}
+			err := cfg.ValidateFeedAddresses(testAddressCodec{})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestCommitOffchainConfig_Validate(t *testing.T) {
 	type fields struct {
 		RemoteGasPriceBatchWriteFrequency  commonconfig.Duration
@@ -134,6 +490,7 @@ func TestCommitOffchainConfig_Validate(t *testing.T) {
 		TokenPriceAsyncObservedDisabled    bool
 		TokenPriceAsyncObserverSyncFreq    commonconfig.Duration
 		TokenPriceAsyncObserverSyncTimeout commonconfig.Duration
+		ChainFeeMaxChangePPB               map[cciptypes.ChainSelector]uint64
 	}
 	remoteTokenAddress := rand.RandomAddress()
 	aggregatorAddress := rand.RandomAddress()
@@ -187,6 +544,38 @@ func TestCommitOffchainConfig_Validate(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"valid, chain fee max change",
+			fields{
+				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(1),
+				NewMsgScanBatchSize:                256,
+				MaxReportTransmissionCheckAttempts: 10,
+				MaxMerkleTreeSize:                  1000,
+				SignObservationPrefix:              defaultSignObservationPrefix,
+				MerkleRootAsyncObserverSyncTimeout: defaultAsyncObserverSyncTimeout,
+				MerkleRootAsyncObserverSyncFreq:    defaultAsyncObserverSyncFreq,
+				ChainFeeAsyncObserverSyncFreq:      defaultAsyncObserverSyncFreq,
+				ChainFeeAsyncObserverSyncTimeout:   defaultAsyncObserverSyncTimeout,
+				ChainFeeMaxChangePPB:               map[cciptypes.ChainSelector]uint64{1: 5e8},
+			},
+			false,
+		},
+		{
+			"invalid, zero chain fee max change",
+			fields{
+				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(1),
+				NewMsgScanBatchSize:                256,
+				MaxReportTransmissionCheckAttempts: 10,
+				MaxMerkleTreeSize:                  1000,
+				SignObservationPrefix:              defaultSignObservationPrefix,
+				MerkleRootAsyncObserverSyncTimeout: defaultAsyncObserverSyncTimeout,
+				MerkleRootAsyncObserverSyncFreq:    defaultAsyncObserverSyncFreq,
+				ChainFeeAsyncObserverSyncFreq:      defaultAsyncObserverSyncFreq,
+				ChainFeeAsyncObserverSyncTimeout:   defaultAsyncObserverSyncTimeout,
+				ChainFeeMaxChangePPB:               map[cciptypes.ChainSelector]uint64{1: 0},
+			},
+			true,
+		},
 		{
 			"invalid, sync freq set to 0",
 			fields{
@@ -304,6 +693,7 @@ func TestCommitOffchainConfig_Validate(t *testing.T) {
 				ChainFeeAsyncObserverDisabled:      tt.fields.ChainFeeAsyncObserverDisabled,
 				ChainFeeAsyncObserverSyncFreq:      tt.fields.ChainFeeAsyncObserverSyncFreq,
 				ChainFeeAsyncObserverSyncTimeout:   tt.fields.ChainFeeAsyncObserverSyncTimeout,
+				ChainFeeMaxChangePPB:               tt.fields.ChainFeeMaxChangePPB,
 			}
 			err := c.Validate()
 			if tt.wantErr {
@@ -387,6 +777,7 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 				RMNSignaturesTimeout:               0,
 				NewMsgScanBatchSize:                defaultNewMsgScanBatchSize,
 				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
+				ReportTransmissionLookback:         defaultReportTransmissionLookback,
 				MaxMerkleTreeSize:                  defaultEvmDefaultMaxMerkleTreeSize,
 				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(defaultRemoteGasPriceBatchWriteFrequency),
 				SignObservationPrefix:              defaultSignObservationPrefix,
@@ -413,6 +804,7 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 				RMNSignaturesTimeout:               defaultRMNSignaturesTimeout,
 				NewMsgScanBatchSize:                defaultNewMsgScanBatchSize,
 				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
+				ReportTransmissionLookback:         defaultReportTransmissionLookback,
 				MaxMerkleTreeSize:                  defaultEvmDefaultMaxMerkleTreeSize,
 				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(defaultRemoteGasPriceBatchWriteFrequency),
 				SignObservationPrefix:              defaultSignObservationPrefix,
@@ -432,6 +824,7 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 				RMNSignaturesTimeout:               5 * time.Minute,
 				NewMsgScanBatchSize:                500,
 				MaxReportTransmissionCheckAttempts: 10,
+				ReportTransmissionLookback:         5 * time.Minute,
 				MaxMerkleTreeSize:                  1000,
 				TransmissionDelayMultiplier:        20,
 				InflightPriceCheckRetries:          5,
@@ -444,6 +837,7 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 				RMNSignaturesTimeout:               5 * time.Minute,
 				NewMsgScanBatchSize:                500,
 				MaxReportTransmissionCheckAttempts: 10,
+				ReportTransmissionLookback:         5 * time.Minute,
 				MaxMerkleTreeSize:                  1000,
 				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(defaultRemoteGasPriceBatchWriteFrequency),
 				SignObservationPrefix:              defaultSignObservationPrefix,
@@ -471,6 +865,7 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 				RMNSignaturesTimeout:               defaultRMNSignaturesTimeout,
 				NewMsgScanBatchSize:                300,
 				MaxReportTransmissionCheckAttempts: defaultMaxReportTransmissionCheckAttempts,
+				ReportTransmissionLookback:         defaultReportTransmissionLookback,
 				MaxMerkleTreeSize:                  500,
 				RemoteGasPriceBatchWriteFrequency:  *commonconfig.MustNewDuration(defaultRemoteGasPriceBatchWriteFrequency),
 				SignObservationPrefix:              defaultSignObservationPrefix,
@@ -496,6 +891,27 @@ func TestCommitOffchainConfig_ApplyDefaults(t *testing.T) {
 }
 
 func TestCommitOffchainConfig_ApplyDefaultsAndValidate(t *testing.T) {
+	baseTokenInfo := TokenInfo{
+		AggregatorAddress: "0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2",
+		DeviationPPB:      cciptypes.NewBigIntFromInt64(1),
+		Decimals:          18,
+	}
+	reportTokenInfo := TokenInfo{
+		DeviationPPB: cciptypes.NewBigIntFromInt64(1),
+		Decimals:     18,
+		ReportFeedID: cciptypes.Bytes32{0x00, 0x03, 0x01},
+	}
+	derivedTokenInfo := func(baseToken cciptypes.UnknownEncodedAddress) TokenInfo {
+		return TokenInfo{
+			DeviationPPB: cciptypes.NewBigIntFromInt64(1),
+			Decimals:     18,
+			Derived: &DerivedPrice{
+				BaseToken: baseToken,
+				Rates:     []RateProvider{{Address: "0x4444444444444444444444Ff18C45Df59775Fbb2"}},
+			},
+		}
+	}
+
 	tests := []struct {
 		name          string
 		input         CommitOffchainConfig
@@ -524,6 +940,68 @@ func TestCommitOffchainConfig_ApplyDefaultsAndValidate(t *testing.T) {
 				SignObservationPrefix:              defaultSignObservationPrefix,
 			},
 		},
+		{
+			name: "Config with a derived token price validates successfully",
+			input: CommitOffchainConfig{
+				TokenPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Minute),
+				PriceFeedChainSelector:        1,
+				TokenInfo: map[cciptypes.UnknownEncodedAddress]TokenInfo{
+					"0x1111111111111111111111Ff18C45Df59775Fbb2": baseTokenInfo,
+					"0x2222222222222222222222Ff18C45Df59775Fbb2": derivedTokenInfo("0x1111111111111111111111Ff18C45Df59775Fbb2"),
+				},
+			},
+		},
+		{
+			name: "Config with a derived token price without base token info fails",
+			input: CommitOffchainConfig{
+				TokenPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Minute),
+				PriceFeedChainSelector:        1,
+				TokenInfo: map[cciptypes.UnknownEncodedAddress]TokenInfo{
+					"0x2222222222222222222222Ff18C45Df59775Fbb2": derivedTokenInfo("0x1111111111111111111111Ff18C45Df59775Fbb2"),
+				},
+			},
+			expectedError: "has no token info",
+		},
+		{
+			name: "Config with a derived token price from a derived base token fails",
+			input: CommitOffchainConfig{
+				TokenPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Minute),
+				PriceFeedChainSelector:        1,
+				TokenInfo: map[cciptypes.UnknownEncodedAddress]TokenInfo{
+					"0x1111111111111111111111Ff18C45Df59775Fbb2": baseTokenInfo,
+					"0x2222222222222222222222Ff18C45Df59775Fbb2": derivedTokenInfo("0x1111111111111111111111Ff18C45Df59775Fbb2"),
+					"0x3333333333333333333333Ff18C45Df59775Fbb2": derivedTokenInfo("0x2222222222222222222222Ff18C45Df59775Fbb2"),
+				},
+			},
+			expectedError: "must not be derived",
+		},
+		{
+			name: "Config with a price report source validates successfully",
+			input: CommitOffchainConfig{
+				TokenPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Minute),
+				PriceFeedChainSelector:        1,
+				TokenInfo: map[cciptypes.UnknownEncodedAddress]TokenInfo{
+					"0x1111111111111111111111Ff18C45Df59775Fbb2": reportTokenInfo,
+				},
+				PriceReportSource: &PriceReportSource{
+					URL:          "https://reports.example.com",
+					Signers:      []cciptypes.UnknownEncodedAddress{"0x2e03388D351BF87CF2409EFf18C45Df59775Fbb2"},
+					MinSigners:   1,
+					ConfigDigest: cciptypes.Bytes32{0x00, 0x01},
+				},
+			},
+		},
+		{
+			name: "Config with a report feed ID without price report source fails",
+			input: CommitOffchainConfig{
+				TokenPriceBatchWriteFrequency: *commonconfig.MustNewDuration(time.Minute),
+				PriceFeedChainSelector:        1,
+				TokenInfo: map[cciptypes.UnknownEncodedAddress]TokenInfo{
+					"0x1111111111111111111111Ff18C45Df59775Fbb2": reportTokenInfo,
+				},
+			},
+			expectedError: "set without priceReportSource",
+		},
 	}
 
 	for _, tt := range tests {
@@ -543,6 +1021,9 @@ func TestCommitOffchainConfig_ApplyDefaultsAndValidate(t *testing.T) {
 				assert.NotZero(t, config.MaxReportTransmissionCheckAttempts)
 				assert.NotZero(t, config.MaxMerkleTreeSize)
 				assert.NotZero(t, config.SignObservationPrefix)
+				if config.PriceReportSource != nil {
+					assert.Equal(t, defaultPriceReportTimeout, config.PriceReportSource.Timeout.Duration())
+				}
 
 				// Check specific defaults
 				if !tt.input.RMNEnabled {
@@ -0,0 +1,136 @@
+package pluginconfig
+
+import (
+	"errors"
+	"fmt"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+// FeedAggregation selects how the prices of the feeds of a token are combined into a single price.
+type FeedAggregation string
+
+const (
+	// FeedAggregationPrimaryWithFallback uses the price of the first feed that returned a valid price, in the order
+	// of TokenInfo.PriceFeeds. This is the default aggregation.
+	FeedAggregationPrimaryWithFallback FeedAggregation = "primaryWithFallback"
+	// FeedAggregationMedian uses the median of the valid feed prices, the average of the two middle prices when
+	// their number is even.
+	FeedAggregationMedian FeedAggregation = "median"
+	// FeedAggregationMin uses the lowest of the valid feed prices.
+	FeedAggregationMin FeedAggregation = "min"
+)
+
+func (a FeedAggregation) Validate() error {
+	switch a {
+	case "", FeedAggregationPrimaryWithFallback, FeedAggregationMedian, FeedAggregationMin:
+		return nil
+	default:
+		return errors.New("unknown feed aggregation " + string(a))
+	}
+}
+
+// PriceFeed is a TOKEN/USD price feed aggregator, e.g. an AggregatorV3Interface contract on EVM chains. Its address is
+// validated with the address codec of its chain, see CommitOffchainConfig.ValidateFeedAddresses.
+type PriceFeed struct {
+	// ChainSelector is the chain of the aggregator, CommitOffchainConfig.PriceFeedChainSelector if not set.
+	ChainSelector cciptypes.ChainSelector `json:"chainSelector"`
+
+	// AggregatorAddress is the address of the price feed TOKEN/USD aggregator.
+	AggregatorAddress cciptypes.UnknownEncodedAddress `json:"aggregatorAddress"`
+}
+
+func (f PriceFeed) Validate() error {
+	return validateAggregatorAddress(f.AggregatorAddress)
+}
+
+// RateProvider is a contract returning the exchange rate of a token to another asset with 18 decimals from its
+// getRate method, e.g. the stETH amount of one wstETH.
+type RateProvider struct {
+	// ChainSelector is the chain of the contract, CommitOffchainConfig.PriceFeedChainSelector if not set.
+	ChainSelector cciptypes.ChainSelector `json:"chainSelector"`
+
+	// Address is the address of the rate provider contract.
+	Address cciptypes.UnknownEncodedAddress `json:"address"`
+}
+
+func (r RateProvider) Validate() error {
+	if r.Address == "" {
+		return errors.New("address not set")
+	}
+	return nil
+}
+
+// DerivedPrice defines the USD price of a token without a TOKEN/USD feed as the product of other prices:
+//
+//	price = price(BaseToken) * answer(Feeds[0]) * ... * rate(Rates[0]) * ...
+//
+// For example price(wstETH) = price(stETH) * rate(wstETH), or price(TOKEN) = answer(TOKEN/ETH) * answer(ETH/USD).
+type DerivedPrice struct {
+	// BaseToken is a token with its own USD feeds, its USD price of a full token is the first factor of the price.
+	// Optional when Feeds are set.
+	BaseToken cciptypes.UnknownEncodedAddress `json:"baseToken"`
+
+	// Feeds are aggregators whose answers are multiplied, e.g. TOKEN/ETH and ETH/USD.
+	Feeds []PriceFeed `json:"feeds"`
+
+	// Rates are the rate providers whose rates are multiplied.
+	Rates []RateProvider `json:"rates"`
+}
+
+// WithChain returns the derived price with the chain of its feeds and rates set to feedChain when not set.
+func (d DerivedPrice) WithChain(feedChain cciptypes.ChainSelector) DerivedPrice {
+	feeds := make([]PriceFeed, len(d.Feeds))
+	for i, feed := range d.Feeds {
+		if feed.ChainSelector == 0 {
+			feed.ChainSelector = feedChain
+		}
+		feeds[i] = feed
+	}
+	rates := make([]RateProvider, len(d.Rates))
+	for i, rate := range d.Rates {
+		if rate.ChainSelector == 0 {
+			rate.ChainSelector = feedChain
+		}
+		rates[i] = rate
+	}
+	return DerivedPrice{BaseToken: d.BaseToken, Feeds: feeds, Rates: rates}
+}
+
+func (d DerivedPrice) Validate() error {
+	if d.BaseToken == "" && len(d.Feeds) == 0 {
+		return errors.New("baseToken or at least one feed must be set")
+	}
+
+	for i, feed := range d.Feeds {
+		if err := feed.Validate(); err != nil {
+			return fmt.Errorf("invalid feed %d: %w", i, err)
+		}
+	}
+
+	for i, rate := range d.Rates {
+		if err := rate.Validate(); err != nil {
+			return fmt.Errorf("invalid rate %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateAggregatorAddress(addr cciptypes.UnknownEncodedAddress) error {
+	if addr == "" {
+		return errors.New("aggregatorAddress not set")
+	}
+	return nil
+}
+
+// validateAddress checks an address of a feed chain with the address codec of its chain family.
+func validateAddress(
+	addrCodec cciptypes.AddressCodec,
+	chain cciptypes.ChainSelector,
+	addr cciptypes.UnknownEncodedAddress,
+) error {
+	if _, err := addrCodec.AddressStringToBytes(string(addr), chain); err != nil {
+		return fmt.Errorf("invalid address %s on chain %d: %w", addr, chain, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package pluginconfig
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+
+	cciptypes "github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
+)
+
+const defaultPriceReportTimeout = 5 * time.Second
+
+// PriceReportSource is an offchain source of signed price reports, in the Data Streams report format (v3 schema)
+// with the prices of full tokens in USD with 18 decimals. The price report of a token is its primary price, see
+// TokenInfo.ReportFeedID.
+type PriceReportSource struct {
+	// URL is the base URL of the report server.
+	URL string `json:"url"`
+
+	// Signers are the ethereum addresses of the keys signing the reports.
+	Signers []cciptypes.UnknownEncodedAddress `json:"signers"`
+
+	// MinSigners is the number of distinct signers of a report required for it to be valid, i.e. f+1.
+	MinSigners int `json:"minSigners"`
+
+	// ConfigDigest is the config digest of the DON signing the reports, the first field of the signed report context.
+	// Reports signed under another config are rejected, e.g. by the same keys for another DON or an older config.
+	ConfigDigest cciptypes.Bytes32 `json:"configDigest"`
+
+	// Timeout is the timeout of the requests to the report server, 5s if not set.
+	Timeout commonconfig.Duration `json:"timeout"`
+}
+
+func (s PriceReportSource) Validate() error {
+	if _, err := url.ParseRequestURI(s.URL); err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	if len(s.Signers) == 0 {
+		return errors.New("signers not set")
+	}
+	seen := make(map[string]struct{}, len(s.Signers))
+	for _, signer := range s.Signers {
+		// the reports are signed with ethereum keys whatever the chain families of the DON
+		decoded, err := hex.DecodeString(strings.TrimPrefix(string(signer), "0x"))
+		if err != nil || len(decoded) != 20 {
+			return fmt.Errorf("signer %s must be a valid ethereum address (i.e hex encoded 20 bytes)", signer)
+		}
+		if _, ok := seen[string(decoded)]; ok {
+			return fmt.Errorf("duplicate signer %s", signer)
+		}
+		seen[string(decoded)] = struct{}{}
+	}
+
+	if s.MinSigners <= 0 || s.MinSigners > len(s.Signers) {
+		return fmt.Errorf("minSigners (%d) must be positive and at most the number of signers (%d)",
+			s.MinSigners, len(s.Signers))
+	}
+
+	if s.ConfigDigest.IsEmpty() {
+		return errors.New("configDigest not set")
+	}
+	return nil
+}
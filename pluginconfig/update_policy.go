@@ -0,0 +1,67 @@
+package pluginconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UpdatePolicyType selects how the commit plugin decides that a token price or a chain fee has to be written.
+type UpdatePolicyType string
+
+const (
+	// UpdatePolicyHeartbeatDeviation writes a value when its heartbeat passed or when the observed value deviates
+	// from the onchain value by more than the configured threshold. This is the default policy.
+	UpdatePolicyHeartbeatDeviation UpdatePolicyType = "heartbeatDeviation"
+	// UpdatePolicyTimeWeighted is like UpdatePolicyHeartbeatDeviation but the deviation threshold decreases linearly
+	// with the time passed since the last update, reaching zero when the heartbeat passes.
+	UpdatePolicyTimeWeighted UpdatePolicyType = "timeWeighted"
+	// UpdatePolicyEWMA is like UpdatePolicyHeartbeatDeviation but the deviation is computed on an exponentially
+	// weighted moving average of the observed values, so that short-lived spikes do not trigger updates.
+	// The average is updated once per OCR round in which the values are selected, not per unit of time, so the
+	// smoothing period is a number of rounds and depends on the round interval of the DON.
+	UpdatePolicyEWMA UpdatePolicyType = "ewma"
+)
+
+const maxEWMAAlphaPPB = 1e9
+
+// MaxMaxUpdatesPerHour is the largest MaxUpdatesPerHour, the time of every update selected within the hour is
+// carried in the outcomes.
+const MaxMaxUpdatesPerHour = 16_384
+
+// UpdatePolicyConfig configures the policy used to select the token prices or the chain fees to write onchain.
+type UpdatePolicyConfig struct {
+	// Type is the policy type, UpdatePolicyHeartbeatDeviation if not set.
+	Type UpdatePolicyType `json:"type"`
+
+	// EWMAAlphaPPB is the weight, in parts per billion, of the latest observed value in the moving average.
+	// Only used by UpdatePolicyEWMA, where it must be in (0, 1e9].
+	EWMAAlphaPPB int64 `json:"ewmaAlphaPPB"`
+
+	// MaxUpdatesPerHour caps the number of values selected for update within any rolling hour, at most
+	// MaxMaxUpdatesPerHour. Values that have never been written go first, then values whose heartbeat passed, then
+	// the ones that deviate, the least recently updated first. Disable by setting to 0.
+	// The budget counts the values selected in the outcomes, a value whose report is not transmitted still uses the
+	// budget, so the onchain updates are at most MaxUpdatesPerHour but may be fewer.
+	MaxUpdatesPerHour uint64 `json:"maxUpdatesPerHour"`
+}
+
+func (c UpdatePolicyConfig) Validate() error {
+	switch c.Type {
+	case "", UpdatePolicyHeartbeatDeviation, UpdatePolicyTimeWeighted:
+		if c.EWMAAlphaPPB != 0 {
+			return fmt.Errorf("ewmaAlphaPPB can only be used with the %s policy", UpdatePolicyEWMA)
+		}
+	case UpdatePolicyEWMA:
+		if c.EWMAAlphaPPB <= 0 || c.EWMAAlphaPPB > maxEWMAAlphaPPB {
+			return fmt.Errorf("ewmaAlphaPPB must be in (0, %d], got %d", int64(maxEWMAAlphaPPB), c.EWMAAlphaPPB)
+		}
+	default:
+		return errors.New("unknown update policy type " + string(c.Type))
+	}
+
+	if c.MaxUpdatesPerHour > MaxMaxUpdatesPerHour {
+		return fmt.Errorf("maxUpdatesPerHour must be at most %d, got %d", MaxMaxUpdatesPerHour, c.MaxUpdatesPerHour)
+	}
+
+	return nil
+}
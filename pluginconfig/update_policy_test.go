@@ -0,0 +1,38 @@
+package pluginconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatePolicyConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cfg    UpdatePolicyConfig
+		expErr bool
+	}{
+		{name: "default", cfg: UpdatePolicyConfig{}},
+		{name: "heartbeat deviation with budget", cfg: UpdatePolicyConfig{
+			Type: UpdatePolicyHeartbeatDeviation, MaxUpdatesPerHour: 10}},
+		{name: "budget too large", cfg: UpdatePolicyConfig{MaxUpdatesPerHour: MaxMaxUpdatesPerHour + 1}, expErr: true},
+		{name: "time weighted", cfg: UpdatePolicyConfig{Type: UpdatePolicyTimeWeighted}},
+		{name: "ewma", cfg: UpdatePolicyConfig{Type: UpdatePolicyEWMA, EWMAAlphaPPB: 1e9}},
+		{name: "ewma without alpha", cfg: UpdatePolicyConfig{Type: UpdatePolicyEWMA}, expErr: true},
+		{name: "ewma alpha too large", cfg: UpdatePolicyConfig{Type: UpdatePolicyEWMA, EWMAAlphaPPB: 1e9 + 1},
+			expErr: true},
+		{name: "alpha without ewma", cfg: UpdatePolicyConfig{EWMAAlphaPPB: 1}, expErr: true},
+		{name: "unknown type", cfg: UpdatePolicyConfig{Type: "fancy"}, expErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}